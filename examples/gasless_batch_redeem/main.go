@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
@@ -97,6 +98,8 @@ func main() {
 	if err != nil {
 		log.Fatalf("创建客户端失败: %v", err)
 	}
+	// 输出交易提交、上链等事件
+	client.SetLogger(slog.Default())
 
 	proxyAddress := client.Address.Hex()
 	fmt.Printf("Proxy Wallet: %s\n", proxyAddress)
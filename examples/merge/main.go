@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"strconv"
 
@@ -80,6 +81,8 @@ func main() {
 	if err != nil {
 		log.Fatalf("创建客户端失败: %v", err)
 	}
+	// 输出交易提交、上链等事件
+	client.SetLogger(slog.Default())
 
 	fmt.Printf("✓ 客户端创建成功\n")
 	fmt.Printf("  Address: %s\n", client.Address.Hex())
@@ -37,16 +37,14 @@ func main() {
 	if signatureTypeStr != "" {
 		fmt.Sscanf(signatureTypeStr, "%d", &signatureType)
 	}
-	sigTypePtr := &signatureType
 
 	// 创建客户端
 	client, err := polymarket.NewClobClient(
 		host,
 		chainID,
-		privateKey,
-		nil,        // 初始时没有API凭证
-		sigTypePtr, // 签名类型（0=EOA, 1=Magic/Email, 2=Browser proxy）
-		funder,
+		polymarket.WithPrivateKey(privateKey),
+		polymarket.WithSignatureType(signatureType), // 签名类型（0=EOA, 1=Magic/Email, 2=Browser proxy）
+		polymarket.WithFunder(funder),
 	)
 	if err != nil {
 		log.Fatalf("创建客户端失败: %v", err)
@@ -0,0 +1,288 @@
+// Package cassette 录制并回放 CLOB 与中继的 HTTP 交互，用于离线测试策略
+//
+// 录制器和回放器都是传输层中间件，可通过 ClobClient.Use / WithMiddleware
+// 以及 PolymarketGaslessWeb3Client.Use 安装：
+//
+//	c := cassette.New()
+//	client.Use(cassette.Record(c))
+//	... // 发送真实请求
+//	c.Save("testdata/session.json")
+//
+//	c, _ = cassette.Load("testdata/session.json")
+//	client.Use(cassette.Replay(c))
+//
+// 录制时 POLY_* 认证头会被脱敏；请求匹配时忽略时间戳、salt 和签名等每次都会变化的字段
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+)
+
+// ErrNoInteraction 回放时找不到匹配的录制记录
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// DefaultIgnoredFields 匹配时忽略的 JSON 字段和查询参数（每次签名都会变化）
+var DefaultIgnoredFields = []string{
+	"salt",
+	"signature",
+	"timestamp",
+}
+
+// Interaction 一次录制的请求/响应
+type Interaction struct {
+	Method          string            `json:"method"`
+	Host            string            `json:"host"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	StatusCode      int               `json:"status_code"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body"`
+}
+
+// Cassette 录制的交互列表（并发安全）
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+
+	// IgnoredFields 匹配时忽略的 JSON 字段和查询参数，默认为 DefaultIgnoredFields
+	IgnoredFields []string `json:"-"`
+	// AllowRepeat 回放时允许重复使用已回放过的记录（用于轮询类请求）
+	AllowRepeat bool `json:"-"`
+
+	mu   sync.Mutex
+	used []bool
+}
+
+// New 创建空的 Cassette
+func New() *Cassette {
+	return &Cassette{IgnoredFields: DefaultIgnoredFields}
+}
+
+// Load 从 JSON 文件加载 Cassette
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	c := New()
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette: %w", err)
+	}
+	return c, nil
+}
+
+// Save 将 Cassette 保存为 JSON 文件
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Record 返回录制中间件：请求照常发送，同时记录请求和响应
+func Record(c *Cassette) polymarket.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return polymarket.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body, req, err := readRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("cassette: failed to read response: %w", err)
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+			c.mu.Lock()
+			c.Interactions = append(c.Interactions, &Interaction{
+				Method:          req.Method,
+				Host:            req.URL.Host,
+				Path:            req.URL.Path,
+				Query:           c.normalizeQuery(req.URL.RawQuery),
+				RequestHeaders:  polymarket.RedactHeaders(req.Header),
+				RequestBody:     c.normalizeBody(body),
+				StatusCode:      resp.StatusCode,
+				ResponseHeaders: flattenHeaders(resp.Header),
+				ResponseBody:    string(respBody),
+			})
+			c.mu.Unlock()
+
+			return resp, nil
+		})
+	}
+}
+
+// Replay 返回回放中间件：不发送网络请求，按方法、主机、路径、查询参数和规范化后的请求体
+// 查找第一条未使用的匹配记录；找不到时返回 ErrNoInteraction
+func Replay(c *Cassette) polymarket.Middleware {
+	return func(http.RoundTripper) http.RoundTripper {
+		return polymarket.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body, req, err := readRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+
+			in, ok := c.match(req, c.normalizeBody(body))
+			if !ok {
+				return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.Path)
+			}
+
+			header := make(http.Header, len(in.ResponseHeaders))
+			for k, v := range in.ResponseHeaders {
+				header.Set(k, v)
+			}
+			return &http.Response{
+				Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+				StatusCode:    in.StatusCode,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        header,
+				Body:          io.NopCloser(strings.NewReader(in.ResponseBody)),
+				ContentLength: int64(len(in.ResponseBody)),
+				Request:       req,
+			}, nil
+		})
+	}
+}
+
+// match 查找匹配的记录
+func (c *Cassette) match(req *http.Request, body string) (*Interaction, bool) {
+	query := c.normalizeQuery(req.URL.RawQuery)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.used) < len(c.Interactions) {
+		c.used = append(c.used, make([]bool, len(c.Interactions)-len(c.used))...)
+	}
+
+	last := -1
+	for i, in := range c.Interactions {
+		if in.Method != req.Method || in.Host != req.URL.Host || in.Path != req.URL.Path ||
+			in.Query != query || in.RequestBody != body {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return in, true
+		}
+		last = i
+	}
+	if c.AllowRepeat && last >= 0 {
+		return c.Interactions[last], true
+	}
+	return nil, false
+}
+
+// ignored 返回需要忽略的字段集合
+func (c *Cassette) ignored() map[string]bool {
+	fields := c.IgnoredFields
+	if fields == nil {
+		fields = DefaultIgnoredFields
+	}
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[strings.ToLower(f)] = true
+	}
+	return set
+}
+
+// normalizeQuery 去掉忽略的参数并按键排序
+func (c *Cassette) normalizeQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	ignored := c.ignored()
+	for k := range values {
+		if ignored[strings.ToLower(k)] {
+			values.Del(k)
+		}
+	}
+	return values.Encode()
+}
+
+// normalizeBody 将 JSON 请求体规范化（键排序、去掉忽略的字段），非 JSON 请求体原样返回
+func (c *Cassette) normalizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	normalized, err := json.Marshal(stripFields(v, c.ignored()))
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
+
+// stripFields 递归删除忽略的字段
+func stripFields(v interface{}, ignored map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if ignored[strings.ToLower(k)] {
+				delete(t, k)
+				continue
+			}
+			t[k] = stripFields(child, ignored)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = stripFields(child, ignored)
+		}
+	}
+	return v
+}
+
+// readRequestBody 读取请求体，并返回可以继续发送的请求副本
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cassette: failed to read request body: %w", err)
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	return body, clone, nil
+}
+
+// flattenHeaders 将响应头转换为 map（多值以逗号连接）
+func flattenHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
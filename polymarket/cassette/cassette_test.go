@@ -0,0 +1,265 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+	"github.com/wimgithub/Polymarket-golang/polymarket/web3"
+)
+
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// authHeaders 每次请求都不同的认证头
+func authHeaders(signature string) map[string]string {
+	return map[string]string{
+		polymarket.PolyAPIKey:     "api-key-secret",
+		polymarket.PolyPassphrase: "passphrase-secret",
+		polymarket.PolySignature:  signature,
+		polymarket.PolyTimestamp:  "1700000000",
+	}
+}
+
+func newClient(baseURL string, middleware polymarket.Middleware) *polymarket.HTTPClient {
+	c := polymarket.NewHTTPClient(baseURL)
+	c.SetRetryPolicy(nil)
+	c.Use(middleware)
+	return c
+}
+
+func TestRecordReplayHTTPClient(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/data/trades":
+			w.Write([]byte(`{"data":[{"id":"t1"}],"next_cursor":"LTE="}`))
+		case "/order":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"salt":111`) {
+				t.Errorf("recorded request body not forwarded: %s", body)
+			}
+			w.Write([]byte(`{"success":true,"orderID":"0xorder"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	// 录制
+	c := New()
+	recorder := newClient(server.URL, Record(c))
+	if _, err := recorder.RequestWithContext(ctx, "GET", "/data/trades?market=0xabc&timestamp=1700000000&next_cursor=MA%3D%3D", authHeaders("sig-1"), nil); err != nil {
+		t.Fatal(err)
+	}
+	order := `{"order":{"salt":111,"maker":"0x1","signature":"0xaaaa","timestamp":"1700000000"},"owner":"key","orderType":"GTC"}`
+	if _, err := recorder.RequestWithContext(ctx, "POST", "/order", authHeaders("sig-2"), order); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// 保存的文件中不含认证信息
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"api-key-secret", "passphrase-secret", "sig-1", "sig-2"} {
+		if strings.Contains(string(raw), secret) {
+			t.Fatalf("cassette contains %q", secret)
+		}
+	}
+	if !strings.Contains(string(raw), "[REDACTED]") {
+		t.Fatal("auth headers not redacted")
+	}
+
+	// 请求体和查询参数已规范化
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Interactions) != 2 {
+		t.Fatalf("loaded %d interactions, want 2", len(loaded.Interactions))
+	}
+	if q := loaded.Interactions[0].Query; q != "market=0xabc&next_cursor=MA%3D%3D" {
+		t.Fatalf("query not normalized: %s", q)
+	}
+	if b := loaded.Interactions[1].RequestBody; b != `{"order":{"maker":"0x1"},"orderType":"GTC","owner":"key"}` {
+		t.Fatalf("body not normalized: %s", b)
+	}
+
+	// 回放：不访问网络，salt、签名、时间戳和字段顺序不同仍能匹配
+	replayer := newClient(server.URL, Replay(loaded))
+	resp, err := replayer.RequestWithContext(ctx, "GET", "/data/trades?next_cursor=MA%3D%3D&timestamp=1800000000&market=0xabc", authHeaders("sig-3"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data := resp.(map[string]interface{})["data"].([]interface{}); data[0].(map[string]interface{})["id"] != "t1" {
+		t.Fatalf("unexpected replayed response: %v", resp)
+	}
+	order = `{"owner":"key", "orderType":"GTC", "order":{"signature":"0xbbbb","maker":"0x1","salt":222,"timestamp":"1800000000"}}`
+	resp, err = replayer.RequestWithContext(ctx, "POST", "/order", authHeaders("sig-4"), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.(map[string]interface{})["orderID"] != "0xorder" {
+		t.Fatalf("unexpected replayed response: %v", resp)
+	}
+	if hits.Load() != 2 {
+		t.Fatalf("replay reached the server: %d hits", hits.Load())
+	}
+
+	// 不匹配的请求明确失败
+	unmatched := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+	}{
+		{"different body", "POST", "/order", `{"order":{"maker":"0x2"},"owner":"key","orderType":"GTC"}`},
+		{"different query", "GET", "/data/trades?market=0xdef&next_cursor=MA%3D%3D", nil},
+		{"different method", "DELETE", "/order", nil},
+		{"already used", "GET", "/data/trades?market=0xabc&next_cursor=MA%3D%3D", nil},
+	}
+	for _, u := range unmatched {
+		_, err := replayer.RequestWithContext(ctx, u.method, u.path, nil, u.body)
+		if !errors.Is(err, ErrNoInteraction) {
+			t.Errorf("%s: expected ErrNoInteraction, got %v", u.name, err)
+		}
+	}
+
+	loaded.AllowRepeat = true
+	if _, err := replayer.RequestWithContext(ctx, "GET", "/data/trades?market=0xabc&next_cursor=MA%3D%3D", nil, nil); err != nil {
+		t.Fatalf("AllowRepeat: %v", err)
+	}
+}
+
+// fakeRPC 只实现中继流程需要的以太坊 JSON-RPC 方法
+func fakeRPC(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid rpc request: %v", err)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_call":
+			// 代理钱包地址
+			resp["result"] = "0x" + strings.Repeat("0", 24) + strings.Repeat("ab", 20)
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fakeRelay 代替网络的中继响应（位于录制器之后）
+func fakeRelay(hits *atomic.Int32) polymarket.Middleware {
+	return func(http.RoundTripper) http.RoundTripper {
+		return polymarket.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			hits.Add(1)
+			body := `{"error":"not found"}`
+			status := http.StatusNotFound
+			switch req.URL.Path {
+			case "/relay-payload":
+				body, status = `{"address":"0x7db63fe6d62eb73fb01f8009416f4c2bb4fbda6a","nonce":"7"}`, http.StatusOK
+			case "/submit":
+				body, status = `{"transactionID":"relay-1","state":"STATE_NEW"}`, http.StatusOK
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		})
+	}
+}
+
+func TestRecordReplayGaslessRelay(t *testing.T) {
+	rpc := fakeRPC(t)
+	builderCreds := &polymarket.ApiCreds{APIKey: "builder-key-secret", APISecret: "c2VjcmV0", APIPassphrase: "builder-pass-secret"}
+	newGasless := func() *web3.PolymarketGaslessWeb3Client {
+		client, err := web3.NewPolymarketGaslessWeb3Client(testPrivateKey, web3.SignatureTypePolyProxy, builderCreds, 137, rpc.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+	to := common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045")
+	data := common.FromHex("0x12345678")
+
+	// 录制：中继返回没有交易哈希的响应，Execute 在等待回执前结束
+	var hits atomic.Int32
+	c := New()
+	recorder := newGasless()
+	recorder.Use(Record(c), fakeRelay(&hits))
+	_, recordErr := recorder.Execute(to, data, "test", "meta")
+	if recordErr == nil || !strings.Contains(recordErr.Error(), "relay-1") {
+		t.Fatalf("unexpected record result: %v", recordErr)
+	}
+	if len(c.Interactions) != 2 || hits.Load() != 2 {
+		t.Fatalf("recorded %d interactions, %d relay hits", len(c.Interactions), hits.Load())
+	}
+
+	path := filepath.Join(t.TempDir(), "relay.json")
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"builder-key-secret", "builder-pass-secret"} {
+		if strings.Contains(string(raw), secret) {
+			t.Fatalf("cassette contains %q", secret)
+		}
+	}
+	if strings.Contains(c.Interactions[1].RequestBody, "signature\"") {
+		t.Fatalf("signature kept in normalized body: %s", c.Interactions[1].RequestBody)
+	}
+
+	// 回放：Builder 时间戳不同，不访问中继
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player := newGasless()
+	player.SetTimeSource(func() time.Time { return time.Unix(1900000000, 0) })
+	player.Use(Replay(loaded))
+	_, replayErr := player.Execute(to, data, "test", "meta")
+	if replayErr == nil || replayErr.Error() != recordErr.Error() {
+		t.Fatalf("replay result %v, want %v", replayErr, recordErr)
+	}
+
+	// 请求内容不同时回放失败（nonce 记录允许重复使用）
+	loaded.AllowRepeat = true
+	_, err = player.Execute(to, common.FromHex("0x87654321"), "test", "meta")
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("expected ErrNoInteraction, got %v", err)
+	}
+}
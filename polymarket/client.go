@@ -1,8 +1,10 @@
 package polymarket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
 
@@ -30,20 +32,33 @@ type ClobClient struct {
 	negRisk   map[string]bool
 	feeRates  map[string]int
 
+	// 代币元数据缓存（ResolveToken / ResolveOutcome）
+	tokenInfo       map[string]TokenInfo // token ID -> 元数据
+	conditionTokens map[string][]string  // condition ID -> token ID 列表
+	unresolved      map[string]bool      // 下单时解析失败的 token ID，不再重复解析
+	catalog         *MarketCatalog       // 可选，解析代币时优先查询
+
 	// RFQ客户端
 	rfq *rfq.RfqClient
 
+	// 服务器时钟偏差
+	clock serverClock
+
 	mu sync.RWMutex
 }
 
 // NewClobClient 创建新的CLOB客户端
 // host: CLOB API端点（如 "https://clob.polymarket.com"）
 // chainID: 链ID（137 for Polygon, 80002 for Amoy）
-// privateKey: 私钥（十六进制字符串，可选）
-// creds: API凭证（可选）
-// signatureType: 签名类型（0=EOA, 1=Email/Magic, 2=Browser proxy，可选）
-// funder: 资金持有者地址（用于代理钱包，可选）
-func NewClobClient(host string, chainID int, privateKey string, creds *ApiCreds, signatureType *int, funder string) (*ClobClient, error) {
+// opts: 可选配置，如 WithPrivateKey、WithCreds、WithSignatureType、WithFunder 等
+//
+// 不提供私钥/签名器时为 Level 0（只读）；提供私钥时为 Level 1；同时提供API凭证时为 Level 2
+func NewClobClient(host string, chainID int, opts ...ClientOption) (*ClobClient, error) {
+	cfg := &clientConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	// 移除host末尾的斜杠
 	if strings.HasSuffix(host, "/") {
 		host = host[:len(host)-1]
@@ -52,33 +67,37 @@ func NewClobClient(host string, chainID int, privateKey string, creds *ApiCreds,
 	client := &ClobClient{
 		host:       host,
 		chainID:    chainID,
-		creds:      creds,
-		httpClient: NewHTTPClient(host),
+		creds:      cfg.creds,
+		httpClient: newHTTPClientFromConfig(host, cfg),
 		tickSizes:  make(map[string]TickSize),
 		negRisk:    make(map[string]bool),
 		feeRates:   make(map[string]int),
+
+		tokenInfo:       make(map[string]TokenInfo),
+		conditionTokens: make(map[string][]string),
+		unresolved:      make(map[string]bool),
 	}
 
 	// 创建签名器（如果提供了私钥）
-	if privateKey != "" {
-		signer, err := NewSigner(privateKey, chainID)
+	signer := cfg.signer
+	if signer == nil && cfg.privateKey != "" {
+		var err error
+		signer, err = NewSigner(cfg.privateKey, chainID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create signer: %w", err)
 		}
+	}
+
+	if signer != nil {
 		client.signer = signer
 
 		// 创建订单构建器
-		sigType := 0 // 默认EOA
-		if signatureType != nil {
-			sigType = *signatureType
-		}
-
 		funderAddr := signer.Address()
-		if funder != "" {
-			funderAddr = funder
+		if cfg.funder != "" {
+			funderAddr = cfg.funder
 		}
 
-		builder, err := obuilder.NewOrderBuilder(signer, sigType, funderAddr)
+		builder, err := obuilder.NewOrderBuilder(signer, cfg.signatureType, funderAddr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create order builder: %w", err)
 		}
@@ -90,10 +109,33 @@ func NewClobClient(host string, chainID int, privateKey string, creds *ApiCreds,
 
 	// 创建RFQ客户端
 	client.rfq = rfq.NewRfqClient(client)
+	client.rfq.SetLogger(client.httpClient.Logger())
+
+	// 构造时不做网络请求，时间同步由调用方通过 StartTimeSync 启动
+	client.SetClockSkewHandler(cfg.onClockSkew)
 
 	return client, nil
 }
 
+// NewClobClientLegacy 使用位置参数创建CLOB客户端（兼容旧版本的 NewClobClient）
+// privateKey: 私钥（十六进制字符串，可选）
+// creds: API凭证（可选）
+// signatureType: 签名类型（0=EOA, 1=Email/Magic, 2=Browser proxy，可选）
+// funder: 资金持有者地址（用于代理钱包，可选）
+//
+// Deprecated: 使用 NewClobClient(host, chainID, opts...)
+func NewClobClientLegacy(host string, chainID int, privateKey string, creds *ApiCreds, signatureType *int, funder string) (*ClobClient, error) {
+	opts := []ClientOption{
+		WithPrivateKey(privateKey),
+		WithCreds(creds),
+		WithFunder(funder),
+	}
+	if signatureType != nil {
+		opts = append(opts, WithSignatureType(*signatureType))
+	}
+	return NewClobClient(host, chainID, opts...)
+}
+
 // getClientMode 获取客户端模式
 func (c *ClobClient) getClientMode() int {
 	if c.signer == nil {
@@ -189,6 +231,15 @@ func (c *ClobClient) GetRFQ() *rfq.RfqClient {
 
 // CreateLevel2HeadersInternal 创建L2认证头（供RFQ客户端使用，避免循环导入）
 func (c *ClobClient) CreateLevel2HeadersInternal(method, path string, body interface{}) (map[string]string, error) {
+	return c.CreateLevel2HeadersWithContext(context.Background(), method, path, body)
+}
+
+// CreateLevel2HeadersWithContext 创建L2认证头（支持context，ctx 已取消时不再签名）
+func (c *ClobClient) CreateLevel2HeadersWithContext(ctx context.Context, method, path string, body interface{}) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	var bodyStr string
 	if body != nil {
 		bodyJSON, err := json.Marshal(body)
@@ -205,7 +256,44 @@ func (c *ClobClient) CreateLevel2HeadersInternal(method, path string, body inter
 		SerializedBody: &bodyStr,
 	}
 
-	return CreateLevel2Headers(c.signer, c.creds, requestArgs)
+	return CreateLevel2HeadersAt(c.signer, c.creds, requestArgs, c.timestamp())
+}
+
+// l1Signer 返回生成L1认证头的函数，HTTP客户端在每次尝试（包括重试）前调用以刷新时间戳
+func (c *ClobClient) l1Signer(nonce *int) func() (map[string]string, error) {
+	return func() (map[string]string, error) {
+		return CreateLevel1HeadersAt(c.signer, nonce, c.timestamp())
+	}
+}
+
+// l2Signer 返回生成L2认证头的函数，HTTP客户端在每次尝试（包括重试）前调用以刷新时间戳
+func (c *ClobClient) l2Signer(requestArgs *RequestArgs) func() (map[string]string, error) {
+	return func() (map[string]string, error) {
+		return CreateLevel2HeadersAt(c.signer, c.creds, requestArgs, c.timestamp())
+	}
+}
+
+// SetRetryPolicy 设置HTTP请求重试策略，nil 表示不重试
+// 默认只重试幂等请求；如需在请求确定未到达服务器时重试下单/撤单，设置 RetryNonIdempotent
+func (c *ClobClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.httpClient.SetRetryPolicy(policy)
+}
+
+// SetRateLimiter 设置客户端限流器，nil 表示不限流
+// 使用同一 API Key 的多个 ClobClient 应共享同一个 RateLimiter
+func (c *ClobClient) SetRateLimiter(limiter *RateLimiter) {
+	c.httpClient.SetRateLimiter(limiter)
+}
+
+// SetLogger 设置日志记录器（HTTP请求、响应、重试以及RFQ事件），nil 表示不输出日志
+func (c *ClobClient) SetLogger(logger *slog.Logger) {
+	c.httpClient.SetLogger(logger)
+	c.rfq.SetLogger(c.httpClient.Logger())
+}
+
+// Use 在HTTP传输层追加中间件（RFQ客户端共享同一个HTTP客户端），应在发送请求前调用
+func (c *ClobClient) Use(middlewares ...Middleware) {
+	c.httpClient.Use(middlewares...)
 }
 
 // GetHost 获取host（供RFQ客户端使用）
@@ -223,8 +311,13 @@ func (c *ClobClient) GetAPICreds() string {
 
 // CreateOrderForRFQ 为RFQ创建签名订单（供RFQ客户端使用，避免循环导入）
 func (c *ClobClient) CreateOrderForRFQ(args *rfq.OrderCreationArgs) (*rfq.SignedOrderData, error) {
+	return c.CreateOrderForRFQWithContext(context.Background(), args)
+}
+
+// CreateOrderForRFQWithContext 为RFQ创建签名订单（支持context）
+func (c *ClobClient) CreateOrderForRFQWithContext(ctx context.Context, args *rfq.OrderCreationArgs) (*rfq.SignedOrderData, error) {
 	// 创建订单参数
-	orderArgs := &OrderArgs{
+	orderArgs := &DecimalOrderArgs{
 		TokenID:    args.TokenID,
 		Price:      args.Price,
 		Size:       args.Size,
@@ -233,7 +326,7 @@ func (c *ClobClient) CreateOrderForRFQ(args *rfq.OrderCreationArgs) (*rfq.Signed
 	}
 
 	// 创建签名订单
-	signedOrder, err := c.CreateOrder(orderArgs, nil)
+	signedOrder, err := c.CreateOrderDecimalWithContext(ctx, orderArgs, nil)
 	if err != nil {
 		return nil, err
 	}
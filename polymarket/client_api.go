@@ -1,34 +1,45 @@
 package polymarket
 
 import (
+	"context"
 	"fmt"
 )
 
 // GetOK 健康检查：确认服务器是否运行
 // 不需要认证
 func (c *ClobClient) GetOK() (interface{}, error) {
-	return c.httpClient.Get("/", nil)
+	return c.GetOKWithContext(context.Background())
+}
+
+// GetOKWithContext 健康检查（支持context）
+func (c *ClobClient) GetOKWithContext(ctx context.Context) (interface{}, error) {
+	return c.httpClient.GetWithContext(ctx, "/", nil)
 }
 
 // GetServerTime 返回服务器当前时间戳
 // 不需要认证
 func (c *ClobClient) GetServerTime() (interface{}, error) {
-	return c.httpClient.Get(Time, nil)
+	return c.GetServerTimeWithContext(context.Background())
+}
+
+// GetServerTimeWithContext 返回服务器当前时间戳（支持context）
+func (c *ClobClient) GetServerTimeWithContext(ctx context.Context) (interface{}, error) {
+	return c.httpClient.GetWithContext(ctx, Time, nil)
 }
 
 // CreateAPIKey 创建新的CLOB API密钥
 // 需要L1认证
 func (c *ClobClient) CreateAPIKey(nonce *int) (*ApiCreds, error) {
-	if err := c.assertLevel1Auth(); err != nil {
-		return nil, err
-	}
+	return c.CreateAPIKeyWithContext(context.Background(), nonce)
+}
 
-	headers, err := CreateLevel1Headers(c.signer, nonce)
-	if err != nil {
+// CreateAPIKeyWithContext 创建新的CLOB API密钥（支持context）
+func (c *ClobClient) CreateAPIKeyWithContext(ctx context.Context, nonce *int) (*ApiCreds, error) {
+	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Post(CreateAPIKey, headers, nil)
+	resp, err := c.httpClient.SignedRequestWithContext(ctx, "POST", CreateAPIKey, nil, c.l1Signer(nonce))
 	if err != nil {
 		return nil, err
 	}
@@ -53,16 +64,16 @@ func (c *ClobClient) CreateAPIKey(nonce *int) (*ApiCreds, error) {
 // DeriveAPIKey 派生已存在的CLOB API密钥
 // 需要L1认证
 func (c *ClobClient) DeriveAPIKey(nonce *int) (*ApiCreds, error) {
-	if err := c.assertLevel1Auth(); err != nil {
-		return nil, err
-	}
+	return c.DeriveAPIKeyWithContext(context.Background(), nonce)
+}
 
-	headers, err := CreateLevel1Headers(c.signer, nonce)
-	if err != nil {
+// DeriveAPIKeyWithContext 派生已存在的CLOB API密钥（支持context）
+func (c *ClobClient) DeriveAPIKeyWithContext(ctx context.Context, nonce *int) (*ApiCreds, error) {
+	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Get(DeriveAPIKey, headers)
+	resp, err := c.httpClient.SignedRequestWithContext(ctx, "GET", DeriveAPIKey, nil, c.l1Signer(nonce))
 	if err != nil {
 		return nil, err
 	}
@@ -96,10 +107,19 @@ func (c *ClobClient) DeriveAPIKey(nonce *int) (*ApiCreds, error) {
 // CreateOrDeriveAPIKey 创建或派生API凭证
 // 先尝试创建，如果失败则派生
 func (c *ClobClient) CreateOrDeriveAPIKey(nonce *int) (*ApiCreds, error) {
-	creds, err := c.CreateAPIKey(nonce)
+	return c.CreateOrDeriveAPIKeyWithContext(context.Background(), nonce)
+}
+
+// CreateOrDeriveAPIKeyWithContext 创建或派生API凭证（支持context）
+func (c *ClobClient) CreateOrDeriveAPIKeyWithContext(ctx context.Context, nonce *int) (*ApiCreds, error) {
+	creds, err := c.CreateAPIKeyWithContext(ctx, nonce)
 	if err != nil {
+		// ctx 已取消时不再尝试派生
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		// 如果创建失败，尝试派生
-		return c.DeriveAPIKey(nonce)
+		return c.DeriveAPIKeyWithContext(ctx, nonce)
 	}
 	return creds, nil
 }
@@ -107,6 +127,11 @@ func (c *ClobClient) CreateOrDeriveAPIKey(nonce *int) (*ApiCreds, error) {
 // GetAPIKeys 获取可用的API密钥列表
 // 需要L2认证
 func (c *ClobClient) GetAPIKeys() (interface{}, error) {
+	return c.GetAPIKeysWithContext(context.Background())
+}
+
+// GetAPIKeysWithContext 获取可用的API密钥列表（支持context）
+func (c *ClobClient) GetAPIKeysWithContext(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -116,17 +141,17 @@ func (c *ClobClient) GetAPIKeys() (interface{}, error) {
 		RequestPath: GetAPIKeys,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(GetAPIKeys, headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", GetAPIKeys, nil, c.l2Signer(requestArgs))
 }
 
 // GetClosedOnlyMode 获取closed only模式标志
 // 需要L2认证
 func (c *ClobClient) GetClosedOnlyMode() (interface{}, error) {
+	return c.GetClosedOnlyModeWithContext(context.Background())
+}
+
+// GetClosedOnlyModeWithContext 获取closed only模式标志（支持context）
+func (c *ClobClient) GetClosedOnlyModeWithContext(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -136,17 +161,17 @@ func (c *ClobClient) GetClosedOnlyMode() (interface{}, error) {
 		RequestPath: ClosedOnly,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(ClosedOnly, headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", ClosedOnly, nil, c.l2Signer(requestArgs))
 }
 
 // DeleteAPIKey 删除API密钥
 // 需要L2认证
 func (c *ClobClient) DeleteAPIKey() (interface{}, error) {
+	return c.DeleteAPIKeyWithContext(context.Background())
+}
+
+// DeleteAPIKeyWithContext 删除API密钥（支持context）
+func (c *ClobClient) DeleteAPIKeyWithContext(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -156,64 +181,82 @@ func (c *ClobClient) DeleteAPIKey() (interface{}, error) {
 		RequestPath: DeleteAPIKey,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(DeleteAPIKey, headers, nil)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", DeleteAPIKey, nil, c.l2Signer(requestArgs))
 }
 
 // GetMidpoint 获取中点价格
 func (c *ClobClient) GetMidpoint(tokenID string) (interface{}, error) {
-	path := fmt.Sprintf("%s?token_id=%s", MidPoint, tokenID)
-	return c.httpClient.Get(path, nil)
+	return c.GetMidpointWithContext(context.Background(), tokenID)
+}
+
+// GetMidpointWithContext 获取中点价格（支持context）
+func (c *ClobClient) GetMidpointWithContext(ctx context.Context, tokenID string) (interface{}, error) {
+	path := tokenQuery(tokenID).Path(MidPoint)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetMidpoints 获取多个token的中点价格
 func (c *ClobClient) GetMidpoints(params []BookParams) (interface{}, error) {
-	body := make([]map[string]string, len(params))
-	for i, p := range params {
-		body[i] = map[string]string{"token_id": p.TokenID}
-	}
-	return c.httpClient.Post(MidPoints, nil, body)
+	return c.GetMidpointsWithContext(context.Background(), params)
+}
+
+// GetMidpointsWithContext 获取多个token的中点价格（支持context）
+func (c *ClobClient) GetMidpointsWithContext(ctx context.Context, params []BookParams) (interface{}, error) {
+	body := bookParamsBody(params, false)
+	return c.httpClient.Call(ctx, "POST", MidPoints, body, CallOptions{Idempotent: true})
 }
 
 // GetPrice 获取市场价格
 func (c *ClobClient) GetPrice(tokenID, side string) (interface{}, error) {
-	path := fmt.Sprintf("%s?token_id=%s&side=%s", Price, tokenID, side)
-	return c.httpClient.Get(path, nil)
+	return c.GetPriceWithContext(context.Background(), tokenID, side)
+}
+
+// GetPriceWithContext 获取市场价格（支持context）
+func (c *ClobClient) GetPriceWithContext(ctx context.Context, tokenID, side string) (interface{}, error) {
+	path := tokenQuery(tokenID).String("side", side).Path(Price)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetPrices 获取多个token的市场价格
 func (c *ClobClient) GetPrices(params []BookParams) (interface{}, error) {
-	body := make([]map[string]string, len(params))
-	for i, p := range params {
-		body[i] = map[string]string{
-			"token_id": p.TokenID,
-			"side":     p.Side,
-		}
-	}
-	return c.httpClient.Post(GetPrices, nil, body)
+	return c.GetPricesWithContext(context.Background(), params)
+}
+
+// GetPricesWithContext 获取多个token的市场价格（支持context）
+func (c *ClobClient) GetPricesWithContext(ctx context.Context, params []BookParams) (interface{}, error) {
+	body := bookParamsBody(params, true)
+	return c.httpClient.Call(ctx, "POST", GetPrices, body, CallOptions{Idempotent: true})
 }
 
 // GetSpread 获取价差
 func (c *ClobClient) GetSpread(tokenID string) (interface{}, error) {
-	path := fmt.Sprintf("%s?token_id=%s", GetSpread, tokenID)
-	return c.httpClient.Get(path, nil)
+	return c.GetSpreadWithContext(context.Background(), tokenID)
+}
+
+// GetSpreadWithContext 获取价差（支持context）
+func (c *ClobClient) GetSpreadWithContext(ctx context.Context, tokenID string) (interface{}, error) {
+	path := tokenQuery(tokenID).Path(GetSpread)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetSpreads 获取多个token的价差
 func (c *ClobClient) GetSpreads(params []BookParams) (interface{}, error) {
-	body := make([]map[string]string, len(params))
-	for i, p := range params {
-		body[i] = map[string]string{"token_id": p.TokenID}
-	}
-	return c.httpClient.Post(GetSpreads, nil, body)
+	return c.GetSpreadsWithContext(context.Background(), params)
+}
+
+// GetSpreadsWithContext 获取多个token的价差（支持context）
+func (c *ClobClient) GetSpreadsWithContext(ctx context.Context, params []BookParams) (interface{}, error) {
+	body := bookParamsBody(params, false)
+	return c.httpClient.Call(ctx, "POST", GetSpreads, body, CallOptions{Idempotent: true})
 }
 
 // GetTickSize 获取tick size（带缓存）
 func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
+	return c.GetTickSizeWithContext(context.Background(), tokenID)
+}
+
+// GetTickSizeWithContext 获取tick size（带缓存，支持context）
+func (c *ClobClient) GetTickSizeWithContext(ctx context.Context, tokenID string) (TickSize, error) {
 	c.mu.RLock()
 	if tickSize, ok := c.tickSizes[tokenID]; ok {
 		c.mu.RUnlock()
@@ -221,8 +264,8 @@ func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
 	}
 	c.mu.RUnlock()
 
-	path := fmt.Sprintf("%s?token_id=%s", GetTickSize, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	path := tokenQuery(tokenID).Path(GetTickSize)
+	resp, err := c.httpClient.GetWithContext(ctx, path, nil)
 	if err != nil {
 		return "", err
 	}
@@ -244,6 +287,11 @@ func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
 
 // GetNegRisk 获取neg risk标志（带缓存）
 func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
+	return c.GetNegRiskWithContext(context.Background(), tokenID)
+}
+
+// GetNegRiskWithContext 获取neg risk标志（带缓存，支持context）
+func (c *ClobClient) GetNegRiskWithContext(ctx context.Context, tokenID string) (bool, error) {
 	c.mu.RLock()
 	if negRisk, ok := c.negRisk[tokenID]; ok {
 		c.mu.RUnlock()
@@ -251,8 +299,8 @@ func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
 	}
 	c.mu.RUnlock()
 
-	path := fmt.Sprintf("%s?token_id=%s", GetNegRisk, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	path := tokenQuery(tokenID).Path(GetNegRisk)
+	resp, err := c.httpClient.GetWithContext(ctx, path, nil)
 	if err != nil {
 		return false, err
 	}
@@ -273,6 +321,11 @@ func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
 
 // GetFeeRateBps 获取手续费率（基点）（带缓存）
 func (c *ClobClient) GetFeeRateBps(tokenID string) (int, error) {
+	return c.GetFeeRateBpsWithContext(context.Background(), tokenID)
+}
+
+// GetFeeRateBpsWithContext 获取手续费率（带缓存，支持context）
+func (c *ClobClient) GetFeeRateBpsWithContext(ctx context.Context, tokenID string) (int, error) {
 	c.mu.RLock()
 	if feeRate, ok := c.feeRates[tokenID]; ok {
 		c.mu.RUnlock()
@@ -280,8 +333,8 @@ func (c *ClobClient) GetFeeRateBps(tokenID string) (int, error) {
 	}
 	c.mu.RUnlock()
 
-	path := fmt.Sprintf("%s?token_id=%s", GetFeeRate, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	path := tokenQuery(tokenID).Path(GetFeeRate)
+	resp, err := c.httpClient.GetWithContext(ctx, path, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -307,8 +360,13 @@ func (c *ClobClient) GetFeeRateBps(tokenID string) (int, error) {
 
 // GetOrderBook 获取订单簿
 func (c *ClobClient) GetOrderBook(tokenID string) (*OrderBookSummary, error) {
-	path := fmt.Sprintf("%s?token_id=%s", GetOrderBook, tokenID)
-	resp, err := c.httpClient.Get(path, nil)
+	return c.GetOrderBookWithContext(context.Background(), tokenID)
+}
+
+// GetOrderBookWithContext 获取订单簿（支持context）
+func (c *ClobClient) GetOrderBookWithContext(ctx context.Context, tokenID string) (*OrderBookSummary, error) {
+	path := tokenQuery(tokenID).Path(GetOrderBook)
+	resp, err := c.httpClient.GetWithContext(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -323,12 +381,14 @@ func (c *ClobClient) GetOrderBook(tokenID string) (*OrderBookSummary, error) {
 
 // GetOrderBooks 获取多个订单簿
 func (c *ClobClient) GetOrderBooks(params []BookParams) ([]*OrderBookSummary, error) {
-	body := make([]map[string]string, len(params))
-	for i, p := range params {
-		body[i] = map[string]string{"token_id": p.TokenID}
-	}
+	return c.GetOrderBooksWithContext(context.Background(), params)
+}
 
-	resp, err := c.httpClient.Post(GetOrderBooks, nil, body)
+// GetOrderBooksWithContext 获取多个订单簿（支持context）
+func (c *ClobClient) GetOrderBooksWithContext(ctx context.Context, params []BookParams) ([]*OrderBookSummary, error) {
+	body := bookParamsBody(params, false)
+
+	resp, err := c.httpClient.Call(ctx, "POST", GetOrderBooks, body, CallOptions{Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -354,17 +414,24 @@ func (c *ClobClient) GetOrderBooks(params []BookParams) ([]*OrderBookSummary, er
 
 // GetLastTradePrice 获取最后成交价格
 func (c *ClobClient) GetLastTradePrice(tokenID string) (interface{}, error) {
-	path := fmt.Sprintf("%s?token_id=%s", GetLastTradePrice, tokenID)
-	return c.httpClient.Get(path, nil)
+	return c.GetLastTradePriceWithContext(context.Background(), tokenID)
+}
+
+// GetLastTradePriceWithContext 获取最后成交价格（支持context）
+func (c *ClobClient) GetLastTradePriceWithContext(ctx context.Context, tokenID string) (interface{}, error) {
+	path := tokenQuery(tokenID).Path(GetLastTradePrice)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetLastTradesPrices 获取多个token的最后成交价格
 func (c *ClobClient) GetLastTradesPrices(params []BookParams) (interface{}, error) {
-	body := make([]map[string]string, len(params))
-	for i, p := range params {
-		body[i] = map[string]string{"token_id": p.TokenID}
-	}
-	return c.httpClient.Post(GetLastTradesPrices, nil, body)
+	return c.GetLastTradesPricesWithContext(context.Background(), params)
+}
+
+// GetLastTradesPricesWithContext 获取多个token的最后成交价格（支持context）
+func (c *ClobClient) GetLastTradesPricesWithContext(ctx context.Context, params []BookParams) (interface{}, error) {
+	body := bookParamsBody(params, false)
+	return c.httpClient.Call(ctx, "POST", GetLastTradesPrices, body, CallOptions{Idempotent: true})
 }
 
 // 辅助函数
@@ -0,0 +1,86 @@
+package polymarket
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
+)
+
+// GetMarketsTyped 获取一页市场列表（返回类型化结果）
+func (c *ClobClient) GetMarketsTyped(nextCursor string) (*MarketsPage, error) {
+	return c.GetMarketsTypedWithContext(context.Background(), nextCursor)
+}
+
+// GetMarketsTypedWithContext 获取一页市场列表（返回类型化结果，支持context）
+func (c *ClobClient) GetMarketsTypedWithContext(ctx context.Context, nextCursor string) (*MarketsPage, error) {
+	var p MarketsPage
+	if err := c.httpClient.CallInto(ctx, "GET", marketsPagePath(GetMarkets, nextCursor), nil, CallOptions{}, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetSimplifiedMarketsTyped 获取一页简化市场列表（返回类型化结果）
+func (c *ClobClient) GetSimplifiedMarketsTyped(nextCursor string) (*SimplifiedMarketsPage, error) {
+	return c.GetSimplifiedMarketsTypedWithContext(context.Background(), nextCursor)
+}
+
+// GetSimplifiedMarketsTypedWithContext 获取一页简化市场列表（返回类型化结果，支持context）
+func (c *ClobClient) GetSimplifiedMarketsTypedWithContext(ctx context.Context, nextCursor string) (*SimplifiedMarketsPage, error) {
+	var p SimplifiedMarketsPage
+	if err := c.httpClient.CallInto(ctx, "GET", marketsPagePath(GetSimplifiedMarkets, nextCursor), nil, CallOptions{}, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetSamplingMarketsTyped 获取一页采样市场列表（返回类型化结果）
+func (c *ClobClient) GetSamplingMarketsTyped(nextCursor string) (*MarketsPage, error) {
+	return c.GetSamplingMarketsTypedWithContext(context.Background(), nextCursor)
+}
+
+// GetSamplingMarketsTypedWithContext 获取一页采样市场列表（返回类型化结果，支持context）
+func (c *ClobClient) GetSamplingMarketsTypedWithContext(ctx context.Context, nextCursor string) (*MarketsPage, error) {
+	var p MarketsPage
+	if err := c.httpClient.CallInto(ctx, "GET", marketsPagePath(GetSamplingMarkets, nextCursor), nil, CallOptions{}, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetSamplingSimplifiedMarketsTyped 获取一页采样简化市场列表（返回类型化结果）
+func (c *ClobClient) GetSamplingSimplifiedMarketsTyped(nextCursor string) (*SimplifiedMarketsPage, error) {
+	return c.GetSamplingSimplifiedMarketsTypedWithContext(context.Background(), nextCursor)
+}
+
+// GetSamplingSimplifiedMarketsTypedWithContext 获取一页采样简化市场列表（返回类型化结果，支持context）
+func (c *ClobClient) GetSamplingSimplifiedMarketsTypedWithContext(ctx context.Context, nextCursor string) (*SimplifiedMarketsPage, error) {
+	var p SimplifiedMarketsPage
+	if err := c.httpClient.CallInto(ctx, "GET", marketsPagePath(GetSamplingSimplifiedMarkets, nextCursor), nil, CallOptions{}, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetMarketTyped 根据condition_id获取市场（返回类型化结果）
+func (c *ClobClient) GetMarketTyped(conditionID string) (*Market, error) {
+	return c.GetMarketTypedWithContext(context.Background(), conditionID)
+}
+
+// GetMarketTypedWithContext 根据condition_id获取市场（返回类型化结果，支持context）
+func (c *ClobClient) GetMarketTypedWithContext(ctx context.Context, conditionID string) (*Market, error) {
+	var m Market
+	if err := c.httpClient.CallInto(ctx, "GET", GetMarket+url.PathEscape(conditionID), nil, CallOptions{}, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// marketsPagePath 市场列表分页请求路径
+func marketsPagePath(endpoint, nextCursor string) string {
+	if nextCursor == "" {
+		nextCursor = StartCursor
+	}
+	return query.New().String("next_cursor", nextCursor).Path(endpoint)
+}
@@ -1,13 +1,22 @@
 package polymarket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
 )
 
 // CreateReadonlyAPIKey 创建只读API密钥
 // 需要L2认证
 func (c *ClobClient) CreateReadonlyAPIKey() (*ReadonlyApiKeyResponse, error) {
+	return c.CreateReadonlyAPIKeyWithContext(context.Background())
+}
+
+// CreateReadonlyAPIKeyWithContext 创建只读API密钥（支持context）
+func (c *ClobClient) CreateReadonlyAPIKeyWithContext(ctx context.Context) (*ReadonlyApiKeyResponse, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -17,12 +26,7 @@ func (c *ClobClient) CreateReadonlyAPIKey() (*ReadonlyApiKeyResponse, error) {
 		RequestPath: CreateReadonlyAPIKey,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Post(CreateReadonlyAPIKey, headers, nil)
+	resp, err := c.httpClient.SignedRequestWithContext(ctx, "POST", CreateReadonlyAPIKey, nil, c.l2Signer(requestArgs))
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +44,11 @@ func (c *ClobClient) CreateReadonlyAPIKey() (*ReadonlyApiKeyResponse, error) {
 // GetReadonlyAPIKeys 获取只读API密钥列表
 // 需要L2认证
 func (c *ClobClient) GetReadonlyAPIKeys() (interface{}, error) {
+	return c.GetReadonlyAPIKeysWithContext(context.Background())
+}
+
+// GetReadonlyAPIKeysWithContext 获取只读API密钥列表（支持context）
+func (c *ClobClient) GetReadonlyAPIKeysWithContext(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -49,17 +58,17 @@ func (c *ClobClient) GetReadonlyAPIKeys() (interface{}, error) {
 		RequestPath: GetReadonlyAPIKeys,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(GetReadonlyAPIKeys, headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", GetReadonlyAPIKeys, nil, c.l2Signer(requestArgs))
 }
 
 // DeleteReadonlyAPIKey 删除只读API密钥
 // 需要L2认证
 func (c *ClobClient) DeleteReadonlyAPIKey(key string) (interface{}, error) {
+	return c.DeleteReadonlyAPIKeyWithContext(context.Background(), key)
+}
+
+// DeleteReadonlyAPIKeyWithContext 删除只读API密钥（支持context）
+func (c *ClobClient) DeleteReadonlyAPIKeyWithContext(ctx context.Context, key string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -78,45 +87,50 @@ func (c *ClobClient) DeleteReadonlyAPIKey(key string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(DeleteReadonlyAPIKey, headers, bodyStr)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", DeleteReadonlyAPIKey, bodyStr, c.l2Signer(requestArgs))
 }
 
 // ValidateReadonlyAPIKey 验证只读API密钥
 // 公开端点，不需要认证
 func (c *ClobClient) ValidateReadonlyAPIKey(address, key string) (interface{}, error) {
-	path := fmt.Sprintf("%s?address=%s&key=%s", ValidateReadonlyAPIKey, address, key)
-	return c.httpClient.Get(path, nil)
+	return c.ValidateReadonlyAPIKeyWithContext(context.Background(), address, key)
+}
+
+// ValidateReadonlyAPIKeyWithContext 验证只读API密钥（支持context）
+func (c *ClobClient) ValidateReadonlyAPIKeyWithContext(ctx context.Context, address, key string) (interface{}, error) {
+	path := query.New().String("address", address).String("key", key).Path(ValidateReadonlyAPIKey)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // IsOrderScoring 检查订单是否正在评分
 // 需要L2认证
 func (c *ClobClient) IsOrderScoring(params *OrderScoringParams) (interface{}, error) {
+	return c.IsOrderScoringWithContext(context.Background(), params)
+}
+
+// IsOrderScoringWithContext 检查订单是否正在评分（支持context）
+func (c *ClobClient) IsOrderScoringWithContext(ctx context.Context, params *OrderScoringParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	url := AddOrderScoringParamsToURL(c.host+IsOrderScoring, params)
+	path := orderScoringQuery(params).Path(IsOrderScoring)
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: IsOrderScoring,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(url[len(c.host):], headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", path, nil, c.l2Signer(requestArgs))
 }
 
 // AreOrdersScoring 检查多个订单是否正在评分
 // 需要L2认证
 func (c *ClobClient) AreOrdersScoring(params *OrdersScoringParams) (interface{}, error) {
+	return c.AreOrdersScoringWithContext(context.Background(), params)
+}
+
+// AreOrdersScoringWithContext 检查多个订单是否正在评分（支持context）
+func (c *ClobClient) AreOrdersScoringWithContext(ctx context.Context, params *OrdersScoringParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -134,65 +148,96 @@ func (c *ClobClient) AreOrdersScoring(params *OrdersScoringParams) (interface{},
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Post(AreOrdersScoring, headers, bodyStr)
+	// 只读查询，可安全重试
+	return c.httpClient.Call(ctx, "POST", AreOrdersScoring, bodyStr, CallOptions{Sign: c.l2Signer(requestArgs), Idempotent: true})
 }
 
 // GetMarkets 获取市场列表
 func (c *ClobClient) GetMarkets(nextCursor string) (interface{}, error) {
+	return c.GetMarketsWithContext(context.Background(), nextCursor)
+}
+
+// GetMarketsWithContext 获取市场列表（支持context）
+func (c *ClobClient) GetMarketsWithContext(ctx context.Context, nextCursor string) (interface{}, error) {
 	if nextCursor == "" {
-		nextCursor = "MA=="
+		nextCursor = StartCursor
 	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+	path := query.New().String("next_cursor", nextCursor).Path(GetMarkets)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetSimplifiedMarkets 获取简化市场列表
 func (c *ClobClient) GetSimplifiedMarkets(nextCursor string) (interface{}, error) {
+	return c.GetSimplifiedMarketsWithContext(context.Background(), nextCursor)
+}
+
+// GetSimplifiedMarketsWithContext 获取简化市场列表（支持context）
+func (c *ClobClient) GetSimplifiedMarketsWithContext(ctx context.Context, nextCursor string) (interface{}, error) {
 	if nextCursor == "" {
-		nextCursor = "MA=="
+		nextCursor = StartCursor
 	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetSimplifiedMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+	path := query.New().String("next_cursor", nextCursor).Path(GetSimplifiedMarkets)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetSamplingMarkets 获取采样市场列表
 func (c *ClobClient) GetSamplingMarkets(nextCursor string) (interface{}, error) {
+	return c.GetSamplingMarketsWithContext(context.Background(), nextCursor)
+}
+
+// GetSamplingMarketsWithContext 获取采样市场列表（支持context）
+func (c *ClobClient) GetSamplingMarketsWithContext(ctx context.Context, nextCursor string) (interface{}, error) {
 	if nextCursor == "" {
-		nextCursor = "MA=="
+		nextCursor = StartCursor
 	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetSamplingMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+	path := query.New().String("next_cursor", nextCursor).Path(GetSamplingMarkets)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetSamplingSimplifiedMarkets 获取采样简化市场列表
 func (c *ClobClient) GetSamplingSimplifiedMarkets(nextCursor string) (interface{}, error) {
+	return c.GetSamplingSimplifiedMarketsWithContext(context.Background(), nextCursor)
+}
+
+// GetSamplingSimplifiedMarketsWithContext 获取采样简化市场列表（支持context）
+func (c *ClobClient) GetSamplingSimplifiedMarketsWithContext(ctx context.Context, nextCursor string) (interface{}, error) {
 	if nextCursor == "" {
-		nextCursor = "MA=="
+		nextCursor = StartCursor
 	}
-	path := fmt.Sprintf("%s?next_cursor=%s", GetSamplingSimplifiedMarkets, nextCursor)
-	return c.httpClient.Get(path, nil)
+	path := query.New().String("next_cursor", nextCursor).Path(GetSamplingSimplifiedMarkets)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetMarket 根据condition_id获取市场
 func (c *ClobClient) GetMarket(conditionID string) (interface{}, error) {
-	path := GetMarket + conditionID
-	return c.httpClient.Get(path, nil)
+	return c.GetMarketWithContext(context.Background(), conditionID)
+}
+
+// GetMarketWithContext 根据condition_id获取市场（支持context）
+func (c *ClobClient) GetMarketWithContext(ctx context.Context, conditionID string) (interface{}, error) {
+	path := GetMarket + url.PathEscape(conditionID)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // GetMarketTradesEvents 根据condition_id获取市场交易事件
 func (c *ClobClient) GetMarketTradesEvents(conditionID string) (interface{}, error) {
-	path := GetMarketTradesEvents + conditionID
-	return c.httpClient.Get(path, nil)
+	return c.GetMarketTradesEventsWithContext(context.Background(), conditionID)
+}
+
+// GetMarketTradesEventsWithContext 根据condition_id获取市场交易事件（支持context）
+func (c *ClobClient) GetMarketTradesEventsWithContext(ctx context.Context, conditionID string) (interface{}, error) {
+	path := GetMarketTradesEvents + url.PathEscape(conditionID)
+	return c.httpClient.GetWithContext(ctx, path, nil)
 }
 
 // UpdateBalanceAllowance 更新余额和授权
 // 需要L2认证
 func (c *ClobClient) UpdateBalanceAllowance(params *BalanceAllowanceParams) (interface{}, error) {
+	return c.UpdateBalanceAllowanceWithContext(context.Background(), params)
+}
+
+// UpdateBalanceAllowanceWithContext 更新余额和授权（支持context）
+func (c *ClobClient) UpdateBalanceAllowanceWithContext(ctx context.Context, params *BalanceAllowanceParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -209,18 +254,13 @@ func (c *ClobClient) UpdateBalanceAllowance(params *BalanceAllowanceParams) (int
 		}
 	}
 
-	url := AddBalanceAllowanceParamsToURL(c.host+UpdateBalanceAllowance, params)
+	path := balanceAllowanceQuery(params).Path(UpdateBalanceAllowance)
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: UpdateBalanceAllowance,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(url[len(c.host):], headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", path, nil, c.l2Signer(requestArgs))
 }
 
 // GetOrderBookHash 获取订单簿哈希
@@ -231,57 +271,36 @@ func (c *ClobClient) GetOrderBookHash(orderbook *OrderBookSummary) string {
 // GetBuilderTrades 获取Builder交易记录
 // 需要Builder认证
 func (c *ClobClient) GetBuilderTrades(params *TradeParams, nextCursor string) ([]interface{}, error) {
+	return c.GetBuilderTradesWithContext(context.Background(), params, nextCursor)
+}
+
+// GetBuilderTradesWithContext 获取Builder交易记录（支持context）
+func (c *ClobClient) GetBuilderTradesWithContext(ctx context.Context, params *TradeParams, nextCursor string) ([]interface{}, error) {
 	// TODO: 实现Builder认证检查
 	// 目前使用L2认证作为替代
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: GetBuilderTrades,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	var results []interface{}
-	for nextCursor != EndCursor {
-		url := AddQueryTradeParams(c.host+GetBuilderTrades, params, nextCursor)
-		resp, err := c.httpClient.Get(url[len(c.host):], headers)
-		if err != nil {
-			return nil, err
-		}
-
-		respMap, ok := resp.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format")
-		}
-
-		if cursor, ok := respMap["next_cursor"].(string); ok {
-			nextCursor = cursor
-		} else {
-			nextCursor = EndCursor
-		}
-
-		if data, ok := respMap["data"].([]interface{}); ok {
-			results = append(results, data...)
-		}
-	}
-
-	return results, nil
+	return fetchAllPages[interface{}](ctx, c, requestArgs, nextCursor, func(cursor string) string {
+		return tradeQuery(params, cursor).Path(GetBuilderTrades)
+	})
 }
 
 // PostHeartbeat 发送心跳
 // 如果心跳启动后10秒内没有发送心跳，所有订单将被取消
 // 需要L2认证
 func (c *ClobClient) PostHeartbeat(heartbeatID *string) (interface{}, error) {
+	return c.PostHeartbeatWithContext(context.Background(), heartbeatID)
+}
+
+// PostHeartbeatWithContext 发送心跳（支持context）
+func (c *ClobClient) PostHeartbeatWithContext(ctx context.Context, heartbeatID *string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -300,10 +319,6 @@ func (c *ClobClient) PostHeartbeat(heartbeatID *string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Post(PostHeartbeat, headers, bodyStr)
+	// 重复发送心跳不会产生副作用，可安全重试
+	return c.httpClient.Call(ctx, "POST", PostHeartbeat, bodyStr, CallOptions{Sign: c.l2Signer(requestArgs), Idempotent: true})
 }
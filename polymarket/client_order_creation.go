@@ -1,17 +1,50 @@
 package polymarket
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strconv"
 
 	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
 	obuilder "github.com/wimgithub/Polymarket-golang/polymarket/order_builder"
 )
 
+// resolveOrderToken 下单前解析代币所属市场，每次下单最多调用一次
+//
+// tick size 和 neg risk 均已缓存、或该代币此前解析失败时不发请求；否则通过 ResolveToken
+// 解析整个市场（同时缓存同一市场的其他代币）。解析失败时返回 nil 并记录，
+// 之后由 resolveTickSize / resolveNegRisk 回退到 /tick-size 和 /neg-risk
+func (c *ClobClient) resolveOrderToken(ctx context.Context, tokenID string) *TokenInfo {
+	if info, ok := c.cachedTokenInfo(tokenID); ok {
+		return info
+	}
+	c.mu.RLock()
+	_, hasTickSize := c.tickSizes[tokenID]
+	_, hasNegRisk := c.negRisk[tokenID]
+	failed := c.unresolved[tokenID]
+	c.mu.RUnlock()
+	if (hasTickSize && hasNegRisk) || failed {
+		return nil
+	}
+
+	info, err := c.ResolveToken(ctx, tokenID)
+	if err != nil {
+		c.httpClient.Logger().DebugContext(ctx, "token resolution failed, using market parameter endpoints",
+			"token_id", tokenID, "error", err)
+		c.mu.Lock()
+		c.unresolved[tokenID] = true
+		c.mu.Unlock()
+		return nil
+	}
+	return info
+}
+
 // ResolveTickSize 解析tick size
-func (c *ClobClient) resolveTickSize(tokenID string, tickSize *TickSize) (TickSize, error) {
-	minTickSize, err := c.GetTickSize(tokenID)
+// info 为 resolveOrderToken 的结果（可为 nil），缓存和 info 都没有时请求 /tick-size
+func (c *ClobClient) resolveTickSize(ctx context.Context, tokenID string, info *TokenInfo, tickSize *TickSize) (TickSize, error) {
+	minTickSize, err := c.marketTickSize(ctx, tokenID, info)
 	if err != nil {
 		return "", err
 	}
@@ -26,9 +59,40 @@ func (c *ClobClient) resolveTickSize(tokenID string, tickSize *TickSize) (TickSi
 	return minTickSize, nil
 }
 
+// marketTickSize 获取市场的最小 tick size（依次使用缓存、info 和 /tick-size）
+func (c *ClobClient) marketTickSize(ctx context.Context, tokenID string, info *TokenInfo) (TickSize, error) {
+	c.mu.RLock()
+	tickSize, ok := c.tickSizes[tokenID]
+	c.mu.RUnlock()
+	if ok {
+		return tickSize, nil
+	}
+	if info != nil && info.TickSize != "" {
+		return info.TickSize, nil
+	}
+	return c.GetTickSizeWithContext(ctx, tokenID)
+}
+
+// resolveNegRisk 解析neg risk，依次使用调用方提供的值、缓存、info 和 /neg-risk
+func (c *ClobClient) resolveNegRisk(ctx context.Context, tokenID string, info *TokenInfo, negRisk *bool) (bool, error) {
+	if negRisk != nil {
+		return *negRisk, nil
+	}
+	c.mu.RLock()
+	cached, ok := c.negRisk[tokenID]
+	c.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+	if info != nil {
+		return info.NegRisk, nil
+	}
+	return c.GetNegRiskWithContext(ctx, tokenID)
+}
+
 // ResolveFeeRate 解析手续费率
-func (c *ClobClient) resolveFeeRate(tokenID string, userFeeRate int) (int, error) {
-	marketFeeRateBps, err := c.GetFeeRateBps(tokenID)
+func (c *ClobClient) resolveFeeRate(ctx context.Context, tokenID string, userFeeRate int) (int, error) {
+	marketFeeRateBps, err := c.GetFeeRateBpsWithContext(ctx, tokenID)
 	if err != nil {
 		return 0, err
 	}
@@ -45,6 +109,29 @@ func (c *ClobClient) resolveFeeRate(tokenID string, userFeeRate int) (int, error
 // 需要L1认证
 // options.RawOrder = true 时跳过从服务器获取 tick_size，但必须通过 options.TickSize 提供
 func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+	return c.CreateOrderWithContext(context.Background(), orderArgs, options)
+}
+
+// CreateOrderWithContext 创建并签名订单（支持context，用于获取tick_size/neg_risk/fee_rate的请求）
+// float64 适配层：价格和数量按 6 位小数四舍五入后由 CreateOrderDecimalWithContext 处理
+func (c *ClobClient) CreateOrderWithContext(ctx context.Context, orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+	args, err := orderArgs.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	signedOrder, err := c.CreateOrderDecimalWithContext(ctx, args, options)
+	orderArgs.FeeRateBps = args.FeeRateBps
+	return signedOrder, err
+}
+
+// CreateOrderDecimal 创建并签名订单（限价订单，定点小数）
+// 需要L1认证
+func (c *ClobClient) CreateOrderDecimal(orderArgs *DecimalOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+	return c.CreateOrderDecimalWithContext(context.Background(), orderArgs, options)
+}
+
+// CreateOrderDecimalWithContext 创建并签名订单（定点小数，支持context）
+func (c *ClobClient) CreateOrderDecimalWithContext(ctx context.Context, orderArgs *DecimalOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
 	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
@@ -76,23 +163,24 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 		if options != nil && options.TickSize != nil {
 			tickSizePtr = options.TickSize
 		}
-		tickSize, err = c.resolveTickSize(orderArgs.TokenID, tickSizePtr)
+		info := c.resolveOrderToken(ctx, orderArgs.TokenID)
+		tickSize, err = c.resolveTickSize(ctx, orderArgs.TokenID, info, tickSizePtr)
 		if err != nil {
 			return nil, err
 		}
 
 		// 解析neg risk
-		if options != nil && options.NegRisk != nil {
-			negRisk = *options.NegRisk
-		} else {
-			negRisk, err = c.GetNegRisk(orderArgs.TokenID)
-			if err != nil {
-				return nil, err
-			}
+		var negRiskPtr *bool
+		if options != nil {
+			negRiskPtr = options.NegRisk
+		}
+		negRisk, err = c.resolveNegRisk(ctx, orderArgs.TokenID, info, negRiskPtr)
+		if err != nil {
+			return nil, err
 		}
 
 		// 解析手续费率
-		feeRateBps, err := c.resolveFeeRate(orderArgs.TokenID, orderArgs.FeeRateBps)
+		feeRateBps, err := c.resolveFeeRate(ctx, orderArgs.TokenID, orderArgs.FeeRateBps)
 		if err != nil {
 			return nil, err
 		}
@@ -100,9 +188,8 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 	}
 
 	// 验证价格
-	if !PriceValid(orderArgs.Price, tickSize) {
-		tickSizeFloat, _ := strconv.ParseFloat(string(tickSize), 64)
-		return nil, fmt.Errorf("price (%.6f), min: %s - max: %.6f", orderArgs.Price, tickSize, 1.0-tickSizeFloat)
+	if err := validatePrice(orderArgs.Price, tickSize); err != nil {
+		return nil, err
 	}
 
 	// 获取舍入配置
@@ -112,7 +199,7 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 	}
 
 	// 获取订单金额（带舍入）
-	side, makerAmount, takerAmount, err = c.builder.GetOrderAmounts(
+	side, makerAmount, takerAmount, err = c.builder.GetOrderAmountsDecimal(
 		orderArgs.Side,
 		orderArgs.Size,
 		orderArgs.Price,
@@ -157,6 +244,32 @@ func (c *ClobClient) CreateOrder(orderArgs *OrderArgs, options *PartialCreateOrd
 // CreateMarketOrder 创建并签名市价订单
 // 需要L1认证
 func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+	return c.CreateMarketOrderWithContext(context.Background(), orderArgs, options)
+}
+
+// CreateMarketOrderWithContext 创建并签名市价订单（支持context）
+// float64 适配层：金额和价格按 6 位小数四舍五入后由 CreateMarketOrderDecimalWithContext 处理
+func (c *ClobClient) CreateMarketOrderWithContext(ctx context.Context, orderArgs *MarketOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+	args, err := orderArgs.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	signedOrder, err := c.CreateMarketOrderDecimalWithContext(ctx, args, options)
+	if orderArgs.Price <= 0 {
+		orderArgs.Price = args.Price.Float64()
+	}
+	orderArgs.FeeRateBps = args.FeeRateBps
+	return signedOrder, err
+}
+
+// CreateMarketOrderDecimal 创建并签名市价订单（定点小数）
+// 需要L1认证
+func (c *ClobClient) CreateMarketOrderDecimal(orderArgs *DecimalMarketOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
+	return c.CreateMarketOrderDecimalWithContext(context.Background(), orderArgs, options)
+}
+
+// CreateMarketOrderDecimalWithContext 创建并签名市价订单（定点小数，支持context）
+func (c *ClobClient) CreateMarketOrderDecimalWithContext(ctx context.Context, orderArgs *DecimalMarketOrderArgs, options *PartialCreateOrderOptions) (*SignedOrder, error) {
 	if err := c.assertLevel1Auth(); err != nil {
 		return nil, err
 	}
@@ -166,14 +279,15 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	if options != nil && options.TickSize != nil {
 		tickSizePtr = options.TickSize
 	}
-	tickSize, err := c.resolveTickSize(orderArgs.TokenID, tickSizePtr)
+	info := c.resolveOrderToken(ctx, orderArgs.TokenID)
+	tickSize, err := c.resolveTickSize(ctx, orderArgs.TokenID, info, tickSizePtr)
 	if err != nil {
 		return nil, err
 	}
 
 	// 如果价格未设置或为0，计算市价
-	if orderArgs.Price <= 0 {
-		price, err := c.CalculateMarketPrice(orderArgs.TokenID, orderArgs.Side, orderArgs.Amount, orderArgs.OrderType)
+	if orderArgs.Price.Sign() <= 0 {
+		price, err := c.CalculateMarketPriceDecimalWithContext(ctx, orderArgs.TokenID, orderArgs.Side, orderArgs.Amount, orderArgs.OrderType)
 		if err != nil {
 			return nil, err
 		}
@@ -181,24 +295,22 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	}
 
 	// 验证价格
-	if !PriceValid(orderArgs.Price, tickSize) {
-		tickSizeFloat, _ := strconv.ParseFloat(string(tickSize), 64)
-		return nil, fmt.Errorf("price (%.6f), min: %s - max: %.6f", orderArgs.Price, tickSize, 1.0-tickSizeFloat)
+	if err := validatePrice(orderArgs.Price, tickSize); err != nil {
+		return nil, err
 	}
 
 	// 解析neg risk
-	negRisk := false
-	if options != nil && options.NegRisk != nil {
-		negRisk = *options.NegRisk
-	} else {
-		negRisk, err = c.GetNegRisk(orderArgs.TokenID)
-		if err != nil {
-			return nil, err
-		}
+	var negRiskPtr *bool
+	if options != nil {
+		negRiskPtr = options.NegRisk
+	}
+	negRisk, err := c.resolveNegRisk(ctx, orderArgs.TokenID, info, negRiskPtr)
+	if err != nil {
+		return nil, err
 	}
 
 	// 解析手续费率
-	feeRateBps, err := c.resolveFeeRate(orderArgs.TokenID, orderArgs.FeeRateBps)
+	feeRateBps, err := c.resolveFeeRate(ctx, orderArgs.TokenID, orderArgs.FeeRateBps)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +323,7 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 	}
 
 	// 获取订单金额
-	side, makerAmount, takerAmount, err := c.builder.GetMarketOrderAmounts(
+	side, makerAmount, takerAmount, err := c.builder.GetMarketOrderAmountsDecimal(
 		orderArgs.Side,
 		orderArgs.Amount,
 		orderArgs.Price,
@@ -257,7 +369,12 @@ func (c *ClobClient) CreateMarketOrder(orderArgs *MarketOrderArgs, options *Part
 // 支持通过 options.OrderType 指定订单类型：GTC, FOK, GTD, FAK（默认 GTC）
 // 返回 PostOrderResult，包含原始 Payload 和 API 响应
 func (c *ClobClient) CreateAndPostOrder(orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*PostOrderResult, error) {
-	order, err := c.CreateOrder(orderArgs, options)
+	return c.CreateAndPostOrderWithContext(context.Background(), orderArgs, options)
+}
+
+// CreateAndPostOrderWithContext 创建并提交订单（支持context）
+func (c *ClobClient) CreateAndPostOrderWithContext(ctx context.Context, orderArgs *OrderArgs, options *PartialCreateOrderOptions) (*PostOrderResult, error) {
+	order, err := c.CreateOrderWithContext(ctx, orderArgs, options)
 	if err != nil {
 		return nil, err
 	}
@@ -268,27 +385,59 @@ func (c *ClobClient) CreateAndPostOrder(orderArgs *OrderArgs, options *PartialCr
 		orderType = *options.OrderType
 	}
 
-	return c.PostOrder(order, orderType)
+	return c.PostOrderWithContext(ctx, order, orderType)
 }
 
 // CalculateMarketPrice 计算市价
 func (c *ClobClient) CalculateMarketPrice(tokenID, side string, amount float64, orderType OrderType) (float64, error) {
-	book, err := c.GetOrderBook(tokenID)
+	return c.CalculateMarketPriceWithContext(context.Background(), tokenID, side, amount, orderType)
+}
+
+// CalculateMarketPriceWithContext 计算市价（支持context）
+func (c *ClobClient) CalculateMarketPriceWithContext(ctx context.Context, tokenID, side string, amount float64, orderType OrderType) (float64, error) {
+	amountDec, err := decimal.FromFloat(amount)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %w", err)
+	}
+	price, err := c.CalculateMarketPriceDecimalWithContext(ctx, tokenID, side, amountDec, orderType)
+	return price.Float64(), err
+}
+
+// CalculateMarketPriceDecimal 计算市价（定点小数）
+func (c *ClobClient) CalculateMarketPriceDecimal(tokenID, side string, amount decimal.Decimal, orderType OrderType) (decimal.Decimal, error) {
+	return c.CalculateMarketPriceDecimalWithContext(context.Background(), tokenID, side, amount, orderType)
+}
+
+// CalculateMarketPriceDecimalWithContext 计算市价（定点小数，支持context）
+func (c *ClobClient) CalculateMarketPriceDecimalWithContext(ctx context.Context, tokenID, side string, amount decimal.Decimal, orderType OrderType) (decimal.Decimal, error) {
+	book, err := c.GetOrderBookWithContext(ctx, tokenID)
 	if err != nil {
-		return 0, fmt.Errorf("no orderbook: %w", err)
+		return decimal.Zero, fmt.Errorf("no orderbook: %w", err)
 	}
 
 	if side == BUY {
 		if len(book.Asks) == 0 {
-			return 0, fmt.Errorf("no match")
+			return decimal.Zero, fmt.Errorf("no match")
 		}
-		return c.builder.CalculateBuyMarketPrice(ConvertOrderSummaries(book.Asks), amount, string(orderType))
+		return c.builder.CalculateBuyMarketPriceDecimal(ConvertOrderSummaries(book.Asks), amount, string(orderType))
 	} else {
 		if len(book.Bids) == 0 {
-			return 0, fmt.Errorf("no match")
+			return decimal.Zero, fmt.Errorf("no match")
 		}
-		return c.builder.CalculateSellMarketPrice(ConvertOrderSummaries(book.Bids), amount, string(orderType))
+		return c.builder.CalculateSellMarketPriceDecimal(ConvertOrderSummaries(book.Bids), amount, string(orderType))
+	}
+}
+
+// validatePrice 检查价格是否在 [tickSize, 1 - tickSize] 范围内
+func validatePrice(price decimal.Decimal, tickSize TickSize) error {
+	if PriceValidDecimal(price, tickSize) {
+		return nil
+	}
+	tick, err := tickSize.Decimal()
+	if err != nil {
+		return fmt.Errorf("invalid tick size: %s", tickSize)
 	}
+	return fmt.Errorf("price (%s), min: %s - max: %s", price.StringFixed(decimal.Places), tickSize, decimal.One.Sub(tick).StringFixed(decimal.Places))
 }
 
 // ConvertOrderSummaries 转换OrderSummary为order_builder.OrderSummary接口（导出函数）
@@ -1,15 +1,24 @@
 package polymarket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
 )
 
 // PostOrder 提交订单
 // 需要L2认证
 // 返回 PostOrderResult，包含原始 Payload 和 API 响应
 func (c *ClobClient) PostOrder(order *SignedOrder, orderType OrderType) (*PostOrderResult, error) {
-	return c.PostOrderWithOptions(order, orderType, false)
+	return c.PostOrderWithOptionsContext(context.Background(), order, orderType, false)
+}
+
+// PostOrderWithContext 提交订单（支持context）
+func (c *ClobClient) PostOrderWithContext(ctx context.Context, order *SignedOrder, orderType OrderType) (*PostOrderResult, error) {
+	return c.PostOrderWithOptionsContext(ctx, order, orderType, false)
 }
 
 // PostOrderWithOptions 提交订单（支持 PostOnly 选项）
@@ -17,6 +26,11 @@ func (c *ClobClient) PostOrder(order *SignedOrder, orderType OrderType) (*PostOr
 // 需要L2认证
 // 返回 PostOrderResult，包含原始 Payload 和 API 响应
 func (c *ClobClient) PostOrderWithOptions(order *SignedOrder, orderType OrderType, postOnly bool) (*PostOrderResult, error) {
+	return c.PostOrderWithOptionsContext(context.Background(), order, orderType, postOnly)
+}
+
+// PostOrderWithOptionsContext 提交订单（支持 PostOnly 选项和context）
+func (c *ClobClient) PostOrderWithOptionsContext(ctx context.Context, order *SignedOrder, orderType OrderType, postOnly bool) (*PostOrderResult, error) {
 	if postOnly && orderType != OrderTypeGTC && orderType != OrderTypeGTD {
 		return nil, fmt.Errorf("post_only orders can only be of type GTC or GTD")
 	}
@@ -39,12 +53,7 @@ func (c *ClobClient) PostOrderWithOptions(order *SignedOrder, orderType OrderTyp
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Post(PostOrder, headers, bodyStr)
+	resp, err := c.httpClient.SignedRequestWithContext(ctx, "POST", PostOrder, bodyStr, c.l2Signer(requestArgs))
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +68,11 @@ func (c *ClobClient) PostOrderWithOptions(order *SignedOrder, orderType OrderTyp
 // 需要L2认证
 // 返回 PostOrdersResult，包含原始 Payload 和 API 响应
 func (c *ClobClient) PostOrders(args []PostOrdersArgs) (*PostOrdersResult, error) {
+	return c.PostOrdersWithContext(context.Background(), args)
+}
+
+// PostOrdersWithContext 批量提交订单（支持context）
+func (c *ClobClient) PostOrdersWithContext(ctx context.Context, args []PostOrdersArgs) (*PostOrdersResult, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -88,12 +102,7 @@ func (c *ClobClient) PostOrders(args []PostOrdersArgs) (*PostOrdersResult, error
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Post(PostOrders, headers, bodyStr)
+	resp, err := c.httpClient.SignedRequestWithContext(ctx, "POST", PostOrders, bodyStr, c.l2Signer(requestArgs))
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +116,11 @@ func (c *ClobClient) PostOrders(args []PostOrdersArgs) (*PostOrdersResult, error
 // Cancel 取消订单
 // 需要L2认证
 func (c *ClobClient) Cancel(orderID string) (interface{}, error) {
+	return c.CancelWithContext(context.Background(), orderID)
+}
+
+// CancelWithContext 取消订单（支持context）
+func (c *ClobClient) CancelWithContext(ctx context.Context, orderID string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -125,17 +139,17 @@ func (c *ClobClient) Cancel(orderID string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(Cancel, headers, bodyStr)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", Cancel, bodyStr, c.l2Signer(requestArgs))
 }
 
 // CancelOrders 批量取消订单
 // 需要L2认证
 func (c *ClobClient) CancelOrders(orderIDs []string) (interface{}, error) {
+	return c.CancelOrdersWithContext(context.Background(), orderIDs)
+}
+
+// CancelOrdersWithContext 批量取消订单（支持context）
+func (c *ClobClient) CancelOrdersWithContext(ctx context.Context, orderIDs []string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -153,17 +167,17 @@ func (c *ClobClient) CancelOrders(orderIDs []string) (interface{}, error) {
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(CancelOrders, headers, bodyStr)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", CancelOrders, bodyStr, c.l2Signer(requestArgs))
 }
 
 // CancelAll 取消所有订单
 // 需要L2认证
 func (c *ClobClient) CancelAll() (interface{}, error) {
+	return c.CancelAllWithContext(context.Background())
+}
+
+// CancelAllWithContext 取消所有订单（支持context）
+func (c *ClobClient) CancelAllWithContext(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -173,17 +187,17 @@ func (c *ClobClient) CancelAll() (interface{}, error) {
 		RequestPath: CancelAll,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(CancelAll, headers, nil)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", CancelAll, nil, c.l2Signer(requestArgs))
 }
 
 // CancelMarketOrders 取消市场订单
 // 需要L2认证
 func (c *ClobClient) CancelMarketOrders(market, assetID string) (interface{}, error) {
+	return c.CancelMarketOrdersWithContext(context.Background(), market, assetID)
+}
+
+// CancelMarketOrdersWithContext 取消市场订单（支持context）
+func (c *ClobClient) CancelMarketOrdersWithContext(ctx context.Context, market, assetID string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -205,134 +219,82 @@ func (c *ClobClient) CancelMarketOrders(market, assetID string) (interface{}, er
 		SerializedBody: &bodyStr,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(CancelMarketOrders, headers, bodyStr)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", CancelMarketOrders, bodyStr, c.l2Signer(requestArgs))
 }
 
 // GetOrders 获取订单列表
 // 需要L2认证
 func (c *ClobClient) GetOrders(params *OpenOrderParams, nextCursor string) ([]interface{}, error) {
+	return c.GetOrdersWithContext(context.Background(), params, nextCursor)
+}
+
+// GetOrdersWithContext 获取订单列表（支持context）
+func (c *ClobClient) GetOrdersWithContext(ctx context.Context, params *OpenOrderParams, nextCursor string) ([]interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: Orders,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	var results []interface{}
-	for nextCursor != EndCursor {
-		url := AddQueryOpenOrdersParams(c.host+Orders, params, nextCursor)
-		resp, err := c.httpClient.Get(url[len(c.host):], headers)
-		if err != nil {
-			return nil, err
-		}
-
-		respMap, ok := resp.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format")
-		}
-
-		if cursor, ok := respMap["next_cursor"].(string); ok {
-			nextCursor = cursor
-		} else {
-			nextCursor = EndCursor
-		}
-
-		if data, ok := respMap["data"].([]interface{}); ok {
-			results = append(results, data...)
-		}
-	}
-
-	return results, nil
+	return fetchAllPages[interface{}](ctx, c, requestArgs, nextCursor, func(cursor string) string {
+		return openOrdersQuery(params, cursor).Path(Orders)
+	})
 }
 
 // GetOrder 获取单个订单
 // 需要L2认证
 func (c *ClobClient) GetOrder(orderID string) (interface{}, error) {
+	return c.GetOrderWithContext(context.Background(), orderID)
+}
+
+// GetOrderWithContext 获取单个订单（支持context）
+func (c *ClobClient) GetOrderWithContext(ctx context.Context, orderID string) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	endpoint := GetOrder + orderID
+	endpoint := GetOrder + url.PathEscape(orderID)
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: endpoint,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(endpoint, headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", endpoint, nil, c.l2Signer(requestArgs))
 }
 
 // GetTrades 获取交易历史
 // 需要L2认证
 func (c *ClobClient) GetTrades(params *TradeParams, nextCursor string) ([]interface{}, error) {
+	return c.GetTradesWithContext(context.Background(), params, nextCursor)
+}
+
+// GetTradesWithContext 获取交易历史（支持context）
+func (c *ClobClient) GetTradesWithContext(ctx context.Context, params *TradeParams, nextCursor string) ([]interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	if nextCursor == "" {
-		nextCursor = "MA=="
-	}
-
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: Trades,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	var results []interface{}
-	for nextCursor != EndCursor {
-		url := AddQueryTradeParams(c.host+Trades, params, nextCursor)
-		resp, err := c.httpClient.Get(url[len(c.host):], headers)
-		if err != nil {
-			return nil, err
-		}
-
-		respMap, ok := resp.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid response format")
-		}
-
-		if cursor, ok := respMap["next_cursor"].(string); ok {
-			nextCursor = cursor
-		} else {
-			nextCursor = EndCursor
-		}
-
-		if data, ok := respMap["data"].([]interface{}); ok {
-			results = append(results, data...)
-		}
-	}
-
-	return results, nil
+	return fetchAllPages[interface{}](ctx, c, requestArgs, nextCursor, func(cursor string) string {
+		return tradeQuery(params, cursor).Path(Trades)
+	})
 }
 
 // GetBalanceAllowance 获取余额和授权
 // 需要L2认证
 func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (map[string]interface{}, error) {
+	return c.GetBalanceAllowanceWithContext(context.Background(), params)
+}
+
+// GetBalanceAllowanceWithContext 获取余额和授权（支持context）
+func (c *ClobClient) GetBalanceAllowanceWithContext(ctx context.Context, params *BalanceAllowanceParams) (map[string]interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
@@ -349,18 +311,13 @@ func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (map[st
 		}
 	}
 
-	url := AddBalanceAllowanceParamsToURL(c.host+GetBalanceAllowance, params)
+	path := balanceAllowanceQuery(params).Path(GetBalanceAllowance)
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: GetBalanceAllowance,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Get(url[len(c.host):], headers)
+	resp, err := c.httpClient.SignedRequestWithContext(ctx, "GET", path, nil, c.l2Signer(requestArgs))
 	if err != nil {
 		return nil, err
 	}
@@ -376,47 +333,50 @@ func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (map[st
 // GetNotifications 获取通知
 // 需要L2认证
 func (c *ClobClient) GetNotifications() (interface{}, error) {
+	return c.GetNotificationsWithContext(context.Background())
+}
+
+// GetNotificationsWithContext 获取通知（支持context）
+func (c *ClobClient) GetNotificationsWithContext(ctx context.Context) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	sigType := 0
-	if c.builder != nil {
-		// 这里需要从builder获取sigType，暂时设为0
-	}
-
-	url := fmt.Sprintf("%s?signature_type=%d", GetNotifications, sigType)
+	path := c.notificationsPath()
 	requestArgs := &RequestArgs{
 		Method:      "GET",
 		RequestPath: GetNotifications,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Get(url, headers)
+	return c.httpClient.SignedRequestWithContext(ctx, "GET", path, nil, c.l2Signer(requestArgs))
 }
 
 // DropNotifications 删除通知
 // 需要L2认证
 func (c *ClobClient) DropNotifications(params *DropNotificationParams) (interface{}, error) {
+	return c.DropNotificationsWithContext(context.Background(), params)
+}
+
+// DropNotificationsWithContext 删除通知（支持context）
+func (c *ClobClient) DropNotificationsWithContext(ctx context.Context, params *DropNotificationParams) (interface{}, error) {
 	if err := c.assertLevel2Auth(); err != nil {
 		return nil, err
 	}
 
-	url := DropNotificationsQueryParams(c.host+DropNotifications, params)
+	path := dropNotificationsQuery(params).Path(DropNotifications)
 	requestArgs := &RequestArgs{
 		Method:      "DELETE",
 		RequestPath: DropNotifications,
 	}
 
-	headers, err := CreateLevel2Headers(c.signer, c.creds, requestArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.httpClient.Delete(url[len(c.host):], headers, nil)
+	return c.httpClient.SignedRequestWithContext(ctx, "DELETE", path, nil, c.l2Signer(requestArgs))
 }
 
+// notificationsPath 获取通知的请求路径（带签名类型）
+func (c *ClobClient) notificationsPath() string {
+	sigType := 0
+	if c.builder != nil {
+		sigType = c.builder.GetSigType()
+	}
+	return query.New().Int("signature_type", int64(sigType)).Path(GetNotifications)
+}
@@ -0,0 +1,119 @@
+package polymarket
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+)
+
+// GetOrdersTyped 获取订单列表（返回类型化结果）
+// 需要L2认证
+func (c *ClobClient) GetOrdersTyped(params *OpenOrderParams, nextCursor string) ([]OpenOrder, error) {
+	return c.GetOrdersTypedWithContext(context.Background(), params, nextCursor)
+}
+
+// GetOrdersTypedWithContext 获取订单列表（返回类型化结果，支持context）
+func (c *ClobClient) GetOrdersTypedWithContext(ctx context.Context, params *OpenOrderParams, nextCursor string) ([]OpenOrder, error) {
+	if err := c.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: Orders,
+	}
+
+	return fetchAllPages[OpenOrder](ctx, c, requestArgs, nextCursor, func(cursor string) string {
+		return openOrdersQuery(params, cursor).Path(Orders)
+	})
+}
+
+// GetOrderTyped 获取单个订单（返回类型化结果）
+// 需要L2认证
+func (c *ClobClient) GetOrderTyped(orderID string) (*OpenOrder, error) {
+	return c.GetOrderTypedWithContext(context.Background(), orderID)
+}
+
+// GetOrderTypedWithContext 获取单个订单（返回类型化结果，支持context）
+func (c *ClobClient) GetOrderTypedWithContext(ctx context.Context, orderID string) (*OpenOrder, error) {
+	if err := c.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+
+	endpoint := GetOrder + url.PathEscape(orderID)
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: endpoint,
+	}
+
+	var order OpenOrder
+	if err := c.httpClient.CallInto(ctx, "GET", endpoint, nil, CallOptions{Sign: c.l2Signer(requestArgs)}, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetTradesTyped 获取交易历史（返回类型化结果）
+// 需要L2认证
+func (c *ClobClient) GetTradesTyped(params *TradeParams, nextCursor string) ([]Trade, error) {
+	return c.GetTradesTypedWithContext(context.Background(), params, nextCursor)
+}
+
+// GetTradesTypedWithContext 获取交易历史（返回类型化结果，支持context）
+func (c *ClobClient) GetTradesTypedWithContext(ctx context.Context, params *TradeParams, nextCursor string) ([]Trade, error) {
+	if err := c.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: Trades,
+	}
+
+	return fetchAllPages[Trade](ctx, c, requestArgs, nextCursor, func(cursor string) string {
+		return tradeQuery(params, cursor).Path(Trades)
+	})
+}
+
+// GetBuilderTradesTyped 获取Builder交易记录（返回类型化结果）
+// 需要Builder认证
+func (c *ClobClient) GetBuilderTradesTyped(params *TradeParams, nextCursor string) ([]BuilderTrade, error) {
+	return c.GetBuilderTradesTypedWithContext(context.Background(), params, nextCursor)
+}
+
+// GetBuilderTradesTypedWithContext 获取Builder交易记录（返回类型化结果，支持context）
+func (c *ClobClient) GetBuilderTradesTypedWithContext(ctx context.Context, params *TradeParams, nextCursor string) ([]BuilderTrade, error) {
+	// 与 GetBuilderTradesWithContext 一致，目前使用L2认证
+	if err := c.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: GetBuilderTrades,
+	}
+
+	return fetchAllPages[BuilderTrade](ctx, c, requestArgs, nextCursor, func(cursor string) string {
+		return tradeQuery(params, cursor).Path(GetBuilderTrades)
+	})
+}
+
+// fetchAllPages 从 nextCursor 开始依次获取所有分页数据，直到 EndCursor
+func fetchAllPages[T any](ctx context.Context, c *ClobClient, requestArgs *RequestArgs, nextCursor string, pathFor func(cursor string) string) ([]T, error) {
+	return pagination.New(signedPageFetch[T](c, requestArgs, pathFor), PageOptions{Cursor: nextCursor}).Collect(ctx)
+}
+
+// signedPageFetch 返回获取需要L2认证的一页数据的 pagination.Fetch
+func signedPageFetch[T any](c *ClobClient, requestArgs *RequestArgs, pathFor func(cursor string) string) pagination.Fetch[T] {
+	return func(ctx context.Context, cursor string) ([]T, string, error) {
+		if err := c.assertLevel2Auth(); err != nil {
+			return nil, "", err
+		}
+		var p page[T]
+		if err := c.httpClient.CallInto(ctx, "GET", pathFor(cursor), nil, CallOptions{Sign: c.l2Signer(requestArgs)}, &p); err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	}
+}
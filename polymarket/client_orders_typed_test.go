@@ -0,0 +1,205 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// pageServer 按 next_cursor 返回分页数据的测试服务器：MA== -> Mg== -> NA== -> LTE=
+type pageServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	cursors  []string         // 收到的游标（按路径）
+	failAt   string           // 请求该游标时返回 400
+	onCursor func(string)     // 收到请求时的回调
+	ids      map[string][]int // 游标 -> 该页的 id
+}
+
+func newPageServer(t *testing.T) *pageServer {
+	s := &pageServer{ids: map[string][]int{"MA==": {1, 2}, "Mg==": {3}, "NA==": {4, 5}}}
+	next := map[string]string{"MA==": "Mg==", "Mg==": "NA==", "NA==": EndCursor}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("next_cursor")
+		s.mu.Lock()
+		s.cursors = append(s.cursors, r.URL.Path+"@"+cursor)
+		failAt, onCursor := s.failAt, s.onCursor
+		s.mu.Unlock()
+		if onCursor != nil {
+			onCursor(cursor)
+		}
+		if cursor == failAt {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"bad cursor"}`))
+			return
+		}
+		ids, ok := s.ids[cursor]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data := "["
+		for i, id := range ids {
+			if i > 0 {
+				data += ","
+			}
+			data += fmt.Sprintf(`{"id":"%d"}`, id)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":%s],"next_cursor":%q}`, data, next[cursor])
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// requests 返回收到的请求并清空记录
+func (s *pageServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reqs := s.cursors
+	s.cursors = nil
+	return reqs
+}
+
+func newPagedClient(t *testing.T, baseURL string) *ClobClient {
+	t.Helper()
+	creds := &ApiCreds{APIKey: "key", APISecret: base64.URLEncoding.EncodeToString([]byte("test-secret")), APIPassphrase: "pass"}
+	client, err := NewClobClient(baseURL, 137,
+		WithPrivateKey("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"),
+		WithCreds(creds),
+		WithRetryPolicy(nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestFetchAllPages(t *testing.T) {
+	server := newPageServer(t)
+	client := newPagedClient(t, server.URL)
+
+	// 各方法返回的 id 列表
+	calls := []struct {
+		name string
+		path string
+		call func(ctx context.Context, cursor string) ([]string, error)
+	}{
+		{"GetOrdersTyped", Orders, func(ctx context.Context, cursor string) ([]string, error) {
+			orders, err := client.GetOrdersTypedWithContext(ctx, &OpenOrderParams{Market: "0xcond"}, cursor)
+			ids := make([]string, len(orders))
+			for i, o := range orders {
+				ids[i] = o.ID
+			}
+			return ids, err
+		}},
+		{"GetTradesTyped", Trades, func(ctx context.Context, cursor string) ([]string, error) {
+			trades, err := client.GetTradesTypedWithContext(ctx, nil, cursor)
+			ids := make([]string, len(trades))
+			for i, tr := range trades {
+				ids[i] = tr.ID
+			}
+			return ids, err
+		}},
+		{"GetBuilderTradesTyped", GetBuilderTrades, func(ctx context.Context, cursor string) ([]string, error) {
+			trades, err := client.GetBuilderTradesTypedWithContext(ctx, nil, cursor)
+			ids := make([]string, len(trades))
+			for i, tr := range trades {
+				ids[i] = tr.ID
+			}
+			return ids, err
+		}},
+		{"GetOrders", Orders, func(ctx context.Context, cursor string) ([]string, error) {
+			return rawIDs(client.GetOrdersWithContext(ctx, nil, cursor))
+		}},
+		{"GetTrades", Trades, func(ctx context.Context, cursor string) ([]string, error) {
+			return rawIDs(client.GetTradesWithContext(ctx, nil, cursor))
+		}},
+		{"GetBuilderTrades", GetBuilderTrades, func(ctx context.Context, cursor string) ([]string, error) {
+			return rawIDs(client.GetBuilderTradesWithContext(ctx, nil, cursor))
+		}},
+	}
+
+	for _, c := range calls {
+		ctx := context.Background()
+
+		// 从第一页取到 EndCursor
+		ids, err := c.call(ctx, "")
+		if err != nil || !reflect.DeepEqual(ids, []string{"1", "2", "3", "4", "5"}) {
+			t.Errorf("%s: ids = %v, err = %v", c.name, ids, err)
+		}
+		want := []string{c.path + "@MA==", c.path + "@Mg==", c.path + "@NA=="}
+		if reqs := server.requests(); !reflect.DeepEqual(reqs, want) {
+			t.Errorf("%s: requests = %v, want %v", c.name, reqs, want)
+		}
+
+		// 从指定游标继续
+		ids, err = c.call(ctx, "NA==")
+		if err != nil || !reflect.DeepEqual(ids, []string{"4", "5"}) {
+			t.Errorf("%s from NA==: ids = %v, err = %v", c.name, ids, err)
+		}
+		server.requests()
+
+		// 从 EndCursor 开始不发送请求
+		ids, err = c.call(ctx, EndCursor)
+		if err != nil || len(ids) != 0 {
+			t.Errorf("%s from EndCursor: ids = %v, err = %v", c.name, ids, err)
+		}
+		if reqs := server.requests(); len(reqs) != 0 {
+			t.Errorf("%s from EndCursor: requests = %v", c.name, reqs)
+		}
+
+		// 中间页出错时返回错误，不返回部分数据
+		server.mu.Lock()
+		server.failAt = "Mg=="
+		server.mu.Unlock()
+		ids, err = c.call(ctx, "")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(ids) != 0 {
+			t.Errorf("%s with failing page: ids = %v, err = %v", c.name, ids, err)
+		}
+		if reqs := server.requests(); len(reqs) != 2 {
+			t.Errorf("%s with failing page: requests = %v", c.name, reqs)
+		}
+		server.mu.Lock()
+		server.failAt = ""
+		server.mu.Unlock()
+
+		// 请求第二页时取消：不再请求后续页
+		ctx, cancel := context.WithCancel(context.Background())
+		server.mu.Lock()
+		server.onCursor = func(cursor string) {
+			if cursor == "Mg==" {
+				cancel()
+			}
+		}
+		server.mu.Unlock()
+		ids, err = c.call(ctx, "")
+		if !errors.Is(err, context.Canceled) || len(ids) != 0 {
+			t.Errorf("%s cancelled: ids = %v, err = %v", c.name, ids, err)
+		}
+		if reqs := server.requests(); len(reqs) != 2 {
+			t.Errorf("%s cancelled: requests = %v", c.name, reqs)
+		}
+		server.mu.Lock()
+		server.onCursor = nil
+		server.mu.Unlock()
+		cancel()
+	}
+}
+
+// rawIDs 提取原始结果中的 id
+func rawIDs(items []interface{}, err error) ([]string, error) {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i], _ = item.(map[string]interface{})["id"].(string)
+	}
+	return ids, err
+}
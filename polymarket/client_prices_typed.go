@@ -0,0 +1,153 @@
+package polymarket
+
+import (
+	"context"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// GetMidpointTyped 获取中点价格（返回数值）
+func (c *ClobClient) GetMidpointTyped(tokenID string) (decimal.Decimal, error) {
+	return c.GetMidpointTypedWithContext(context.Background(), tokenID)
+}
+
+// GetMidpointTypedWithContext 获取中点价格（返回数值，支持context）
+func (c *ClobClient) GetMidpointTypedWithContext(ctx context.Context, tokenID string) (decimal.Decimal, error) {
+	var resp map[string]*flexDecimal
+	if err := c.httpClient.CallInto(ctx, "GET", tokenQuery(tokenID).Path(MidPoint), nil, CallOptions{}, &resp); err != nil {
+		return decimal.Zero, err
+	}
+	return singleValue(resp, "mid", tokenID)
+}
+
+// GetMidpointsTyped 获取多个token的中点价格（按 token_id 索引）
+func (c *ClobClient) GetMidpointsTyped(params []BookParams) (map[string]decimal.Decimal, error) {
+	return c.GetMidpointsTypedWithContext(context.Background(), params)
+}
+
+// GetMidpointsTypedWithContext 获取多个token的中点价格（按 token_id 索引，支持context）
+func (c *ClobClient) GetMidpointsTypedWithContext(ctx context.Context, params []BookParams) (map[string]decimal.Decimal, error) {
+	return c.postDecimalsByToken(ctx, MidPoints, params)
+}
+
+// GetPriceTyped 获取市场价格（返回数值）
+func (c *ClobClient) GetPriceTyped(tokenID, side string) (decimal.Decimal, error) {
+	return c.GetPriceTypedWithContext(context.Background(), tokenID, side)
+}
+
+// GetPriceTypedWithContext 获取市场价格（返回数值，支持context）
+func (c *ClobClient) GetPriceTypedWithContext(ctx context.Context, tokenID, side string) (decimal.Decimal, error) {
+	var resp map[string]*flexDecimal
+	path := tokenQuery(tokenID).String("side", side).Path(Price)
+	if err := c.httpClient.CallInto(ctx, "GET", path, nil, CallOptions{}, &resp); err != nil {
+		return decimal.Zero, err
+	}
+	return singleValue(resp, "price", tokenID)
+}
+
+// GetPricesTyped 获取多个token的市场价格（按 token_id 索引）
+func (c *ClobClient) GetPricesTyped(params []BookParams) (map[string]TokenPrice, error) {
+	return c.GetPricesTypedWithContext(context.Background(), params)
+}
+
+// GetPricesTypedWithContext 获取多个token的市场价格（按 token_id 索引，支持context）
+func (c *ClobClient) GetPricesTypedWithContext(ctx context.Context, params []BookParams) (map[string]TokenPrice, error) {
+	var resp map[string]TokenPrice
+	if err := c.httpClient.CallInto(ctx, "POST", GetPrices, bookParamsBody(params, true), CallOptions{Idempotent: true}, &resp); err != nil {
+		return nil, err
+	}
+	if err := requireTokens(resp, params); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSpreadTyped 获取价差（返回数值）
+func (c *ClobClient) GetSpreadTyped(tokenID string) (decimal.Decimal, error) {
+	return c.GetSpreadTypedWithContext(context.Background(), tokenID)
+}
+
+// GetSpreadTypedWithContext 获取价差（返回数值，支持context）
+func (c *ClobClient) GetSpreadTypedWithContext(ctx context.Context, tokenID string) (decimal.Decimal, error) {
+	var resp map[string]*flexDecimal
+	if err := c.httpClient.CallInto(ctx, "GET", tokenQuery(tokenID).Path(GetSpread), nil, CallOptions{}, &resp); err != nil {
+		return decimal.Zero, err
+	}
+	return singleValue(resp, "spread", tokenID)
+}
+
+// GetSpreadsTyped 获取多个token的价差（按 token_id 索引）
+func (c *ClobClient) GetSpreadsTyped(params []BookParams) (map[string]decimal.Decimal, error) {
+	return c.GetSpreadsTypedWithContext(context.Background(), params)
+}
+
+// GetSpreadsTypedWithContext 获取多个token的价差（按 token_id 索引，支持context）
+func (c *ClobClient) GetSpreadsTypedWithContext(ctx context.Context, params []BookParams) (map[string]decimal.Decimal, error) {
+	return c.postDecimalsByToken(ctx, GetSpreads, params)
+}
+
+// GetLastTradePriceTyped 获取最后成交价格和方向
+func (c *ClobClient) GetLastTradePriceTyped(tokenID string) (*LastTrade, error) {
+	return c.GetLastTradePriceTypedWithContext(context.Background(), tokenID)
+}
+
+// GetLastTradePriceTypedWithContext 获取最后成交价格和方向（支持context）
+func (c *ClobClient) GetLastTradePriceTypedWithContext(ctx context.Context, tokenID string) (*LastTrade, error) {
+	var trade LastTrade
+	if err := c.httpClient.CallInto(ctx, "GET", tokenQuery(tokenID).Path(GetLastTradePrice), nil, CallOptions{}, &trade); err != nil {
+		return nil, err
+	}
+	trade.TokenID = tokenID
+	return &trade, nil
+}
+
+// GetLastTradesPricesTyped 获取多个token的最后成交价格（按 token_id 索引）
+func (c *ClobClient) GetLastTradesPricesTyped(params []BookParams) (map[string]LastTrade, error) {
+	return c.GetLastTradesPricesTypedWithContext(context.Background(), params)
+}
+
+// GetLastTradesPricesTypedWithContext 获取多个token的最后成交价格（按 token_id 索引，支持context）
+func (c *ClobClient) GetLastTradesPricesTypedWithContext(ctx context.Context, params []BookParams) (map[string]LastTrade, error) {
+	var trades []LastTrade
+	if err := c.httpClient.CallInto(ctx, "POST", GetLastTradesPrices, bookParamsBody(params, false), CallOptions{Idempotent: true}, &trades); err != nil {
+		return nil, err
+	}
+
+	resp := make(map[string]LastTrade, len(trades))
+	for _, t := range trades {
+		resp[t.TokenID] = t
+	}
+	if err := requireTokens(resp, params); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// postDecimalsByToken 请求按 token_id 索引的数值（如 {"<token_id>": "0.55"}）
+func (c *ClobClient) postDecimalsByToken(ctx context.Context, endpoint string, params []BookParams) (map[string]decimal.Decimal, error) {
+	var raw map[string]flexDecimal
+	if err := c.httpClient.CallInto(ctx, "POST", endpoint, bookParamsBody(params, false), CallOptions{Idempotent: true}, &raw); err != nil {
+		return nil, err
+	}
+	if err := requireTokens(raw, params); err != nil {
+		return nil, err
+	}
+
+	resp := make(map[string]decimal.Decimal, len(raw))
+	for tokenID, v := range raw {
+		resp[tokenID] = decimal.Decimal(v)
+	}
+	return resp, nil
+}
+
+// bookParamsBody 构建批量价格类接口的请求体
+func bookParamsBody(params []BookParams, withSide bool) []map[string]string {
+	body := make([]map[string]string, len(params))
+	for i, p := range params {
+		body[i] = map[string]string{"token_id": p.TokenID}
+		if withSide {
+			body[i]["side"] = p.Side
+		}
+	}
+	return body
+}
@@ -1,5 +1,10 @@
 package polymarket
 
+import (
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+)
+
 // Access levels
 const (
 	L0 = 0 // No authentication
@@ -23,7 +28,8 @@ Be sure to store them safely!
 	Amoy   = 80002
 	Polygon = 137
 
-	EndCursor = "LTE="
+	StartCursor = pagination.StartCursor
+	EndCursor   = pagination.EndCursor
 )
 
 // Order sides
@@ -52,6 +58,11 @@ const (
 	TickSize00001 TickSize = "0.0001"
 )
 
+// Decimal 返回 tick size 的定点小数值
+func (t TickSize) Decimal() (decimal.Decimal, error) {
+	return decimal.Parse(string(t))
+}
+
 // Signature types
 const (
 	SignatureTypeEOA = 0 // Externally Owned Account
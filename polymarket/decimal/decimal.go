@@ -0,0 +1,384 @@
+// Package decimal 提供定点小数类型 Decimal，以 6 位小数的整数单位存储
+//
+// CLOB 的 USDC 和条件代币精度都是 1e6，价格、数量、金额用 Decimal 表示时
+// 不存在 float64 的二进制舍入误差，可以直接转换为链上的 maker/taker amount
+package decimal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Places 小数位数
+const Places = 6
+
+// unit 1 对应的整数单位数（10^Places）
+const unit = 1_000_000
+
+var (
+	// Zero 零值
+	Zero = Decimal{}
+	// One 1
+	One = Decimal{units: unit}
+)
+
+var (
+	bigUnit  = big.NewInt(unit)
+	bigMinI  = big.NewInt(math.MinInt64)
+	bigMaxI  = big.NewInt(math.MaxInt64)
+	pow10Tab = [...]int64{1, 10, 100, 1_000, 10_000, 100_000, 1_000_000}
+)
+
+// ErrPrecision 数值超过 6 位小数，无法精确表示
+var ErrPrecision = errors.New("decimal: more than 6 decimal places")
+
+// ErrOverflow 数值超出表示范围
+var ErrOverflow = errors.New("decimal: overflow")
+
+// Decimal 定点小数，零值为 0
+type Decimal struct {
+	units int64 // 值 * 10^6
+}
+
+// FromUnits 由整数单位（值 * 10^6）创建，如 FromUnits(550000) 为 0.55
+func FromUnits(units int64) Decimal {
+	return Decimal{units: units}
+}
+
+// FromInt 由整数创建
+func FromInt(i int64) Decimal {
+	if i > math.MaxInt64/unit || i < math.MinInt64/unit {
+		panic(ErrOverflow)
+	}
+	return Decimal{units: i * unit}
+}
+
+// FromFloat 由 float64 创建，按十进制表示四舍五入到 6 位小数
+// NaN 和无穷大返回错误，绝对值超过约 9.2e12 时返回 ErrOverflow
+func FromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Zero, fmt.Errorf("decimal: invalid number %v", f)
+	}
+	return Parse(strconv.FormatFloat(f, 'f', Places, 64))
+}
+
+// Parse 解析十进制字符串（如 "0.55"、"-12"、"1e-3"），超过 6 位小数时返回 ErrPrecision
+func Parse(s string) (Decimal, error) {
+	return parse(s, false)
+}
+
+// ParseRound 与 Parse 相同，但超过 6 位小数的部分四舍五入（0.5 远离零）而不返回错误，
+// 用于解析 API 返回的数值（如按成交额计算的手续费）
+func ParseRound(s string) (Decimal, error) {
+	return parse(s, true)
+}
+
+// parse 解析十进制字符串，round 为 false 时超出精度返回 ErrPrecision
+func parse(s string, round bool) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, fmt.Errorf("decimal: empty string")
+	}
+	if !isDecimalLiteral(s) {
+		return Zero, fmt.Errorf("decimal: invalid number %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Zero, fmt.Errorf("decimal: invalid number %q", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(bigUnit))
+	if r.IsInt() {
+		return fromBig(r.Num())
+	}
+	if !round {
+		return Zero, fmt.Errorf("%w: %q", ErrPrecision, s)
+	}
+	// 按绝对值加 0.5 后截断
+	num := new(big.Int).Abs(r.Num())
+	num.Mul(num, big.NewInt(2)).Add(num, r.Denom())
+	units := num.Quo(num, new(big.Int).Mul(r.Denom(), big.NewInt(2)))
+	if r.Sign() < 0 {
+		units.Neg(units)
+	}
+	return fromBig(units)
+}
+
+// MustParse 与 Parse 相同，出错时 panic（用于常量）
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// FromBigUnits 由 *big.Int 整数单位（值 * 10^6）创建，超出范围时返回 ErrOverflow
+func FromBigUnits(units *big.Int) (Decimal, error) {
+	return fromBig(units)
+}
+
+// fromBig 由整数单位创建，超出 int64 范围时返回 ErrOverflow
+func fromBig(units *big.Int) (Decimal, error) {
+	if units.Cmp(bigMinI) < 0 || units.Cmp(bigMaxI) > 0 {
+		return Zero, ErrOverflow
+	}
+	return Decimal{units: units.Int64()}, nil
+}
+
+// Units 返回整数单位（值 * 10^6），即链上 6 位精度的金额
+func (d Decimal) Units() int64 {
+	return d.units
+}
+
+// BigInt 以 *big.Int 返回整数单位
+func (d Decimal) BigInt() *big.Int {
+	return big.NewInt(d.units)
+}
+
+// Float64 返回最接近的 float64
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String 返回最短的十进制表示（去掉末尾的 0），如 "0.55"、"5"
+func (d Decimal) String() string {
+	s := d.StringFixed(Places)
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// StringFixed 返回固定小数位数的表示，places 小于 6 时按四舍五入截取
+func (d Decimal) StringFixed(places int) string {
+	places = clampPlaces(places)
+	units := d.Round(places).units
+
+	neg := units < 0
+	abs := uint64(units)
+	if neg {
+		abs = -abs
+	}
+	intPart := abs / unit
+	frac := fmt.Sprintf("%06d", abs%unit)[:places]
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(strconv.FormatUint(intPart, 10))
+	if places > 0 {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// IsZero 是否为 0
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+// Sign 返回 -1、0 或 1
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// Cmp 比较大小：d < o 返回 -1，相等返回 0，d > o 返回 1
+func (d Decimal) Cmp(o Decimal) int {
+	switch {
+	case d.units < o.units:
+		return -1
+	case d.units > o.units:
+		return 1
+	}
+	return 0
+}
+
+// Neg 返回 -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{units: -d.units}
+}
+
+// Add 返回 d + o，溢出时 panic
+func (d Decimal) Add(o Decimal) Decimal {
+	sum := d.units + o.units
+	if (sum > d.units) != (o.units > 0) {
+		panic(ErrOverflow)
+	}
+	return Decimal{units: sum}
+}
+
+// Sub 返回 d - o，溢出时 panic
+func (d Decimal) Sub(o Decimal) Decimal {
+	diff := d.units - o.units
+	if (diff < d.units) != (o.units > 0) {
+		panic(ErrOverflow)
+	}
+	return Decimal{units: diff}
+}
+
+// Mul 返回 d * o，超过 6 位小数的部分向零截断，溢出时 panic
+// 价格（≤4 位小数）乘以数量（≤2 位小数）的结果总是精确的
+func (d Decimal) Mul(o Decimal) Decimal {
+	p := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(o.units))
+	p.Quo(p, bigUnit)
+	r, err := fromBig(p)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Quo 返回 d / o，超过 6 位小数的部分向零截断，o 为 0 或溢出时 panic
+func (d Decimal) Quo(o Decimal) Decimal {
+	if o.units == 0 {
+		panic("decimal: division by zero")
+	}
+	q := new(big.Int).Mul(big.NewInt(d.units), bigUnit)
+	q.Quo(q, big.NewInt(o.units))
+	r, err := fromBig(q)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// RoundDown 向下（向负无穷）舍入到 places 位小数，溢出时 panic
+func (d Decimal) RoundDown(places int) Decimal {
+	step := stepUnits(places)
+	r := d.units % step
+	if r < 0 {
+		r += step
+	}
+	return d.Sub(Decimal{units: r})
+}
+
+// RoundUp 向上（向正无穷）舍入到 places 位小数，溢出时 panic
+func (d Decimal) RoundUp(places int) Decimal {
+	down := d.RoundDown(places)
+	if down.units == d.units {
+		return d
+	}
+	return down.Add(Decimal{units: stepUnits(places)})
+}
+
+// Round 四舍五入（0.5 远离零）到 places 位小数，与 math.Round 一致，溢出时 panic
+func (d Decimal) Round(places int) Decimal {
+	step := stepUnits(places)
+	r := d.units % step // 与 d 同号，|r| < step
+	truncated := Decimal{units: d.units - r}
+	switch {
+	case r > 0 && r*2 >= step:
+		return truncated.Add(Decimal{units: step})
+	case r < 0 && -r*2 >= step:
+		return truncated.Sub(Decimal{units: step})
+	}
+	return truncated
+}
+
+// DecimalPlaces 返回有效小数位数（不计末尾的 0），如 0.550 返回 2
+func (d Decimal) DecimalPlaces() int {
+	if d.units == 0 {
+		return 0
+	}
+	places := Places
+	for u := d.units; places > 0 && u%10 == 0; u /= 10 {
+		places--
+	}
+	return places
+}
+
+// IsMultipleOf 是否为 step 的整数倍（如检查价格是否符合 tick size），step 为 0 时返回 false
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	return step.units != 0 && d.units%step.units == 0
+}
+
+// MarshalJSON 序列化为 JSON 字符串（如 "0.55"），与 API 的数值格式一致
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON 兼容 JSON 字符串、数字和 null（null 和空字符串为 0）
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*d = Zero
+			return nil
+		}
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// stepUnits 返回 places 位小数对应的最小单位
+func stepUnits(places int) int64 {
+	return pow10Tab[Places-clampPlaces(places)]
+}
+
+// clampPlaces 将小数位数限制在 [0, Places]
+func clampPlaces(places int) int {
+	if places < 0 {
+		return 0
+	}
+	if places > Places {
+		return Places
+	}
+	return places
+}
+
+// isDecimalLiteral 检查是否为十进制数字（可带符号、小数点和指数），
+// 排除 big.Rat 额外接受的分数（"1/2"）和进制前缀（"0x10"）
+func isDecimalLiteral(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	mantissa, exp, hasExp := strings.Cut(strings.ToLower(s), "e")
+	intPart, frac, _ := strings.Cut(mantissa, ".")
+	if intPart == "" && frac == "" || !allDigits(intPart) || !allDigits(frac) {
+		return false
+	}
+	if hasExp {
+		if exp != "" && (exp[0] == '+' || exp[0] == '-') {
+			exp = exp[1:]
+		}
+		return exp != "" && allDigits(exp)
+	}
+	return true
+}
+
+// allDigits 是否只包含 0-9（空字符串返回 true）
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in    string
+		units int64
+		err   error
+	}{
+		{"0.55", 550_000, nil},
+		{"-12", -12_000_000, nil},
+		{"+3.5", 3_500_000, nil},
+		{".5", 500_000, nil},
+		{"0.000001", 1, nil},
+		{"0.5500000", 550_000, nil}, // 末尾的 0 不计入精度
+		{"  0.55\t\n", 550_000, nil},
+		{"1e-3", 1_000, nil},
+		{"1.5E2", 150_000_000, nil},
+		{"-2.5e-6", -2, ErrPrecision},
+		{"1e-7", 0, ErrPrecision},
+		{"0.0000001", 0, ErrPrecision},
+		{"0.1234567", 0, ErrPrecision},
+		{"9223372036854.775807", math.MaxInt64, nil},
+		{"-9223372036854.775808", math.MinInt64, nil},
+		{"9223372036854.775808", 0, ErrOverflow},
+		{"1e13", 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || d.Units() != tt.units {
+			t.Errorf("Parse(%q) = %d, %v, want %d", tt.in, d.Units(), err, tt.units)
+		}
+	}
+
+	for _, in := range []string{"", "   ", ".", "-", "abc", "1.2.3", "0x10", "1/2", "1e", "1e+", "1_000", "- 1"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) expected error", in)
+		}
+	}
+}
+
+func TestParseRound(t *testing.T) {
+	tests := []struct {
+		in    string
+		units int64
+	}{
+		{"0.55", 550_000},
+		{"0.1234564", 123_456},
+		{"0.1234565", 123_457},
+		{"-0.1234565", -123_457},
+		{"-0.0000004", 0},
+		{"1e-7", 0},
+		{"5e-7", 1},
+		{"9223372036854.7758074", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		d, err := ParseRound(tt.in)
+		if err != nil || d.Units() != tt.units {
+			t.Errorf("ParseRound(%q) = %d, %v, want %d", tt.in, d.Units(), err, tt.units)
+		}
+	}
+	for _, in := range []string{"", "abc", "0x10"} {
+		if _, err := ParseRound(in); err == nil {
+			t.Errorf("ParseRound(%q) expected error", in)
+		}
+	}
+	if _, err := ParseRound("9223372036854.7758075"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseRound overflow error = %v", err)
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		in    float64
+		units int64
+		ok    bool
+	}{
+		{0.29, 290_000, true},
+		{0.57, 570_000, true},
+		{1.0000005, 1_000_001, true}, // 按十进制表示四舍五入
+		{-0.1, -100_000, true},
+		{9.2e12, 9_200_000_000_000_000_000, true},
+		{-9.2e12, -9_200_000_000_000_000_000, true},
+		{9.3e12, 0, false},
+		{-1e15, 0, false},
+		{math.MaxFloat64, 0, false},
+		{math.NaN(), 0, false},
+		{math.Inf(1), 0, false},
+		{math.Inf(-1), 0, false},
+	}
+	for _, tt := range tests {
+		d, err := FromFloat(tt.in)
+		if (err == nil) != tt.ok || d.Units() != tt.units {
+			t.Errorf("FromFloat(%v) = %d, %v", tt.in, d.Units(), err)
+		}
+	}
+	if _, err := FromFloat(1e13); !errors.Is(err, ErrOverflow) {
+		t.Errorf("FromFloat(1e13) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		down   string
+		up     string
+		round  string
+	}{
+		{"1.2345", 2, "1.23", "1.24", "1.23"},
+		{"1.235", 2, "1.23", "1.24", "1.24"},
+		{"-1.2345", 2, "-1.24", "-1.23", "-1.23"},
+		{"-1.235", 2, "-1.24", "-1.23", "-1.24"}, // 0.5 远离零
+		{"-0.5", 0, "-1", "0", "-1"},
+		{"0.5", 0, "0", "1", "1"},
+		{"-0.000001", 3, "-0.001", "0", "0"},
+		{"2.5", 0, "2", "3", "3"},
+		{"-2.5", 0, "-3", "-2", "-3"},
+		{"7", 2, "7", "7", "7"},
+		{"-7.1", -1, "-8", "-7", "-7"}, // places 小于 0 按 0 处理
+		{"0.1234567e1", 9, "1.234567", "1.234567", "1.234567"},
+	}
+	for _, tt := range tests {
+		d := MustParse(tt.in)
+		if got := d.RoundDown(tt.places).String(); got != tt.down {
+			t.Errorf("%s.RoundDown(%d) = %s, want %s", tt.in, tt.places, got, tt.down)
+		}
+		if got := d.RoundUp(tt.places).String(); got != tt.up {
+			t.Errorf("%s.RoundUp(%d) = %s, want %s", tt.in, tt.places, got, tt.up)
+		}
+		if got := d.Round(tt.places).String(); got != tt.round {
+			t.Errorf("%s.Round(%d) = %s, want %s", tt.in, tt.places, got, tt.round)
+		}
+	}
+}
+
+func TestStringFixed(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		want   string
+	}{
+		{"0.55", 6, "0.550000"},
+		{"0.55", 2, "0.55"},
+		{"0.555", 2, "0.56"},
+		{"-0.555", 2, "-0.56"},
+		{"-0.004", 2, "0.00"},
+		{"12", 0, "12"},
+		{"12.5", 0, "13"},
+		{"1.5", 9, "1.500000"},
+		{"-9223372036854.775808", 6, "-9223372036854.775808"},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.in).StringFixed(tt.places); got != tt.want {
+			t.Errorf("%s.StringFixed(%d) = %s, want %s", tt.in, tt.places, got, tt.want)
+		}
+	}
+	if got := MustParse("0.550").String(); got != "0.55" {
+		t.Errorf("String() = %s, want 0.55", got)
+	}
+	if got := MustParse("-3").String(); got != "-3" {
+		t.Errorf("String() = %s, want -3", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		in    string
+		units int64
+		ok    bool
+	}{
+		{`"0.55"`, 550_000, true},
+		{`0.55`, 550_000, true},
+		{`12`, 12_000_000, true},
+		{`1e-3`, 1_000, true},
+		{`null`, 0, true},
+		{`""`, 0, true},
+		{` "1.5" `, 1_500_000, true},
+		{`"0.1234567"`, 0, false},
+		{`"abc"`, 0, false},
+		{`true`, 0, false},
+	}
+	for _, tt := range tests {
+		var d Decimal
+		err := json.Unmarshal([]byte(tt.in), &d)
+		if (err == nil) != tt.ok || d.Units() != tt.units {
+			t.Errorf("Unmarshal(%s) = %d, %v", tt.in, d.Units(), err)
+		}
+	}
+
+	var v struct {
+		Price Decimal  `json:"price"`
+		Size  *Decimal `json:"size"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":"0.57","size":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"price":"0.57","size":null}` {
+		t.Errorf("Marshal = %s", out)
+	}
+}
+
+// expectPanic 断言 fn 发生 panic
+func expectPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: expected panic", name)
+		}
+	}()
+	fn()
+}
+
+func TestOverflow(t *testing.T) {
+	max := FromUnits(math.MaxInt64)
+	min := FromUnits(math.MinInt64)
+	unitStep := FromUnits(1)
+
+	// 边界上的运算不溢出
+	if got := max.Sub(unitStep).Add(unitStep); got != max {
+		t.Errorf("max-1+1 = %v", got)
+	}
+	if got := min.Add(unitStep).Sub(unitStep); got != min {
+		t.Errorf("min+1-1 = %v", got)
+	}
+	if got := max.Add(min); got.Units() != -1 {
+		t.Errorf("max+min = %d", got.Units())
+	}
+	if got := max.Mul(One); got != max {
+		t.Errorf("max*1 = %v", got)
+	}
+	if got := min.Quo(One); got != min {
+		t.Errorf("min/1 = %v", got)
+	}
+	if got := max.Quo(FromInt(2)); got.Units() != math.MaxInt64/2 {
+		t.Errorf("max/2 = %d", got.Units())
+	}
+	if got := MustParse("3037000").Mul(MustParse("3037000")); got.String() != "9223369000000" {
+		t.Errorf("3037000^2 = %v", got)
+	}
+
+	expectPanic(t, "max+1", func() { max.Add(unitStep) })
+	expectPanic(t, "min-1", func() { min.Sub(unitStep) })
+	expectPanic(t, "min+(-1)", func() { min.Add(unitStep.Neg()) })
+	expectPanic(t, "max-(-1)", func() { max.Sub(unitStep.Neg()) })
+	expectPanic(t, "max*2", func() { max.Mul(FromInt(2)) })
+	expectPanic(t, "min*-1", func() { min.Mul(One.Neg()) })
+	expectPanic(t, "max/0.5", func() { max.Quo(MustParse("0.5")) })
+	expectPanic(t, "min/-1", func() { min.Quo(One.Neg()) })
+	expectPanic(t, "1/0", func() { One.Quo(Zero) })
+	expectPanic(t, "FromInt", func() { FromInt(math.MaxInt64 / 1_000_000 * 2) })
+}
@@ -0,0 +1,147 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
+)
+
+// 常见错误（可配合 errors.Is 使用）
+var (
+	ErrUnauthorized = errors.New("unauthorized") // 401/403：认证失败
+	ErrNotFound     = errors.New("not found")    // 404
+	ErrRateLimited  = errors.New("rate limited") // 429：触发限流
+	ErrServerError  = errors.New("server error") // 5xx
+	ErrBadRequest   = errors.New("bad request")  // 400：请求被拒绝
+
+	// ErrClientRateLimited 客户端限流器处于快速失败模式且令牌不足，请求未发出
+	ErrClientRateLimited = errors.New("client-side rate limit exceeded")
+
+	// ErrTokenNotInResponse 价格类接口的响应中缺少请求的 token
+	ErrTokenNotInResponse = errors.New("token missing from response")
+
+	// ErrOutcomeNotFound 市场中没有指定名称的结果
+	ErrOutcomeNotFound = errors.New("outcome not found in market")
+
+	// CLOB 下单拒绝原因（根据服务器返回的 error 字段匹配）
+	ErrNotEnoughBalance  = errors.New("not enough balance / allowance")
+	ErrInvalidTickSize   = errors.New("price breaks minimum tick size rules")
+	ErrOrderSizeTooSmall = errors.New("size lower than the minimum")
+	ErrDuplicateOrder    = errors.New("duplicated order")
+	ErrInvalidExpiration = errors.New("invalid expiration")
+	ErrPostOnlyCrosses   = errors.New("post-only order crosses book")
+	ErrFOKNotFilled      = errors.New("FOK order not fully filled")
+	ErrMarketNotReady    = errors.New("market not ready")
+)
+
+// rejectionReasons 服务器错误信息（小写子串）到常见错误的映射
+var rejectionReasons = []struct {
+	substr string
+	err    error
+}{
+	{"not enough balance", ErrNotEnoughBalance},
+	{"minimum tick size", ErrInvalidTickSize},
+	{"lower than the minimum", ErrOrderSizeTooSmall},
+	{"duplicated", ErrDuplicateOrder},
+	{"invalid expiration", ErrInvalidExpiration},
+	{"crosses book", ErrPostOnlyCrosses},
+	{"fok orders are fully filled", ErrFOKNotFilled},
+	{"fully filled or killed", ErrFOKNotFilled},
+	{"not yet ready", ErrMarketNotReady},
+}
+
+// requestIDHeaders 可能携带请求ID的响应头（按优先级）
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+// APIError API 返回非 200 状态码时的错误
+// 适用于 ClobClient、RfqClient 以及 gasless 中继请求，可通过 errors.As 获取，
+// 或通过 errors.Is 与 ErrRateLimited、ErrNotEnoughBalance 等常见错误比较
+type APIError struct {
+	StatusCode int    // HTTP 状态码
+	Message    string // 服务器返回的 error 字段，无法解析时为原始响应体
+	Body       string // 原始响应体
+	Method     string // 请求方法
+	Endpoint   string // 请求路径或中继URL（不含查询参数）
+	RequestID  string // 请求ID（如果响应头中有）
+	Retryable  bool   // 是否可重试（429、500、502、503、504）
+}
+
+// NewAPIError 根据 HTTP 响应构建 APIError
+func NewAPIError(method, endpoint string, resp *http.Response, body []byte) *APIError {
+	endpoint = query.StripQuery(endpoint)
+
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    parseErrorMessage(body),
+		Body:       string(body),
+		Method:     method,
+		Endpoint:   endpoint,
+		Retryable:  isRetryableStatus(resp.StatusCode),
+	}
+	for _, h := range requestIDHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			e.RequestID = v
+			break
+		}
+	}
+	return e
+}
+
+// Error 实现 error 接口（保持与之前的错误文本一致）
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is 支持 errors.Is 与常见错误比较
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	}
+
+	msg := strings.ToLower(e.Message)
+	for _, r := range rejectionReasons {
+		if r.err == target && strings.Contains(msg, r.substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseErrorMessage 从响应体中提取 error 字段
+func parseErrorMessage(body []byte) string {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, key := range []string{"error", "errorMsg", "message"} {
+			if msg, ok := payload[key].(string); ok && msg != "" {
+				return msg
+			}
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// isRetryableStatus 判断状态码是否属于可重试的临时错误
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package polymarket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{
+		ErrUnauthorized, ErrNotFound, ErrRateLimited, ErrServerError, ErrBadRequest,
+		ErrNotEnoughBalance, ErrInvalidTickSize, ErrOrderSizeTooSmall, ErrDuplicateOrder,
+		ErrInvalidExpiration, ErrPostOnlyCrosses, ErrFOKNotFilled, ErrMarketNotReady,
+	}
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   []error
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"error":"Unauthorized/Invalid api key"}`, []error{ErrUnauthorized}},
+		{"forbidden", http.StatusForbidden, `forbidden`, []error{ErrUnauthorized}},
+		{"not found", http.StatusNotFound, `{"error":"market not found"}`, []error{ErrNotFound}},
+		{"rate limited", http.StatusTooManyRequests, `{"error":"Too Many Requests"}`, []error{ErrRateLimited}},
+		{"server error", http.StatusInternalServerError, `{"error":"internal"}`, []error{ErrServerError}},
+		{"bad gateway", http.StatusBadGateway, `<html>bad gateway</html>`, []error{ErrServerError}},
+		{"plain bad request", http.StatusBadRequest, `{"error":"invalid order payload"}`, []error{ErrBadRequest}},
+		{"balance", http.StatusBadRequest,
+			`{"error":"not enough balance / allowance: the balance is not enough -> balance: 0, order amount: 5"}`,
+			[]error{ErrBadRequest, ErrNotEnoughBalance}},
+		{"tick size", http.StatusBadRequest, `{"error":"order 0x1 is invalid. Price (0.555) breaks minimum tick size rule: 0.01"}`,
+			[]error{ErrBadRequest, ErrInvalidTickSize}},
+		{"size", http.StatusBadRequest, `{"error":"Size (1) lower than the minimum: 5"}`, []error{ErrBadRequest, ErrOrderSizeTooSmall}},
+		{"duplicate", http.StatusBadRequest, `{"errorMsg":"order 0x1 is invalid. Duplicated."}`, []error{ErrBadRequest, ErrDuplicateOrder}},
+		{"expiration", http.StatusBadRequest, `{"error":"invalid expiration value"}`, []error{ErrBadRequest, ErrInvalidExpiration}},
+		{"post only", http.StatusBadRequest, `{"error":"invalid post-only order: order crosses book"}`, []error{ErrBadRequest, ErrPostOnlyCrosses}},
+		{"fok", http.StatusBadRequest, `{"error":"order couldn't be fully filled. FOK orders are fully filled or killed."}`,
+			[]error{ErrBadRequest, ErrFOKNotFilled}},
+		{"market not ready", http.StatusBadRequest, `{"message":"the market is not yet ready to process new orders"}`,
+			[]error{ErrBadRequest, ErrMarketNotReady}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			err := fmt.Errorf("post order: %w", NewAPIError(http.MethodPost, "/order?x=1", resp, []byte(tt.body)))
+			for _, target := range sentinels {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == target
+				}
+				if got := errors.Is(err, target); got != want {
+					t.Errorf("errors.Is(%q) = %v, want %v", target, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	resp.Header.Set("Cf-Ray", "ray-1")
+	resp.Header.Set("X-Request-Id", "req-1")
+	err := NewAPIError(http.MethodGet, "/book?token_id=1", resp, []byte(`{"error":"try later"}`))
+	if err.Message != "try later" || err.Endpoint != "/book" || err.RequestID != "req-1" || !err.Retryable {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}
+	err = NewAPIError(http.MethodPost, "/order", resp, []byte("  plain text  "))
+	if err.Message != "plain text" || err.RequestID != "" || err.Retryable {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
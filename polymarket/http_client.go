@@ -2,38 +2,151 @@ package polymarket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
 	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
 )
 
 // HTTPClient HTTP客户端
 type HTTPClient struct {
 	client  *http.Client
 	baseURL string
+	retry   *RetryPolicy
+	limiter *RateLimiter
+
+	userAgent string
+	logger    *slog.Logger
+	sleep     func(ctx context.Context, d time.Duration) error // 重试前等待，测试时替换
 }
 
-// NewHTTPClient 创建新的HTTP客户端
+// NewHTTPClient 创建新的HTTP客户端（使用默认重试策略）
 func NewHTTPClient(baseURL string) *HTTPClient {
 	return &HTTPClient{
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseURL: baseURL,
+		baseURL:   baseURL,
+		retry:     DefaultRetryPolicy(),
+		userAgent: "polymarket-sdk-go",
+		logger:    slog.New(slog.DiscardHandler),
+		sleep:     sleepContext,
 	}
 }
 
+// SetRetryPolicy 设置重试策略，nil 表示不重试
+func (c *HTTPClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.retry = policy
+}
+
+// RetryPolicy 获取当前重试策略
+func (c *HTTPClient) RetryPolicy() *RetryPolicy {
+	return c.retry
+}
+
+// SetRateLimiter 设置客户端限流器，nil 表示不限流
+// 同一个 RateLimiter 可以被多个 HTTPClient 共享
+func (c *HTTPClient) SetRateLimiter(limiter *RateLimiter) {
+	c.limiter = limiter
+}
+
+// RateLimiter 获取当前限流器
+func (c *HTTPClient) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
+// Use 在传输层追加中间件（先追加的位于外层），应在发送请求前调用
+func (c *HTTPClient) Use(middlewares ...Middleware) {
+	c.client = WrapHTTPClient(c.client, middlewares...)
+}
+
+// SetLogger 设置日志记录器（请求、响应、重试事件，认证头会被脱敏），nil 表示不输出日志
+func (c *HTTPClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	c.logger = logger
+}
+
+// Logger 获取日志记录器
+func (c *HTTPClient) Logger() *slog.Logger {
+	return c.logger
+}
+
+// CallOptions 单次调用的选项
+type CallOptions struct {
+	Headers map[string]string // 固定的请求头
+	// Sign 每次尝试前调用，返回的认证头会覆盖 Headers 中的同名字段
+	// L1/L2 认证头包含时间戳，重试时必须重新签名
+	Sign func() (map[string]string, error)
+	// Idempotent 请求是否幂等（可安全重复发送），GET 请求始终视为幂等
+	Idempotent bool
+}
+
 // Request 发送HTTP请求
 func (c *HTTPClient) Request(method, path string, headers map[string]string, body interface{}) (interface{}, error) {
-	url := c.baseURL + path
+	return c.RequestWithContext(context.Background(), method, path, headers, body)
+}
 
-	var reqBody io.Reader
+// RequestWithContext 发送HTTP请求，ctx 取消或超时时中断请求
+func (c *HTTPClient) RequestWithContext(ctx context.Context, method, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.Call(ctx, method, path, body, CallOptions{Headers: headers})
+}
+
+// SignedRequestWithContext 发送需要认证的请求，每次尝试前调用 sign 重新生成认证头
+func (c *HTTPClient) SignedRequestWithContext(ctx context.Context, method, path string, body interface{}, sign func() (map[string]string, error)) (interface{}, error) {
+	return c.Call(ctx, method, path, body, CallOptions{Sign: sign})
+}
+
+// SignedRequestIntoWithContext 发送需要认证的请求，并将JSON响应解析到 out
+func (c *HTTPClient) SignedRequestIntoWithContext(ctx context.Context, method, path string, body interface{}, sign func() (map[string]string, error), out interface{}) error {
+	return c.CallInto(ctx, method, path, body, CallOptions{Sign: sign}, out)
+}
+
+// Call 发送HTTP请求，并按重试策略重试临时错误
+// 响应为JSON时返回解析后的 interface{}，否则返回原始字符串
+func (c *HTTPClient) Call(ctx context.Context, method, path string, body interface{}, opts CallOptions) (interface{}, error) {
+	respBody, err := c.CallRaw(ctx, method, path, body, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 尝试解析JSON
+	var jsonData interface{}
+	if err := json.Unmarshal(respBody, &jsonData); err != nil {
+		// 如果不是JSON，返回原始字符串
+		return string(respBody), nil
+	}
+
+	return jsonData, nil
+}
+
+// CallInto 发送HTTP请求，并将JSON响应直接解析到 out（避免先解析为 interface{} 再转换）
+func (c *HTTPClient) CallInto(ctx context.Context, method, path string, body interface{}, opts CallOptions, out interface{}) error {
+	respBody, err := c.CallRaw(ctx, method, path, body, opts)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", query.StripQuery(path), err)
+	}
+	return nil
+}
+
+// CallRaw 发送HTTP请求，返回原始响应体
+func (c *HTTPClient) CallRaw(ctx context.Context, method, path string, body interface{}, opts CallOptions) ([]byte, error) {
+	var bodyData []byte
 	if body != nil {
 		if bodyStr, ok := body.(string); ok {
 			// 预序列化的body（用于HMAC签名，保持一致性）
-			reqBody = bytes.NewBufferString(bodyStr)
+			bodyData = []byte(bodyStr)
 		} else {
 			// JSON序列化（紧凑格式，无空格）
 			// 参考: https://github.com/Polymarket/py-clob-client/issues/164
@@ -42,17 +155,88 @@ func (c *HTTPClient) Request(method, path string, headers map[string]string, bod
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal body: %w", err)
 			}
-			reqBody = bytes.NewBuffer(jsonData)
+			bodyData = jsonData
 		}
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	idempotent := opts.Idempotent || method == http.MethodGet || method == http.MethodHead
+	maxAttempts := 1
+	if c.retry != nil && c.retry.MaxAttempts > 1 {
+		maxAttempts = c.retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		// 每次尝试（包括重试）都需要获取令牌
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, method, path); err != nil {
+				return nil, err
+			}
+		}
+
+		result, outcome := c.do(ctx, method, path, bodyData, opts)
+		if outcome == nil {
+			return result, nil
+		}
+		if attempt >= maxAttempts || !c.retry.shouldRetry(idempotent, outcome) {
+			return nil, outcome.err
+		}
+
+		wait := c.retry.wait(attempt, outcome.retryAfter)
+		c.logger.DebugContext(ctx, "retrying request",
+			"method", method,
+			"path", path,
+			"attempt", attempt+1,
+			"wait", wait,
+			"error", outcome.err,
+		)
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, outcome.err
+		}
+	}
+}
+
+// do 执行单次请求，成功时 outcome 为 nil
+func (c *HTTPClient) do(ctx context.Context, method, path string, bodyData []byte, opts CallOptions) ([]byte, *attemptOutcome) {
+	url := c.baseURL + path
+
+	var reqBody io.Reader
+	if bodyData != nil {
+		reqBody = bytes.NewReader(bodyData)
+	}
+
+	headers := opts.Headers
+	if opts.Sign != nil {
+		signed, err := opts.Sign()
+		if err != nil {
+			return nil, &attemptOutcome{err: err}
+		}
+		headers = make(map[string]string, len(opts.Headers)+len(signed))
+		for k, v := range opts.Headers {
+			headers[k] = v
+		}
+		for k, v := range signed {
+			headers[k] = v
+		}
+	}
+
+	// 记录请求是否已写出，用于判断非幂等请求能否安全重试
+	var wrote atomic.Bool
+	trace := &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				wrote.Store(true)
+			}
+		},
+	}
+	ctx = httptrace.WithClientTrace(ctx, trace)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, &attemptOutcome{err: fmt.Errorf("failed to create request: %w", err)}
 	}
 
 	// 设置默认headers
-	req.Header.Set("User-Agent", "polymarket-sdk-go")
+	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "*/*")
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Content-Type", "application/json")
@@ -63,29 +247,50 @@ func (c *HTTPClient) Request(method, path string, headers map[string]string, bod
 		req.Header.Set(k, v)
 	}
 
+	c.logger.DebugContext(ctx, "http request",
+		"method", method,
+		"path", path,
+		"headers", RedactHeaders(req.Header),
+	)
+
+	start := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		c.logger.DebugContext(ctx, "http request failed",
+			"method", method,
+			"path", path,
+			"duration", time.Since(start),
+			"error", err,
+		)
+		return nil, &attemptOutcome{
+			err:       fmt.Errorf("request failed: %w", err),
+			transport: true,
+			wrote:     wrote.Load(),
+		}
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, &attemptOutcome{err: fmt.Errorf("failed to read response: %w", err)}
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
-	}
+	c.logger.DebugContext(ctx, "http response",
+		"method", method,
+		"path", path,
+		"status", resp.StatusCode,
+		"duration", time.Since(start),
+	)
 
-	// 尝试解析JSON
-	var jsonData interface{}
-	if err := json.Unmarshal(respBody, &jsonData); err != nil {
-		// 如果不是JSON，返回原始字符串
-		return string(respBody), nil
+	if resp.StatusCode != http.StatusOK {
+		return nil, &attemptOutcome{
+			err:        NewAPIError(method, path, resp, respBody),
+			wrote:      true,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
-	return jsonData, nil
+	return respBody, nil
 }
 
 // Get 发送GET请求
@@ -93,17 +298,37 @@ func (c *HTTPClient) Get(path string, headers map[string]string) (interface{}, e
 	return c.Request("GET", path, headers, nil)
 }
 
+// GetWithContext 发送GET请求（支持context）
+func (c *HTTPClient) GetWithContext(ctx context.Context, path string, headers map[string]string) (interface{}, error) {
+	return c.RequestWithContext(ctx, "GET", path, headers, nil)
+}
+
 // Post 发送POST请求
 func (c *HTTPClient) Post(path string, headers map[string]string, body interface{}) (interface{}, error) {
 	return c.Request("POST", path, headers, body)
 }
 
+// PostWithContext 发送POST请求（支持context）
+func (c *HTTPClient) PostWithContext(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.RequestWithContext(ctx, "POST", path, headers, body)
+}
+
 // Delete 发送DELETE请求
 func (c *HTTPClient) Delete(path string, headers map[string]string, body interface{}) (interface{}, error) {
 	return c.Request("DELETE", path, headers, body)
 }
 
+// DeleteWithContext 发送DELETE请求（支持context）
+func (c *HTTPClient) DeleteWithContext(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.RequestWithContext(ctx, "DELETE", path, headers, body)
+}
+
 // Put 发送PUT请求
 func (c *HTTPClient) Put(path string, headers map[string]string, body interface{}) (interface{}, error) {
 	return c.Request("PUT", path, headers, body)
 }
+
+// PutWithContext 发送PUT请求（支持context）
+func (c *HTTPClient) PutWithContext(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error) {
+	return c.RequestWithContext(ctx, "PUT", path, headers, body)
+}
@@ -1,130 +1,129 @@
 package polymarket
 
 import (
-	"fmt"
-	"strings"
+	"net/url"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
 )
 
-// BuildQueryParams 构建查询参数
+// BuildQueryParams 构建查询参数（参数名和值都会被URL编码）
 func BuildQueryParams(url, param, val string) string {
-	urlWithParams := url
-	last := urlWithParams[len(urlWithParams)-1:]
-	if last == "?" {
-		urlWithParams = fmt.Sprintf("%s%s=%s", urlWithParams, param, val)
-	} else {
-		urlWithParams = fmt.Sprintf("%s&%s=%s", urlWithParams, param, val)
+	sep := "&"
+	if last := url[len(url)-1:]; last == "?" {
+		sep = ""
 	}
-	return urlWithParams
+	return url + sep + escape(param) + "=" + escape(val)
+}
+
+// escape 对查询参数进行URL编码
+func escape(s string) string {
+	return url.QueryEscape(s)
 }
 
 // AddQueryTradeParams 添加交易查询参数
 func AddQueryTradeParams(baseURL string, params *TradeParams, nextCursor string) string {
+	return tradeQuery(params, nextCursor).Path(baseURL)
+}
+
+// tradeQuery 交易查询参数
+func tradeQuery(params *TradeParams, nextCursor string) *query.Builder {
 	if nextCursor == "" {
-		nextCursor = "MA=="
+		nextCursor = StartCursor
 	}
 
-	url := baseURL
+	q := query.New()
 	if params != nil {
-		url = url + "?"
-		if params.Market != "" {
-			url = BuildQueryParams(url, "market", params.Market)
-		}
-		if params.AssetID != "" {
-			url = BuildQueryParams(url, "asset_id", params.AssetID)
-		}
-		if params.After > 0 {
-			url = BuildQueryParams(url, "after", fmt.Sprintf("%d", params.After))
-		}
-		if params.Before > 0 {
-			url = BuildQueryParams(url, "before", fmt.Sprintf("%d", params.Before))
-		}
-		if params.MakerAddress != "" {
-			url = BuildQueryParams(url, "maker_address", params.MakerAddress)
-		}
-		if params.ID != "" {
-			url = BuildQueryParams(url, "id", params.ID)
-		}
-		if nextCursor != "" {
-			url = BuildQueryParams(url, "next_cursor", nextCursor)
-		}
+		q.String("market", params.Market).
+			String("asset_id", params.AssetID).
+			PositiveInt("after", int64(params.After)).
+			PositiveInt("before", int64(params.Before)).
+			String("maker_address", params.MakerAddress).
+			String("id", params.ID)
 	}
-	return url
+	return q.String("next_cursor", nextCursor)
 }
 
 // AddQueryOpenOrdersParams 添加开放订单查询参数
 func AddQueryOpenOrdersParams(baseURL string, params *OpenOrderParams, nextCursor string) string {
+	return openOrdersQuery(params, nextCursor).Path(baseURL)
+}
+
+// openOrdersQuery 开放订单查询参数
+func openOrdersQuery(params *OpenOrderParams, nextCursor string) *query.Builder {
 	if nextCursor == "" {
-		nextCursor = "MA=="
+		nextCursor = StartCursor
 	}
 
-	url := baseURL
+	q := query.New()
 	if params != nil {
-		url = url + "?"
-		if params.Market != "" {
-			url = BuildQueryParams(url, "market", params.Market)
-		}
-		if params.AssetID != "" {
-			url = BuildQueryParams(url, "asset_id", params.AssetID)
-		}
-		if params.ID != "" {
-			url = BuildQueryParams(url, "id", params.ID)
-		}
-		if nextCursor != "" {
-			url = BuildQueryParams(url, "next_cursor", nextCursor)
-		}
+		q.String("market", params.Market).
+			String("asset_id", params.AssetID).
+			String("id", params.ID)
 	}
-	return url
+	return q.String("next_cursor", nextCursor)
 }
 
 // DropNotificationsQueryParams 添加删除通知查询参数
 func DropNotificationsQueryParams(baseURL string, params *DropNotificationParams) string {
-	url := baseURL
-	if params != nil && len(params.IDs) > 0 {
-		url = url + "?"
-		idsStr := strings.Join(params.IDs, ",")
-		url = BuildQueryParams(url, "ids", idsStr)
+	return dropNotificationsQuery(params).Path(baseURL)
+}
+
+// dropNotificationsQuery 删除通知查询参数
+func dropNotificationsQuery(params *DropNotificationParams) *query.Builder {
+	q := query.New()
+	if params != nil {
+		q.Join("ids", params.IDs)
 	}
-	return url
+	return q
 }
 
 // AddBalanceAllowanceParamsToURL 添加余额和授权查询参数
 func AddBalanceAllowanceParamsToURL(baseURL string, params *BalanceAllowanceParams) string {
-	url := baseURL
+	return balanceAllowanceQuery(params).Path(baseURL)
+}
+
+// balanceAllowanceQuery 余额和授权查询参数
+func balanceAllowanceQuery(params *BalanceAllowanceParams) *query.Builder {
+	q := query.New()
 	if params != nil {
-		url = url + "?"
-		if params.AssetType != "" {
-			url = BuildQueryParams(url, "asset_type", string(params.AssetType))
-		}
-		if params.TokenID != "" {
-			url = BuildQueryParams(url, "token_id", params.TokenID)
-		}
+		q.String("asset_type", string(params.AssetType)).
+			String("token_id", params.TokenID)
 		if params.SignatureType != nil && *params.SignatureType >= 0 {
-			url = BuildQueryParams(url, "signature_type", fmt.Sprintf("%d", *params.SignatureType))
+			q.Int("signature_type", int64(*params.SignatureType))
 		}
 	}
-	return url
+	return q
 }
 
 // AddOrderScoringParamsToURL 添加订单评分查询参数
 func AddOrderScoringParamsToURL(baseURL string, params *OrderScoringParams) string {
-	url := baseURL
+	return orderScoringQuery(params).Path(baseURL)
+}
+
+// orderScoringQuery 订单评分查询参数
+func orderScoringQuery(params *OrderScoringParams) *query.Builder {
+	q := query.New()
 	if params != nil {
-		url = url + "?"
-		if params.OrderID != "" {
-			url = BuildQueryParams(url, "order_id", params.OrderID)
-		}
+		q.String("order_id", params.OrderID)
 	}
-	return url
+	return q
 }
 
 // AddOrdersScoringParamsToURL 添加多个订单评分查询参数
 func AddOrdersScoringParamsToURL(baseURL string, params *OrdersScoringParams) string {
-	url := baseURL
-	if params != nil && len(params.OrderIDs) > 0 {
-		url = url + "?"
-		orderIDsStr := strings.Join(params.OrderIDs, ",")
-		url = BuildQueryParams(url, "order_ids", orderIDsStr)
+	return ordersScoringQuery(params).Path(baseURL)
+}
+
+// ordersScoringQuery 多个订单评分查询参数
+func ordersScoringQuery(params *OrdersScoringParams) *query.Builder {
+	q := query.New()
+	if params != nil {
+		q.Join("order_ids", params.OrderIDs)
 	}
-	return url
+	return q
 }
 
+// tokenQuery 只包含 token_id 的查询参数
+func tokenQuery(tokenID string) *query.Builder {
+	return query.New().String("token_id", tokenID)
+}
@@ -0,0 +1,159 @@
+package polymarket
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/rfq"
+)
+
+func TestQueryParams(t *testing.T) {
+	sigType := 1
+	negative := -1
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"trade nil params", AddQueryTradeParams(Trades, nil, ""), "/data/trades?next_cursor=MA%3D%3D"},
+		{"trade empty params", AddQueryTradeParams(Trades, &TradeParams{}, EndCursor), "/data/trades?next_cursor=LTE%3D"},
+		{"trade all fields", AddQueryTradeParams(Trades, &TradeParams{
+			ID: "t-1", MakerAddress: "0xabc", Market: "0xcond", AssetID: "123", Before: 1700000100, After: 1700000000,
+		}, "Mjk5"), "/data/trades?after=1700000000&asset_id=123&before=1700000100&id=t-1&maker_address=0xabc&market=0xcond&next_cursor=Mjk5"},
+		{"trade escapes values", AddQueryTradeParams(Trades, &TradeParams{Market: "a&b=c"}, "x+y/z"), "/data/trades?market=a%26b%3Dc&next_cursor=x%2By%2Fz"},
+		{"open orders nil params", AddQueryOpenOrdersParams(Orders, nil, ""), "/data/orders?next_cursor=MA%3D%3D"},
+		{"open orders all fields", AddQueryOpenOrdersParams(Orders, &OpenOrderParams{ID: "0x1", Market: "0xcond", AssetID: "123"}, "MTAw"),
+			"/data/orders?asset_id=123&id=0x1&market=0xcond&next_cursor=MTAw"},
+		{"balance nil params", AddBalanceAllowanceParamsToURL(GetBalanceAllowance, nil), "/balance-allowance"},
+		{"balance collateral", AddBalanceAllowanceParamsToURL(GetBalanceAllowance, &BalanceAllowanceParams{AssetType: AssetTypeCollateral, SignatureType: &sigType}),
+			"/balance-allowance?asset_type=COLLATERAL&signature_type=1"},
+		{"balance conditional", AddBalanceAllowanceParamsToURL(GetBalanceAllowance, &BalanceAllowanceParams{AssetType: AssetTypeConditional, TokenID: "123", SignatureType: &negative}),
+			"/balance-allowance?asset_type=CONDITIONAL&token_id=123"},
+		{"drop notifications nil params", DropNotificationsQueryParams(DropNotifications, nil), "/notifications"},
+		{"drop notifications empty ids", DropNotificationsQueryParams(DropNotifications, &DropNotificationParams{}), "/notifications"},
+		{"drop notifications joined ids", DropNotificationsQueryParams(DropNotifications, &DropNotificationParams{IDs: []string{"1", "2", "3"}}),
+			"/notifications?ids=1%2C2%2C3"},
+		{"order scoring nil params", AddOrderScoringParamsToURL(IsOrderScoring, nil), "/order-scoring"},
+		{"order scoring", AddOrderScoringParamsToURL(IsOrderScoring, &OrderScoringParams{OrderID: "0xabc"}), "/order-scoring?order_id=0xabc"},
+		{"orders scoring nil params", AddOrdersScoringParamsToURL(AreOrdersScoring, nil), "/orders-scoring"},
+		{"orders scoring joined ids", AddOrdersScoringParamsToURL(AreOrdersScoring, &OrdersScoringParams{OrderIDs: []string{"0x1", "0x2"}}),
+			"/orders-scoring?order_ids=0x1%2C0x2"},
+		{"token", tokenQuery("123").String("side", "BUY").Path(Price), "/price?side=BUY&token_id=123"},
+		{"build query params", BuildQueryParams(BuildQueryParams("/p?", "next_cursor", "MA=="), "a b", "c&d"), "/p?next_cursor=MA%3D%3D&a+b=c%26d"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s:\n got  %s\n want %s", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// signedRequest 服务端收到的签名请求
+type signedRequest struct {
+	method    string
+	path      string
+	query     string
+	signature string
+	timestamp string
+}
+
+func TestSignedPathMatchesSentPath(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []signedRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, signedRequest{
+			method:    r.Method,
+			path:      r.URL.Path,
+			query:     r.URL.RawQuery,
+			signature: r.Header.Get(PolySignature),
+			timestamp: r.Header.Get(PolyTimestamp),
+		})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"next_cursor":"LTE="}`))
+	}))
+	defer server.Close()
+
+	creds := &ApiCreds{APIKey: "key", APISecret: base64.URLEncoding.EncodeToString([]byte("test-secret")), APIPassphrase: "pass"}
+	client, err := NewClobClient(server.URL, 137,
+		WithPrivateKey("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"),
+		WithCreds(creds),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigType := 0
+	calls := []struct {
+		name  string
+		call  func() error
+		path  string
+		query string
+	}{
+		{"trades", func() error {
+			_, err := client.GetTrades(&TradeParams{Market: "0xcond", After: 1700000000}, "")
+			return err
+		}, Trades, "after=1700000000&market=0xcond&next_cursor=MA%3D%3D"},
+		{"orders", func() error {
+			_, err := client.GetOrders(&OpenOrderParams{AssetID: "123"}, "MTAw")
+			return err
+		}, Orders, "asset_id=123&next_cursor=MTAw"},
+		{"balance allowance", func() error {
+			_, err := client.GetBalanceAllowance(&BalanceAllowanceParams{AssetType: AssetTypeCollateral, SignatureType: &sigType})
+			return err
+		}, GetBalanceAllowance, "asset_type=COLLATERAL&signature_type=0"},
+		{"drop notifications", func() error {
+			_, err := client.DropNotifications(&DropNotificationParams{IDs: []string{"1", "2"}})
+			return err
+		}, DropNotifications, "ids=1%2C2"},
+		{"rfq requests", func() error {
+			_, err := client.rfq.GetRfqRequests(&rfq.GetRfqRequestsParams{TokenID: "123", NextCursor: "MA=="})
+			return err
+		}, "/rfq/data/requests", "next_cursor=MA%3D%3D&token_id=123"},
+		{"rfq quotes", func() error {
+			_, err := client.rfq.GetRfqQuoterQuotes(&rfq.GetRfqQuotesParams{QuoteIDs: []string{"q1", "q2"}})
+			return err
+		}, "/rfq/data/quoter/quotes", "quoteIds=q1&quoteIds=q2"},
+	}
+
+	for _, c := range calls {
+		mu.Lock()
+		received = nil
+		mu.Unlock()
+
+		if err := c.call(); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+
+		mu.Lock()
+		reqs := received
+		mu.Unlock()
+		if len(reqs) != 1 {
+			t.Fatalf("%s: %d requests sent", c.name, len(reqs))
+		}
+		req := reqs[0]
+		if req.path != c.path || req.query != c.query {
+			t.Errorf("%s: sent %s?%s, want %s?%s", c.name, req.path, req.query, c.path, c.query)
+		}
+
+		// 服务端用实际收到的路径重新计算签名
+		ts, err := strconv.Atoi(req.timestamp)
+		if err != nil {
+			t.Fatalf("%s: invalid timestamp %q", c.name, req.timestamp)
+		}
+		want, err := BuildHMACSignature(creds.APISecret, ts, req.method, req.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.signature != want {
+			t.Errorf("%s: signature does not cover the sent path %s", c.name, req.path)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package query 构建经过正确 URL 编码的查询字符串，供 polymarket 与 rfq 包共用
+//
+// CLOB 的 HMAC 签名只覆盖请求路径（不含查询参数），因此调用方应使用同一个 path
+// 常量同时作为签名路径和 Path 的参数，保证签名的路径与实际发送的路径一致
+package query
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Builder 查询参数构建器，基于 url.Values，所有键值都会被正确转义
+type Builder struct {
+	values url.Values
+}
+
+// New 创建查询参数构建器
+func New() *Builder {
+	return &Builder{values: url.Values{}}
+}
+
+// String 添加字符串参数，空字符串会被忽略
+func (b *Builder) String(key, val string) *Builder {
+	if val != "" {
+		b.values.Set(key, val)
+	}
+	return b
+}
+
+// Int 添加整数参数（包括0）
+func (b *Builder) Int(key string, val int64) *Builder {
+	b.values.Set(key, strconv.FormatInt(val, 10))
+	return b
+}
+
+// PositiveInt 添加整数参数，<=0 时忽略（用于可选的时间戳等）
+func (b *Builder) PositiveInt(key string, val int64) *Builder {
+	if val > 0 {
+		b.Int(key, val)
+	}
+	return b
+}
+
+// Join 以逗号连接多个值作为一个参数，空列表会被忽略
+func (b *Builder) Join(key string, vals []string) *Builder {
+	if len(vals) > 0 {
+		b.values.Set(key, strings.Join(vals, ","))
+	}
+	return b
+}
+
+// Repeat 以重复键的形式添加多个值（key=a&key=b），空值会被忽略
+func (b *Builder) Repeat(key string, vals []string) *Builder {
+	for _, v := range vals {
+		if v != "" {
+			b.values.Add(key, v)
+		}
+	}
+	return b
+}
+
+// Values 返回底层的 url.Values
+func (b *Builder) Values() url.Values {
+	return b.values
+}
+
+// Encode 返回编码后的查询字符串（按键排序，不含 "?"）
+func (b *Builder) Encode() string {
+	return b.values.Encode()
+}
+
+// Path 返回附加了查询字符串的路径，没有参数时原样返回 path
+func (b *Builder) Path(path string) string {
+	if len(b.values) == 0 {
+		return path
+	}
+	return path + "?" + b.values.Encode()
+}
+
+// StripQuery 返回去掉查询字符串后的路径
+func StripQuery(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -0,0 +1,44 @@
+package query
+
+import "testing"
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"empty", New().Path("/p"), "/p"},
+		{"empty string skipped", New().String("a", "").Path("/p"), "/p"},
+		{"cursor escaped", New().String("next_cursor", "MA==").Path("/p"), "/p?next_cursor=MA%3D%3D"},
+		{"end cursor escaped", New().String("next_cursor", "LTE=").Path("/p"), "/p?next_cursor=LTE%3D"},
+		{"reserved characters", New().String("q", "a&b=c d/?#").Encode(), "q=a%26b%3Dc+d%2F%3F%23"},
+		{"keys sorted", New().String("b", "2").String("a", "1").Encode(), "a=1&b=2"},
+		{"int zero kept", New().Int("n", 0).Encode(), "n=0"},
+		{"positive int", New().PositiveInt("after", 0).PositiveInt("before", -1).PositiveInt("at", 17).Encode(), "at=17"},
+		{"join", New().Join("ids", []string{"a", "b,c"}).Encode(), "ids=a%2Cb%2Cc"},
+		{"join empty", New().Join("ids", nil).Path("/p"), "/p"},
+		{"repeat", New().Repeat("id", []string{"1", "", "2"}).Encode(), "id=1&id=2"},
+		{"repeat empty", New().Repeat("id", []string{}).Path("/p"), "/p"},
+		{"set overrides", New().String("a", "1").String("a", "2").Encode(), "a=2"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestStripQuery(t *testing.T) {
+	tests := map[string]string{
+		"/data/trades":                   "/data/trades",
+		"/data/trades?next_cursor=MA%3D": "/data/trades",
+		"/p?":                            "/p",
+		"":                               "",
+	}
+	for in, want := range tests {
+		if got := StripQuery(in); got != want {
+			t.Errorf("StripQuery(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
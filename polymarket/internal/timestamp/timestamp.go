@@ -0,0 +1,55 @@
+// Package timestamp 解析 API 返回的时间，供 polymarket、rfq 与 ws 包共用
+//
+// 不同接口的时间格式不统一：秒级或毫秒级 Unix 时间戳（字符串或数字）、
+// RFC3339 字符串、带时区缩写的日期时间以及纯日期，空值表示未设置
+package timestamp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// layouts 支持的字符串时间格式
+var layouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999-07", "2006-01-02"}
+
+// Parse 解析时间字符串：整数按 Unix 时间戳处理（大于 1e12 视为毫秒），
+// 空字符串和 0 为零时间，其余按 RFC3339 等格式解析，无法识别时返回错误
+func Parse(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		switch {
+		case n == 0:
+			return time.Time{}, nil
+		case n > 1e12:
+			return time.UnixMilli(n), nil
+		default:
+			return time.Unix(n, 0), nil
+		}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// ParseJSON 解析 JSON 字符串或数字形式的时间，null 为零时间
+func ParseJSON(data []byte) (time.Time, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return time.Time{}, nil
+	}
+	s := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return Parse(s)
+}
@@ -0,0 +1,36 @@
+package timestamp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{`1700000000`, time.Unix(1700000000, 0), true},
+		{`"1700000000"`, time.Unix(1700000000, 0), true},
+		{`1700000000123`, time.UnixMilli(1700000000123), true},
+		{`"1700000000123"`, time.UnixMilli(1700000000123), true},
+		{`"2025-01-02T03:04:05.5Z"`, time.Date(2025, 1, 2, 3, 4, 5, 5e8, time.UTC), true},
+		{`"2025-01-02 03:04:05+00"`, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), true},
+		{`"2025-01-02"`, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{`0`, time.Time{}, true},
+		{`"0"`, time.Time{}, true},
+		{`""`, time.Time{}, true},
+		{`null`, time.Time{}, true},
+		{` `, time.Time{}, true},
+		{`"TBD"`, time.Time{}, false},
+		{`1.5`, time.Time{}, false},
+		{`"unterminated`, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseJSON([]byte(tt.in))
+		if (err == nil) != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("ParseJSON(%s) = %v, %v", tt.in, got, err)
+		}
+	}
+}
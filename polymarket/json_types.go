@@ -0,0 +1,89 @@
+package polymarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/timestamp"
+)
+
+// 以下类型用于解析 API 返回的数值和时间：CLOB 多以字符串返回数值（如 "0.55"），
+// 时间戳有时为数字、有时为字符串，也可能为空
+
+// flexDecimal 兼容字符串、数字和空值的定点小数，超过 6 位小数的部分四舍五入
+type flexDecimal decimal.Decimal
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (d *flexDecimal) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteJSON(data)
+	if err != nil || !ok {
+		*d = flexDecimal(decimal.Zero)
+		return err
+	}
+	v, err := decimal.ParseRound(s)
+	if err != nil {
+		return fmt.Errorf("invalid number %s: %w", data, err)
+	}
+	*d = flexDecimal(v)
+	return nil
+}
+
+// flexInt 兼容字符串、数字和空值的整数
+type flexInt int64
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (i *flexInt) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteJSON(data)
+	if err != nil || !ok {
+		*i = 0
+		return err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		// 兼容 "100.0" 形式
+		fv, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return fmt.Errorf("invalid integer %s", data)
+		}
+		v = int64(fv)
+	}
+	*i = flexInt(v)
+	return nil
+}
+
+// flexTime 兼容秒级/毫秒级 Unix 时间戳（字符串或数字）以及 RFC3339 字符串，空值为零时间
+// 无法识别的格式同样解析为零时间，避免单个异常字段导致整页数据解析失败
+type flexTime time.Time
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (t *flexTime) UnmarshalJSON(data []byte) error {
+	parsed, err := timestamp.ParseJSON(data)
+	if err != nil {
+		parsed = time.Time{}
+	}
+	*t = flexTime(parsed)
+	return nil
+}
+
+// unquoteJSON 返回 JSON 字符串或数字的文本形式，null 和空字符串返回 ok=false
+func unquoteJSON(data []byte) (string, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return "", false, nil
+	}
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", false, err
+		}
+		if s == "" {
+			return "", false, nil
+		}
+		return s, true, nil
+	}
+	return string(data), true, nil
+}
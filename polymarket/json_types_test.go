@@ -0,0 +1,80 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFlexTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`1700000000`, time.Unix(1700000000, 0)},
+		{`"1700000000"`, time.Unix(1700000000, 0)},
+		{`1700000000123`, time.UnixMilli(1700000000123)},
+		{`"2025-01-02T03:04:05Z"`, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{`"2025-01-02 03:04:05+00"`, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{`"2025-01-02"`, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{`0`, time.Time{}},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+		{`"TBD"`, time.Time{}},
+		{`"02/01/2025 7pm"`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var ft flexTime
+		if err := json.Unmarshal([]byte(tt.in), &ft); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if got := time.Time(ft); !got.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPageWithUnparseableTime(t *testing.T) {
+	raw := `{"data":[
+		{"condition_id":"0x1","game_start_time":"2025-01-02 03:04:05+00","minimum_tick_size":0.01},
+		{"condition_id":"0x2","game_start_time":"sometime next week","minimum_tick_size":"0.001"}
+	],"next_cursor":"LTE="}`
+	var p page[Market]
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		t.Fatalf("one odd time field failed the whole page: %v", err)
+	}
+	if len(p.Data) != 2 || p.Data[1].ConditionID != "0x2" || p.Data[1].MinimumTickSize.String() != "0.001" {
+		t.Fatalf("unexpected markets: %+v", p.Data)
+	}
+	if p.Data[0].GameStartTime.IsZero() || !p.Data[1].GameStartTime.IsZero() {
+		t.Fatalf("unexpected game start times: %v, %v", p.Data[0].GameStartTime, p.Data[1].GameStartTime)
+	}
+}
+
+func TestTradeDecimals(t *testing.T) {
+	raw := `{"id":"t1","size":"12.5","price":0.57,"fee_rate_bps":"0",
+		"maker_orders":[{"order_id":"0x1","matched_amount":"0.33333333","price":"0.57"}]}`
+	var trade Trade
+	if err := json.Unmarshal([]byte(raw), &trade); err != nil {
+		t.Fatal(err)
+	}
+	if trade.Size.String() != "12.5" || trade.Price.String() != "0.57" {
+		t.Fatalf("unexpected size/price: %s, %s", trade.Size, trade.Price)
+	}
+	// 超过 6 位小数时四舍五入，不导致解析失败
+	if got := trade.MakerOrders[0].MatchedAmount.String(); got != "0.333333" {
+		t.Fatalf("matched amount = %s, want 0.333333", got)
+	}
+
+	var order OpenOrder
+	if err := json.Unmarshal([]byte(`{"original_size":"10","size_matched":"2.5","price":null}`), &order); err != nil {
+		t.Fatal(err)
+	}
+	if got := order.RemainingSize().String(); got != "7.5" || !order.Price.IsZero() {
+		t.Fatalf("remaining = %s, price = %s", got, order.Price)
+	}
+	if err := json.Unmarshal([]byte(`{"price":"abc"}`), &order); err == nil {
+		t.Fatal("expected error for invalid price")
+	}
+}
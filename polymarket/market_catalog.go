@@ -0,0 +1,427 @@
+package polymarket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+)
+
+// catalogFileVersion 目录文件格式版本
+const catalogFileVersion = 1
+
+// MarketCatalog 本地市场目录：下载全部市场，按 condition ID、token ID、question ID
+// 和 neg risk market ID 建立索引，并可保存到本地文件（JSON lines），下次启动时增量同步
+//
+// 并发安全
+type MarketCatalog struct {
+	fetch  pagination.Fetch[Market] // 获取一页市场
+	logger *slog.Logger
+
+	mu         sync.RWMutex
+	markets    map[string]Market   // condition ID -> 市场
+	byToken    map[string]string   // token ID -> condition ID
+	byQuestion map[string]string   // question ID -> condition ID
+	byNegRisk  map[string][]string // neg risk market ID -> condition ID 列表
+	tailCursor string              // 最后一页的请求游标，增量同步从此处继续
+	updatedAt  time.Time
+}
+
+// TickSizeChange 市场的 tick size 变化
+type TickSizeChange struct {
+	ConditionID string
+	Old         decimal.Decimal
+	New         decimal.Decimal
+}
+
+// CatalogDiff 目录两次更新之间的变化
+// Sync 只填充 Added；Closed、TickSizeChanged 和 Removed 需要对比全部市场，只由 Refresh 填充
+type CatalogDiff struct {
+	Added           []Market         // 新市场
+	Closed          []Market         // 由未关闭变为关闭的市场（仅 Refresh）
+	TickSizeChanged []TickSizeChange // tick size 发生变化的市场（仅 Refresh）
+	Removed         []Market         // 服务器不再返回的市场（仅 Refresh）
+}
+
+// IsEmpty 是否没有任何变化
+func (d *CatalogDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Closed) == 0 && len(d.TickSizeChanged) == 0 && len(d.Removed) == 0
+}
+
+// catalogHeader 目录文件的第一行
+type catalogHeader struct {
+	Version    int       `json:"version"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	TailCursor string    `json:"tail_cursor"`
+	Count      int       `json:"count"`
+}
+
+// NewMarketCatalog 创建空的市场目录，通过 Load 读取本地文件，或通过 Refresh / Sync 下载
+func NewMarketCatalog(client *ClobClient) *MarketCatalog {
+	return newMarketCatalog(client.fetchMarkets, client.httpClient.Logger())
+}
+
+// newMarketCatalog 使用指定的分页数据源创建市场目录
+func newMarketCatalog(fetch pagination.Fetch[Market], logger *slog.Logger) *MarketCatalog {
+	c := &MarketCatalog{fetch: fetch, logger: logger}
+	c.reset()
+	return c
+}
+
+// Refresh 全量下载市场目录并替换当前内容，返回与当前内容相比的变化
+func (c *MarketCatalog) Refresh(ctx context.Context) (*CatalogDiff, error) {
+	fetched, tailCursor, err := c.download(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	diff := &CatalogDiff{}
+	seen := make(map[string]bool, len(fetched))
+	for i := range fetched {
+		seen[fetched[i].ConditionID] = true
+	}
+	for id, m := range c.markets {
+		if !seen[id] {
+			diff.Removed = append(diff.Removed, m)
+		}
+	}
+
+	prev := c.markets
+	c.reset()
+	for i := range fetched {
+		m := fetched[i]
+		if _, dup := c.markets[m.ConditionID]; !dup {
+			old, exists := prev[m.ConditionID]
+			diff.record(old, exists, m)
+		}
+		c.upsert(m)
+	}
+	c.tailCursor = tailCursor
+	c.updatedAt = time.Now()
+	diff.sort()
+
+	c.logger.InfoContext(ctx, "market catalog refreshed",
+		"markets", len(c.markets),
+		"added", len(diff.Added),
+		"closed", len(diff.Closed),
+		"removed", len(diff.Removed),
+	)
+	return diff, nil
+}
+
+// Sync 增量同步：从上次最后一页继续获取，只添加目录中还没有的新市场
+// 目录为空时等同于 Refresh
+//
+// 分页按创建顺序返回市场，增量同步无法发现已有市场的关闭和 tick size 变化，
+// 返回的 CatalogDiff 只包含 Added；已有市场保持不变，其变化由下一次 Refresh 检测并报告
+func (c *MarketCatalog) Sync(ctx context.Context) (*CatalogDiff, error) {
+	c.mu.RLock()
+	tailCursor, empty := c.tailCursor, len(c.markets) == 0
+	c.mu.RUnlock()
+	if empty || tailCursor == "" {
+		return c.Refresh(ctx)
+	}
+
+	fetched, newTail, err := c.download(ctx, tailCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	diff := &CatalogDiff{}
+	for i := range fetched {
+		m := fetched[i]
+		if _, exists := c.markets[m.ConditionID]; exists {
+			continue
+		}
+		diff.Added = append(diff.Added, m)
+		c.index(m)
+	}
+	c.tailCursor = newTail
+	c.updatedAt = time.Now()
+	diff.sort()
+
+	c.logger.InfoContext(ctx, "market catalog synced",
+		"markets", len(c.markets),
+		"fetched", len(fetched),
+		"added", len(diff.Added),
+	)
+	return diff, nil
+}
+
+// download 从 cursor 开始获取到最后一页，返回市场和最后一页的请求游标
+func (c *MarketCatalog) download(ctx context.Context, cursor string) ([]Market, string, error) {
+	if cursor == "" {
+		cursor = StartCursor
+	}
+	pager := pagination.New(c.fetch, PageOptions{Cursor: cursor})
+
+	var markets []Market
+	pageCursor := cursor
+	for data, err := range pager.Pages(ctx) {
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to download markets at cursor %s: %w", pageCursor, err)
+		}
+		markets = append(markets, data...)
+		if !pager.Done() {
+			pageCursor = pager.Cursor()
+		}
+	}
+	return markets, pageCursor, nil
+}
+
+// upsert 插入或替换市场，调用方需持有写锁
+func (c *MarketCatalog) upsert(m Market) {
+	if old, exists := c.markets[m.ConditionID]; exists {
+		c.unindex(old)
+	}
+	c.index(m)
+}
+
+// index 将市场加入索引，调用方需持有写锁
+func (c *MarketCatalog) index(m Market) {
+	c.markets[m.ConditionID] = m
+	for _, t := range m.Tokens {
+		if t.TokenID != "" {
+			c.byToken[t.TokenID] = m.ConditionID
+		}
+	}
+	if m.QuestionID != "" {
+		c.byQuestion[m.QuestionID] = m.ConditionID
+	}
+	if m.NegRiskMarketID != "" {
+		c.byNegRisk[m.NegRiskMarketID] = append(c.byNegRisk[m.NegRiskMarketID], m.ConditionID)
+	}
+}
+
+// unindex 从索引中删除市场，调用方需持有写锁
+func (c *MarketCatalog) unindex(m Market) {
+	delete(c.markets, m.ConditionID)
+	for _, t := range m.Tokens {
+		if c.byToken[t.TokenID] == m.ConditionID {
+			delete(c.byToken, t.TokenID)
+		}
+	}
+	if c.byQuestion[m.QuestionID] == m.ConditionID {
+		delete(c.byQuestion, m.QuestionID)
+	}
+	if m.NegRiskMarketID != "" {
+		ids := c.byNegRisk[m.NegRiskMarketID]
+		for i, id := range ids {
+			if id == m.ConditionID {
+				ids = append(ids[:i:i], ids[i+1:]...)
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(c.byNegRisk, m.NegRiskMarketID)
+		} else {
+			c.byNegRisk[m.NegRiskMarketID] = ids
+		}
+	}
+}
+
+// reset 清空内容，调用方需持有写锁（或在构造时调用）
+func (c *MarketCatalog) reset() {
+	c.markets = make(map[string]Market)
+	c.byToken = make(map[string]string)
+	c.byQuestion = make(map[string]string)
+	c.byNegRisk = make(map[string][]string)
+	c.tailCursor = ""
+}
+
+// Market 按 condition ID 查找市场
+func (c *MarketCatalog) Market(conditionID string) (Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, ok := c.markets[conditionID]
+	return m, ok
+}
+
+// ByToken 按 token ID 查找所属市场
+func (c *MarketCatalog) ByToken(tokenID string) (Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, ok := c.markets[c.byToken[tokenID]]
+	return m, ok
+}
+
+// ByQuestion 按 question ID 查找市场
+func (c *MarketCatalog) ByQuestion(questionID string) (Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, ok := c.markets[c.byQuestion[questionID]]
+	return m, ok
+}
+
+// ByNegRiskMarket 返回同一 neg risk 市场组下的所有市场（按 condition ID 排序）
+func (c *MarketCatalog) ByNegRiskMarket(negRiskMarketID string) []Market {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ids := c.byNegRisk[negRiskMarketID]
+	markets := make([]Market, 0, len(ids))
+	for _, id := range ids {
+		markets = append(markets, c.markets[id])
+	}
+	sortMarkets(markets)
+	return markets
+}
+
+// Markets 返回所有市场（按 condition ID 排序）
+func (c *MarketCatalog) Markets() []Market {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	markets := make([]Market, 0, len(c.markets))
+	for _, m := range c.markets {
+		markets = append(markets, m)
+	}
+	sortMarkets(markets)
+	return markets
+}
+
+// Len 返回市场数量
+func (c *MarketCatalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.markets)
+}
+
+// UpdatedAt 返回最后一次下载的时间，未下载过时为零值
+func (c *MarketCatalog) UpdatedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updatedAt
+}
+
+// Save 将目录保存到 path（JSON lines：第一行为文件头，之后每行一个市场）
+// 先写入临时文件再重命名，中途失败不会破坏已有文件
+func (c *MarketCatalog) Save(path string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create catalog file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	header := catalogHeader{
+		Version:    catalogFileVersion,
+		UpdatedAt:  c.updatedAt,
+		TailCursor: c.tailCursor,
+		Count:      len(c.markets),
+	}
+	if err := enc.Encode(header); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write catalog header: %w", err)
+	}
+
+	ids := make([]string, 0, len(c.markets))
+	for id := range c.markets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := enc.Encode(c.markets[id]); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write market %s: %w", id, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write catalog file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write catalog file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load 从 Save 保存的文件读取目录，替换当前内容
+func (c *MarketCatalog) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	var header catalogHeader
+	if err := dec.Decode(&header); err != nil {
+		return fmt.Errorf("failed to read catalog header: %w", err)
+	}
+	if header.Version != catalogFileVersion {
+		return fmt.Errorf("unsupported catalog file version %d", header.Version)
+	}
+
+	markets := make([]Market, 0, header.Count)
+	for dec.More() {
+		var m Market
+		if err := dec.Decode(&m); err != nil {
+			return fmt.Errorf("failed to read market %d: %w", len(markets)+1, err)
+		}
+		markets = append(markets, m)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reset()
+	for _, m := range markets {
+		c.index(m)
+	}
+	c.tailCursor = header.TailCursor
+	c.updatedAt = header.UpdatedAt
+	return nil
+}
+
+// record 对比同一市场的旧版本和新版本，记录变化
+func (d *CatalogDiff) record(old Market, exists bool, m Market) {
+	if !exists {
+		d.Added = append(d.Added, m)
+		return
+	}
+	if !old.Closed && m.Closed {
+		d.Closed = append(d.Closed, m)
+	}
+	if old.MinimumTickSize != m.MinimumTickSize {
+		d.TickSizeChanged = append(d.TickSizeChanged, TickSizeChange{
+			ConditionID: m.ConditionID,
+			Old:         old.MinimumTickSize,
+			New:         m.MinimumTickSize,
+		})
+	}
+}
+
+// sort 按 condition ID 排序，使结果稳定
+func (d *CatalogDiff) sort() {
+	sortMarkets(d.Added)
+	sortMarkets(d.Closed)
+	sortMarkets(d.Removed)
+	sort.Slice(d.TickSizeChanged, func(i, j int) bool {
+		return d.TickSizeChanged[i].ConditionID < d.TickSizeChanged[j].ConditionID
+	})
+}
+
+// sortMarkets 按 condition ID 排序
+func sortMarkets(markets []Market) {
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].ConditionID < markets[j].ConditionID
+	})
+}
@@ -0,0 +1,283 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// fakeMarketPages 按游标返回固定的市场分页，并记录请求的游标
+type fakeMarketPages struct {
+	pages   map[string][]Market
+	next    map[string]string
+	cursors []string
+}
+
+func (f *fakeMarketPages) fetch(ctx context.Context, cursor string) ([]Market, string, error) {
+	f.cursors = append(f.cursors, cursor)
+	data, ok := f.pages[cursor]
+	if !ok {
+		return nil, "", errors.New("unknown cursor " + cursor)
+	}
+	return data, f.next[cursor], nil
+}
+
+// testMarket 构造测试用的市场
+func testMarket(id, tickSize string, tokens ...string) Market {
+	m := Market{
+		ConditionID:      id,
+		QuestionID:       "q-" + id,
+		Question:         "Question " + id + "?",
+		MinimumTickSize:  decimal.MustParse(tickSize),
+		MinimumOrderSize: decimal.FromInt(5),
+		Active:           true,
+		EndDate:          time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	for i, t := range tokens {
+		outcome := "Yes"
+		if i == 1 {
+			outcome = "No"
+		}
+		m.Tokens = append(m.Tokens, MarketToken{TokenID: t, Outcome: outcome, Price: decimal.MustParse("0.5")})
+	}
+	return m
+}
+
+func negRisk(m Market, group string) Market {
+	m.NegRisk = true
+	m.NegRiskMarketID = group
+	return m
+}
+
+func closed(m Market) Market {
+	m.Closed = true
+	m.AcceptingOrders = false
+	return m
+}
+
+func conditionIDs(markets []Market) []string {
+	ids := make([]string, len(markets))
+	for i, m := range markets {
+		ids[i] = m.ConditionID
+	}
+	return ids
+}
+
+func newTestCatalog(f *fakeMarketPages) *MarketCatalog {
+	return newMarketCatalog(f.fetch, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestMarketCatalogRefreshSaveLoadSync(t *testing.T) {
+	ctx := context.Background()
+	a := negRisk(testMarket("0xa", "0.01", "a1", "a2"), "group")
+	b := negRisk(testMarket("0xb", "0.01", "b1", "b2"), "group")
+	c := testMarket("0xc", "0.001", "c1", "c2")
+	d := testMarket("0xd", "0.01", "d1", "d2")
+
+	f := &fakeMarketPages{
+		pages: map[string][]Market{StartCursor: {b, a}, "Mg==": {c}},
+		next:  map[string]string{StartCursor: "Mg==", "Mg==": EndCursor},
+	}
+	catalog := newTestCatalog(f)
+	diff, err := catalog.Refresh(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conditionIDs(diff.Added); !reflect.DeepEqual(got, []string{"0xa", "0xb", "0xc"}) {
+		t.Fatalf("added = %v", got)
+	}
+	if len(diff.Closed)+len(diff.TickSizeChanged)+len(diff.Removed) != 0 {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+	if catalog.tailCursor != "Mg==" || catalog.UpdatedAt().IsZero() {
+		t.Fatalf("tail cursor = %q, updated at %v", catalog.tailCursor, catalog.UpdatedAt())
+	}
+
+	// 保存后读取到新的目录，内容和索引一致
+	path := filepath.Join(t.TempDir(), "markets.jsonl")
+	if err := catalog.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := newTestCatalog(f)
+	if err := loaded.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Markets(), catalog.Markets()) {
+		t.Fatalf("loaded markets differ:\n got  %+v\n want %+v", loaded.Markets(), catalog.Markets())
+	}
+	if loaded.tailCursor != "Mg==" || !loaded.UpdatedAt().Equal(catalog.UpdatedAt()) {
+		t.Fatalf("loaded tail cursor = %q, updated at %v", loaded.tailCursor, loaded.UpdatedAt())
+	}
+	if m, ok := loaded.ByToken("c2"); !ok || m.ConditionID != "0xc" {
+		t.Fatalf("ByToken(c2) = %v, %v", m.ConditionID, ok)
+	}
+	if m, ok := loaded.ByQuestion("q-0xb"); !ok || m.ConditionID != "0xb" {
+		t.Fatalf("ByQuestion(q-0xb) = %v, %v", m.ConditionID, ok)
+	}
+	if got := conditionIDs(loaded.ByNegRiskMarket("group")); !reflect.DeepEqual(got, []string{"0xa", "0xb"}) {
+		t.Fatalf("ByNegRiskMarket = %v", got)
+	}
+	if m, _ := loaded.Market("0xc"); m.TickSize() != TickSize0001 {
+		t.Fatalf("tick size after load = %q", m.TickSize())
+	}
+
+	// 增量同步从最后一页继续，只添加新市场，已有市场保持不变
+	f.pages["Mg=="] = []Market{closed(c), d}
+	f.cursors = nil
+	diff, err = loaded.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conditionIDs(diff.Added); !reflect.DeepEqual(got, []string{"0xd"}) || !reflect.DeepEqual(f.cursors, []string{"Mg=="}) {
+		t.Fatalf("sync added %v, cursors %v", got, f.cursors)
+	}
+	if len(diff.Closed)+len(diff.TickSizeChanged)+len(diff.Removed) != 0 {
+		t.Fatalf("sync reported changes: %+v", diff)
+	}
+	if m, _ := loaded.Market("0xc"); m.Closed {
+		t.Fatal("sync modified an existing market")
+	}
+	if loaded.Len() != 4 || loaded.tailCursor != "Mg==" {
+		t.Fatalf("len = %d, tail cursor = %q", loaded.Len(), loaded.tailCursor)
+	}
+
+	// 没有新页时不添加任何市场
+	diff, err = loaded.Sync(ctx)
+	if err != nil || !diff.IsEmpty() {
+		t.Fatalf("second sync = %+v, %v", diff, err)
+	}
+
+	// 全量刷新报告同步期间遗漏的关闭、tick size 变化和删除
+	a2 := a
+	a2.MinimumTickSize = decimal.MustParse("0.001")
+	f.pages = map[string][]Market{StartCursor: {a2, closed(c)}, "Mg==": {d}}
+	f.next = map[string]string{StartCursor: "Mg==", "Mg==": EndCursor}
+	diff, err = loaded.Refresh(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Added) != 0 {
+		t.Fatalf("added = %v", conditionIDs(diff.Added))
+	}
+	if got := conditionIDs(diff.Closed); !reflect.DeepEqual(got, []string{"0xc"}) {
+		t.Fatalf("closed = %v", got)
+	}
+	want := []TickSizeChange{{ConditionID: "0xa", Old: decimal.MustParse("0.01"), New: decimal.MustParse("0.001")}}
+	if !reflect.DeepEqual(diff.TickSizeChanged, want) {
+		t.Fatalf("tick size changed = %+v", diff.TickSizeChanged)
+	}
+	if got := conditionIDs(diff.Removed); !reflect.DeepEqual(got, []string{"0xb"}) {
+		t.Fatalf("removed = %v", got)
+	}
+	if _, ok := loaded.ByToken("b1"); ok {
+		t.Fatal("removed market still indexed by token")
+	}
+	if got := conditionIDs(loaded.ByNegRiskMarket("group")); !reflect.DeepEqual(got, []string{"0xa"}) {
+		t.Fatalf("ByNegRiskMarket after refresh = %v", got)
+	}
+}
+
+func TestMarketCatalogUpsertIndex(t *testing.T) {
+	catalog := newTestCatalog(&fakeMarketPages{})
+	a := negRisk(testMarket("0xa", "0.01", "a1", "a2"), "g1")
+	b := negRisk(testMarket("0xb", "0.01", "b1", "b2"), "g1")
+	catalog.upsert(a)
+	catalog.upsert(b)
+
+	// 替换市场：旧的 token、question 和 neg risk 索引被移除
+	a2 := negRisk(testMarket("0xa", "0.01", "a1", "a3"), "g2")
+	a2.QuestionID = "q-new"
+	catalog.upsert(a2)
+
+	if _, ok := catalog.ByToken("a2"); ok {
+		t.Fatal("stale token still indexed")
+	}
+	if m, ok := catalog.ByToken("a3"); !ok || m.ConditionID != "0xa" {
+		t.Fatal("new token not indexed")
+	}
+	if _, ok := catalog.ByQuestion("q-0xa"); ok {
+		t.Fatal("stale question still indexed")
+	}
+	if m, ok := catalog.ByQuestion("q-new"); !ok || m.ConditionID != "0xa" {
+		t.Fatal("new question not indexed")
+	}
+	if got := conditionIDs(catalog.ByNegRiskMarket("g1")); !reflect.DeepEqual(got, []string{"0xb"}) {
+		t.Fatalf("g1 = %v", got)
+	}
+	if got := conditionIDs(catalog.ByNegRiskMarket("g2")); !reflect.DeepEqual(got, []string{"0xa"}) {
+		t.Fatalf("g2 = %v", got)
+	}
+
+	// 同一市场重复插入不产生重复索引
+	catalog.upsert(b)
+	catalog.upsert(b)
+	if got := conditionIDs(catalog.ByNegRiskMarket("g1")); !reflect.DeepEqual(got, []string{"0xb"}) {
+		t.Fatalf("g1 after repeated upsert = %v", got)
+	}
+	catalog.unindex(b)
+	if _, ok := catalog.byNegRisk["g1"]; ok || catalog.Len() != 1 {
+		t.Fatalf("empty neg risk group kept, len = %d", catalog.Len())
+	}
+}
+
+func TestMarketCatalogDiff(t *testing.T) {
+	openMarket := testMarket("0xa", "0.01")
+	tests := []struct {
+		name    string
+		old     Market
+		exists  bool
+		new     Market
+		added   int
+		closed  int
+		changed int
+	}{
+		{"new market", Market{}, false, openMarket, 1, 0, 0},
+		{"new closed market is only added", Market{}, false, closed(openMarket), 1, 0, 0},
+		{"unchanged", openMarket, true, openMarket, 0, 0, 0},
+		{"closed", openMarket, true, closed(openMarket), 0, 1, 0},
+		{"still closed", closed(openMarket), true, closed(openMarket), 0, 0, 0},
+		{"reopened", closed(openMarket), true, openMarket, 0, 0, 0},
+		{"tick size", openMarket, true, testMarket("0xa", "0.001"), 0, 0, 1},
+		{"closed and tick size", openMarket, true, closed(testMarket("0xa", "0.1")), 0, 1, 1},
+	}
+	for _, tt := range tests {
+		var d CatalogDiff
+		d.record(tt.old, tt.exists, tt.new)
+		if len(d.Added) != tt.added || len(d.Closed) != tt.closed || len(d.TickSizeChanged) != tt.changed {
+			t.Errorf("%s: added %d, closed %d, tick size changed %d", tt.name, len(d.Added), len(d.Closed), len(d.TickSizeChanged))
+		}
+		if d.IsEmpty() != (tt.added+tt.closed+tt.changed == 0) {
+			t.Errorf("%s: IsEmpty = %v", tt.name, d.IsEmpty())
+		}
+	}
+}
+
+func TestMarketCatalogLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	catalog := newTestCatalog(&fakeMarketPages{})
+	if err := catalog.Load(filepath.Join(dir, "missing.jsonl")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("missing file error = %v", err)
+	}
+
+	files := map[string]string{
+		"version.jsonl": `{"version":99}` + "\n",
+		"market.jsonl":  `{"version":1,"count":1}` + "\n" + `{"condition_id":` + "\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := catalog.Load(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package polymarket
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Middleware HTTP 传输层中间件，包装 http.RoundTripper
+// 可用于请求日志、指标、链路追踪、代理、注入请求头等横切逻辑；
+// ClobClient（含 RFQ）与 gasless 中继客户端共享同一套中间件机制
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc 函数形式的 http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// ChainMiddleware 将中间件依次包装到 base 上，第一个中间件位于最外层（最先处理请求）
+// base 为 nil 时使用 http.DefaultTransport
+func ChainMiddleware(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+// WrapHTTPClient 返回 client 的副本，其 Transport 被中间件包装（不修改传入的 client）
+func WrapHTTPClient(client *http.Client, middlewares ...Middleware) *http.Client {
+	if client == nil {
+		client = &http.Client{}
+	}
+	wrapped := *client
+	wrapped.Transport = ChainMiddleware(client.Transport, middlewares...)
+	return &wrapped
+}
+
+// HeaderMiddleware 为每个请求注入固定的请求头（覆盖同名请求头）
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// RoundTripper 不应修改传入的请求
+			req = req.Clone(req.Context())
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LoggingMiddleware 记录每个请求的方法、地址、状态码和耗时（认证相关请求头会被脱敏）
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			attrs := []any{
+				"method", req.Method,
+				"host", req.URL.Host,
+				"path", req.URL.Path,
+				"headers", RedactHeaders(req.Header),
+				"duration", time.Since(start),
+			}
+			if err != nil {
+				logger.WarnContext(req.Context(), "http request failed", append(attrs, "error", err)...)
+				return resp, err
+			}
+			logger.DebugContext(req.Context(), "http request", append(attrs, "status", resp.StatusCode)...)
+			return resp, nil
+		})
+	}
+}
+
+// publicPolyHeaders 可以明文输出的 POLY_* 请求头
+var publicPolyHeaders = map[string]bool{
+	"POLY_ADDRESS":           true,
+	"POLY_TIMESTAMP":         true,
+	"POLY_NONCE":             true,
+	"POLY_BUILDER_TIMESTAMP": true,
+}
+
+// RedactHeaders 返回请求头的副本，其中 POLY_* 认证信息（签名、API Key、口令等）和 Authorization 被替换为 "[REDACTED]"
+func RedactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		upper := strings.ToUpper(k)
+		switch {
+		case strings.HasPrefix(upper, "POLY_") && !publicPolyHeaders[upper],
+			upper == "AUTHORIZATION", upper == "COOKIE":
+			out[k] = "[REDACTED]"
+		default:
+			out[k] = strings.Join(v, ", ")
+		}
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+package polymarket
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultNotificationPollInterval 默认的通知轮询间隔
+const DefaultNotificationPollInterval = 10 * time.Second
+
+// GetNotificationsTyped 获取通知（返回类型化结果）
+// 需要L2认证
+func (c *ClobClient) GetNotificationsTyped() ([]Notification, error) {
+	return c.GetNotificationsTypedWithContext(context.Background())
+}
+
+// GetNotificationsTypedWithContext 获取通知（返回类型化结果，支持context）
+func (c *ClobClient) GetNotificationsTypedWithContext(ctx context.Context) ([]Notification, error) {
+	if err := c.assertLevel2Auth(); err != nil {
+		return nil, err
+	}
+
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: GetNotifications,
+	}
+
+	var notifications []Notification
+	if err := c.httpClient.CallInto(ctx, "GET", c.notificationsPath(), nil, CallOptions{Sign: c.l2Signer(requestArgs)}, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// DropNotificationIDs 按通知ID删除通知（DropNotifications 的便捷方法）
+// 需要L2认证
+func (c *ClobClient) DropNotificationIDs(ctx context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	params := &DropNotificationParams{IDs: make([]string, len(ids))}
+	for i, id := range ids {
+		params.IDs[i] = strconv.FormatInt(id, 10)
+	}
+	_, err := c.DropNotificationsWithContext(ctx, params)
+	return err
+}
+
+// NotificationHandlers 按类型处理通知的回调，未设置的类型直接确认（不处理）
+// 回调返回 nil 表示已处理，通知会被删除；返回错误时通知保留，下次轮询重试
+type NotificationHandlers struct {
+	OrderCancelled func(ctx context.Context, n *Notification, p *OrderCancelledPayload) error
+	OrderFilled    func(ctx context.Context, n *Notification, p *OrderFilledPayload) error
+	MarketResolved func(ctx context.Context, n *Notification, p *MarketResolvedPayload) error
+	Other          func(ctx context.Context, n *Notification) error // 未知类型
+}
+
+// NotificationPoller 定期获取通知，去重后分发给 NotificationHandlers，并删除已处理的通知
+type NotificationPoller struct {
+	client   *ClobClient
+	interval time.Duration
+	handlers NotificationHandlers
+
+	mu   sync.Mutex
+	seen map[int64]bool // 已处理但可能尚未删除成功的通知ID
+}
+
+// NewNotificationPoller 创建通知轮询器，interval <= 0 时使用 DefaultNotificationPollInterval
+func NewNotificationPoller(client *ClobClient, interval time.Duration, handlers NotificationHandlers) *NotificationPoller {
+	if interval <= 0 {
+		interval = DefaultNotificationPollInterval
+	}
+	return &NotificationPoller{
+		client:   client,
+		interval: interval,
+		handlers: handlers,
+		seen:     make(map[int64]bool),
+	}
+}
+
+// Run 立即轮询一次，然后按间隔轮询，直到 ctx 结束（返回 ctx.Err()）
+// 单次轮询失败只记录日志，不会中断
+func (p *NotificationPoller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			p.client.httpClient.Logger().WarnContext(ctx, "notification poll failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll 执行一次轮询：获取通知、跳过已处理的通知、分发新通知，然后删除已处理的通知
+// 返回本次分发成功的通知数量
+func (p *NotificationPoller) Poll(ctx context.Context) (int, error) {
+	notifications, err := p.client.GetNotificationsTypedWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// 服务器不再返回的通知已被删除，无需继续记录
+	current := make(map[int64]bool, len(notifications))
+	for i := range notifications {
+		current[notifications[i].ID] = true
+	}
+	for id := range p.seen {
+		if !current[id] {
+			delete(p.seen, id)
+		}
+	}
+
+	var acked []int64
+	handled := 0
+	for i := range notifications {
+		n := &notifications[i]
+		if p.seen[n.ID] {
+			// 已处理，上次删除失败，重新尝试删除
+			acked = append(acked, n.ID)
+			continue
+		}
+
+		if err := p.dispatch(ctx, n); err != nil {
+			p.client.httpClient.Logger().WarnContext(ctx, "notification handler failed",
+				"id", n.ID,
+				"type", n.Type.String(),
+				"error", err,
+			)
+			continue
+		}
+		p.seen[n.ID] = true
+		acked = append(acked, n.ID)
+		handled++
+	}
+
+	if err := p.client.DropNotificationIDs(ctx, acked...); err != nil {
+		return handled, err
+	}
+	return handled, nil
+}
+
+// dispatch 按类型解析通知并调用对应的回调
+func (p *NotificationPoller) dispatch(ctx context.Context, n *Notification) error {
+	switch n.Type {
+	case NotificationOrderCancelled:
+		if p.handlers.OrderCancelled == nil {
+			return nil
+		}
+		payload, err := n.OrderCancelled()
+		if err != nil {
+			return err
+		}
+		return p.handlers.OrderCancelled(ctx, n, payload)
+	case NotificationOrderFilled:
+		if p.handlers.OrderFilled == nil {
+			return nil
+		}
+		payload, err := n.OrderFilled()
+		if err != nil {
+			return err
+		}
+		return p.handlers.OrderFilled(ctx, n, payload)
+	case NotificationMarketResolved:
+		if p.handlers.MarketResolved == nil {
+			return nil
+		}
+		payload, err := n.MarketResolved()
+		if err != nil {
+			return err
+		}
+		return p.handlers.MarketResolved(ctx, n, payload)
+	}
+
+	if p.handlers.Other == nil {
+		return nil
+	}
+	return p.handlers.Other(ctx, n)
+}
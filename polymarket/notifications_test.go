@@ -0,0 +1,148 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// notificationServer 假的 /notifications 服务：GET 返回未删除的通知，DELETE 按 ids 删除
+type notificationServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	pending  map[string]string // id -> 通知 JSON
+	order    []string
+	drops    []string // 每次 DELETE 的 ids 参数
+	failDrop bool     // 下一次 DELETE 返回错误
+}
+
+func newNotificationServer(t *testing.T, notifications ...string) *notificationServer {
+	s := &notificationServer{pending: make(map[string]string)}
+	for _, n := range notifications {
+		id := strings.TrimPrefix(n[:strings.Index(n, ",")], `{"id":`)
+		s.pending[id] = n
+		s.order = append(s.order, id)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodDelete {
+			ids := r.URL.Query().Get("ids")
+			s.drops = append(s.drops, ids)
+			if s.failDrop {
+				s.failDrop = false
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"drop failed"}`))
+				return
+			}
+			for _, id := range strings.Split(ids, ",") {
+				delete(s.pending, id)
+			}
+			w.Write([]byte(`"OK"`))
+			return
+		}
+
+		var list []string
+		for _, id := range s.order {
+			if n, ok := s.pending[id]; ok {
+				list = append(list, n)
+			}
+		}
+		w.Write([]byte("[" + strings.Join(list, ",") + "]"))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestNotificationPoller(t *testing.T) {
+	server := newNotificationServer(t,
+		`{"id":1,"type":1,"owner":"key","timestamp":"1700000000","payload":{"order_id":"0x1","price":"0.45","original_size":"10","size_matched":"2"}}`,
+		`{"id":2,"type":2,"owner":"key","timestamp":"1700000001","payload":{"order_id":"0x2","trade_id":"t-1","price":"0.55","matched_size":"5","status":"MATCHED"}}`,
+		`{"id":3,"type":4,"owner":"key","timestamp":"1700000002","payload":{"market":"0xcond","winning_outcome":"Yes"}}`,
+		`{"id":4,"type":99,"owner":"key","timestamp":"1700000003","payload":{}}`,
+	)
+	client := newPagedClient(t, server.URL)
+
+	calls := map[string]int{}
+	failFill := true
+	poller := NewNotificationPoller(client, 0, NotificationHandlers{
+		OrderCancelled: func(ctx context.Context, n *Notification, p *OrderCancelledPayload) error {
+			calls["cancelled"]++
+			if p.OrderID != "0x1" || p.Price.String() != "0.45" || p.SizeMatched.String() != "2" {
+				t.Errorf("unexpected cancelled payload: %+v", p)
+			}
+			return nil
+		},
+		OrderFilled: func(ctx context.Context, n *Notification, p *OrderFilledPayload) error {
+			calls["filled"]++
+			if p.TradeID != "t-1" || p.MatchedSize.String() != "5" {
+				t.Errorf("unexpected filled payload: %+v", p)
+			}
+			// 第一次处理失败，通知保留到下次轮询
+			if failFill {
+				failFill = false
+				return errors.New("handler failed")
+			}
+			return nil
+		},
+		MarketResolved: func(ctx context.Context, n *Notification, p *MarketResolvedPayload) error {
+			calls["resolved"]++
+			if p.WinningOutcome != "Yes" {
+				t.Errorf("unexpected resolved payload: %+v", p)
+			}
+			return nil
+		},
+		Other: func(ctx context.Context, n *Notification) error {
+			calls["other"]++
+			if n.ID != 4 {
+				t.Errorf("unexpected notification %d", n.ID)
+			}
+			return nil
+		},
+	})
+	ctx := context.Background()
+
+	// 第一次轮询：处理失败的通知不删除；删除请求失败
+	server.failDrop = true
+	handled, err := poller.Poll(ctx)
+	if err == nil || handled != 3 {
+		t.Fatalf("first poll: handled %d, err %v", handled, err)
+	}
+
+	// 第二次轮询：已处理的通知不再分发，只重新删除
+	handled, err = poller.Poll(ctx)
+	if err != nil || handled != 1 {
+		t.Fatalf("second poll: handled %d, err %v", handled, err)
+	}
+	want := map[string]int{"cancelled": 1, "filled": 2, "resolved": 1, "other": 1}
+	for k, v := range want {
+		if calls[k] != v {
+			t.Errorf("%s handler called %d times, want %d", k, calls[k], v)
+		}
+	}
+
+	// 第三次轮询：没有通知，不发删除请求，去重记录被清理
+	handled, err = poller.Poll(ctx)
+	if err != nil || handled != 0 {
+		t.Fatalf("third poll: handled %d, err %v", handled, err)
+	}
+	if len(poller.seen) != 0 {
+		t.Fatalf("seen not pruned: %v", poller.seen)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if strings.Join(server.drops, " ") != "1,3,4 1,2,3,4" {
+		t.Fatalf("drops = %q", server.drops)
+	}
+	if len(server.pending) != 0 {
+		t.Fatalf("notifications left on server: %v", server.pending)
+	}
+}
@@ -0,0 +1,151 @@
+package polymarket
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ClientOption ClobClient 构造选项
+type ClientOption func(*clientConfig)
+
+// clientConfig 构造 ClobClient 时收集的配置
+type clientConfig struct {
+	privateKey    string
+	signer        *Signer
+	creds         *ApiCreds
+	signatureType int
+	funder        string
+	httpClient    *http.Client
+	timeout       time.Duration
+	userAgent     string
+	rateLimiter   *RateLimiter
+	retryPolicy   *RetryPolicy
+	retrySet      bool
+	logger        *slog.Logger
+	middlewares   []Middleware
+
+	onClockSkew func(skew, rtt time.Duration)
+}
+
+// WithPrivateKey 使用私钥（十六进制字符串）创建签名器，启用L1认证
+func WithPrivateKey(privateKey string) ClientOption {
+	return func(c *clientConfig) {
+		c.privateKey = privateKey
+	}
+}
+
+// WithSigner 使用已创建的签名器（优先于 WithPrivateKey）
+func WithSigner(signer *Signer) ClientOption {
+	return func(c *clientConfig) {
+		c.signer = signer
+	}
+}
+
+// WithCreds 设置API凭证，与签名器一起启用L2认证
+func WithCreds(creds *ApiCreds) ClientOption {
+	return func(c *clientConfig) {
+		c.creds = creds
+	}
+}
+
+// WithSignatureType 设置签名类型（0=EOA, 1=Email/Magic, 2=Browser proxy），默认EOA
+func WithSignatureType(signatureType int) ClientOption {
+	return func(c *clientConfig) {
+		c.signatureType = signatureType
+	}
+}
+
+// WithFunder 设置资金持有者地址（用于代理钱包），默认为签名器地址
+func WithFunder(funder string) ClientOption {
+	return func(c *clientConfig) {
+		c.funder = funder
+	}
+}
+
+// WithHTTPClient 使用自定义的 http.Client（如自定义 Transport、代理）
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *clientConfig) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout 设置请求超时时间（默认30秒）
+// 与 WithHTTPClient 同时使用时，会在副本上覆盖其超时，不修改传入的 http.Client
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent 设置 User-Agent 请求头（默认 "polymarket-sdk-go"）
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRateLimiter 设置客户端限流器，使用同一 API Key 的多个客户端应共享同一个限流器
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *clientConfig) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithRetryPolicy 设置重试策略（默认 DefaultRetryPolicy），nil 表示不重试
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		c.retryPolicy = policy
+		c.retrySet = true
+	}
+}
+
+// WithLogger 设置日志记录器（默认不输出日志）
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *clientConfig) {
+		c.logger = logger
+	}
+}
+
+// WithMiddleware 在HTTP传输层追加中间件（先传入的位于外层）
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *clientConfig) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithClockSkewHandler 设置时钟偏差回调（每次同步成功后调用，可用于上报指标）
+func WithClockSkewHandler(fn func(skew, rtt time.Duration)) ClientOption {
+	return func(c *clientConfig) {
+		c.onClockSkew = fn
+	}
+}
+
+// newHTTPClientFromConfig 根据配置创建HTTP客户端
+func newHTTPClientFromConfig(host string, cfg *clientConfig) *HTTPClient {
+	hc := NewHTTPClient(host)
+
+	if cfg.httpClient != nil {
+		hc.client = cfg.httpClient
+	}
+	if cfg.timeout > 0 {
+		client := *hc.client
+		client.Timeout = cfg.timeout
+		hc.client = &client
+	}
+	if cfg.userAgent != "" {
+		hc.userAgent = cfg.userAgent
+	}
+	if cfg.retrySet {
+		hc.retry = cfg.retryPolicy
+	}
+	if cfg.logger != nil {
+		hc.logger = cfg.logger
+	}
+	hc.limiter = cfg.rateLimiter
+	if len(cfg.middlewares) > 0 {
+		hc.Use(cfg.middlewares...)
+	}
+
+	return hc
+}
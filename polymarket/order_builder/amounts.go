@@ -0,0 +1,151 @@
+package order_builder
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/polymarket/go-order-utils/pkg/model"
+)
+
+// 金额计算全部在 6 位精度的整数单位（base units，1e6 = 1）上进行，不经过浮点数
+//
+// 结果满足 CLOB 的精度规则（以 RoundConfig 为准）：
+//   - 价格四舍五入到 Price 位小数
+//   - 代币数量（BUY 的 taker、SELL 的 maker）最多 Size 位小数，向下舍入
+//   - USDC 金额（BUY 的 maker、SELL 的 taker）最多 Amount 位小数，向下舍入
+//   - 限价订单的 USDC 金额 = 代币数量 * 价格（精确相等）
+//   - 市价 BUY 订单的 USDC 金额 = 代币数量 * 价格，且不超过输入金额
+
+// BaseUnit 1 对应的整数单位数（USDC 与条件代币均为 6 位精度）
+const BaseUnit = 1_000_000
+
+var bigBaseUnit = big.NewInt(BaseUnit)
+
+// OrderAmounts 计算限价订单的 maker/taker 金额
+// size 和 price 均为 6 位精度的整数单位（如 0.57 为 570000）
+func OrderAmounts(side string, size, price *big.Int, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	modelSide, err := parseSide(side)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if size.Sign() < 0 {
+		return 0, nil, nil, fmt.Errorf("size must not be negative, got %s", formatUnits(size))
+	}
+	rawPrice, err := roundPrice(price, roundConfig)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	// 代币数量向下舍入到 Size 位，USDC 金额 = 数量 * 价格
+	tokens := floorTo(size, step(roundConfig.Size))
+	usdc := floorTo(mulUnits(tokens, rawPrice), step(roundConfig.Amount))
+
+	if err := checkNonZero(tokens, usdc); err != nil {
+		return 0, nil, nil, err
+	}
+	if modelSide == model.BUY {
+		return model.BUY, usdc, tokens, nil
+	}
+	return model.SELL, tokens, usdc, nil
+}
+
+// MarketOrderAmounts 计算市价订单的 maker/taker 金额
+// BUY 时 amount 为 USDC 金额，SELL 时为代币数量，均为 6 位精度的整数单位
+func MarketOrderAmounts(side string, amount, price *big.Int, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	modelSide, err := parseSide(side)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if amount.Sign() < 0 {
+		return 0, nil, nil, fmt.Errorf("amount must not be negative, got %s", formatUnits(amount))
+	}
+	rawPrice, err := roundPrice(price, roundConfig)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	if modelSide == model.BUY {
+		// 与官方客户端一致，先将 USDC 金额向下舍入到 Size 位；
+		// 再取不超过该金额的最大代币数量，USDC 金额由 数量 * 价格 得出
+		budget := floorTo(amount, step(roundConfig.Size))
+		tokens := floorTo(quoUnits(budget, rawPrice), step(roundConfig.Size))
+		usdc := floorTo(mulUnits(tokens, rawPrice), step(roundConfig.Amount))
+
+		if err := checkNonZero(tokens, usdc); err != nil {
+			return 0, nil, nil, err
+		}
+		return model.BUY, usdc, tokens, nil
+	}
+
+	tokens := floorTo(amount, step(roundConfig.Size))
+	usdc := floorTo(mulUnits(tokens, rawPrice), step(roundConfig.Amount))
+
+	if err := checkNonZero(tokens, usdc); err != nil {
+		return 0, nil, nil, err
+	}
+	return model.SELL, tokens, usdc, nil
+}
+
+// parseSide 解析订单方向
+func parseSide(side string) (model.Side, error) {
+	switch side {
+	case "BUY":
+		return model.BUY, nil
+	case "SELL":
+		return model.SELL, nil
+	}
+	return 0, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
+}
+
+// roundPrice 将价格四舍五入到 Price 位小数，结果必须在 (0, 1) 之间
+func roundPrice(price *big.Int, roundConfig RoundConfig) (*big.Int, error) {
+	priceStep := step(roundConfig.Price)
+	half := new(big.Int).Rsh(priceStep, 1)
+	rawPrice := floorTo(new(big.Int).Add(price, half), priceStep)
+	if rawPrice.Sign() <= 0 || rawPrice.Cmp(bigBaseUnit) >= 0 {
+		return nil, fmt.Errorf("price must be between 0 and 1, got %s", formatUnits(price))
+	}
+	return rawPrice, nil
+}
+
+// checkNonZero 舍入后的金额不能为 0
+func checkNonZero(tokens, usdc *big.Int) error {
+	if tokens.Sign() == 0 || usdc.Sign() == 0 {
+		return fmt.Errorf("order amounts round to zero (size %s, amount %s)", formatUnits(tokens), formatUnits(usdc))
+	}
+	return nil
+}
+
+// step 返回 places 位小数对应的最小整数单位，即 10^(6-places)
+func step(places int) *big.Int {
+	if places >= 6 {
+		return big.NewInt(1)
+	}
+	if places < 0 {
+		places = 0
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(6-places)), nil)
+}
+
+// floorTo 将非负数 x 向下舍入到 unit 的整数倍
+func floorTo(x, unit *big.Int) *big.Int {
+	r := new(big.Int).Mod(x, unit)
+	return r.Sub(x, r)
+}
+
+// mulUnits 两个整数单位数值相乘（结果向下取整）
+func mulUnits(a, b *big.Int) *big.Int {
+	p := new(big.Int).Mul(a, b)
+	return p.Quo(p, bigBaseUnit)
+}
+
+// quoUnits 两个整数单位数值相除（结果向下取整）
+func quoUnits(a, b *big.Int) *big.Int {
+	q := new(big.Int).Mul(a, bigBaseUnit)
+	return q.Quo(q, b)
+}
+
+// formatUnits 将整数单位格式化为十进制字符串（用于错误信息）
+func formatUnits(x *big.Int) string {
+	return new(big.Rat).SetFrac(x, bigBaseUnit).FloatString(6)
+}
@@ -0,0 +1,261 @@
+package order_builder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/polymarket/go-order-utils/pkg/model"
+)
+
+// testSizes 覆盖整数、已对齐、未对齐（超出 Size 位）以及较大的数量，单位为 1e6
+var testSizes = []int64{
+	10_000,        // 0.01
+	290_000,       // 0.29
+	1_000_000,     // 1
+	5_000_000,     // 5
+	7_123_456,     // 7.123456
+	13_370_000,    // 13.37
+	99_990_000,    // 99.99
+	100_000_000,   // 100
+	1_234_567_891, // 1234.567891
+	987_654_321_000,
+}
+
+// tickPrices 返回某个 tick size 下所有有效价格（tick 到 1-tick），单位为 1e6
+func tickPrices(tickSize string) []int64 {
+	roundConfig := RoundingConfig[tickSize]
+	tick := step(roundConfig.Price).Int64()
+	prices := make([]int64, 0, BaseUnit/tick)
+	for p := tick; p < BaseUnit; p += tick {
+		prices = append(prices, p)
+	}
+	return prices
+}
+
+// isMultiple x 是否为 10^(6-places) 的整数倍（即最多 places 位小数）
+func isMultiple(x *big.Int, places int) bool {
+	return new(big.Int).Mod(x, step(places)).Sign() == 0
+}
+
+// checkLimitAmounts 验证限价订单金额满足精度规则且价格精确
+func checkLimitAmounts(t *testing.T, tickSize, side string, size, price int64) {
+	t.Helper()
+	roundConfig := RoundingConfig[tickSize]
+	sizeStep := step(roundConfig.Size).Int64()
+
+	gotSide, maker, taker, err := OrderAmounts(side, big.NewInt(size), big.NewInt(price), roundConfig)
+	if size < sizeStep {
+		if err == nil {
+			t.Fatalf("tick %s %s size=%d price=%d: expected error for size below minimum", tickSize, side, size, price)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("tick %s %s size=%d price=%d: %v", tickSize, side, size, price, err)
+	}
+
+	tokens, usdc := taker, maker
+	wantSide := model.BUY
+	if side == "SELL" {
+		tokens, usdc = maker, taker
+		wantSide = model.SELL
+	}
+	if gotSide != wantSide {
+		t.Fatalf("tick %s %s: side = %v, want %v", tickSize, side, gotSide, wantSide)
+	}
+
+	if want := size - size%sizeStep; tokens.Int64() != want {
+		t.Fatalf("tick %s %s size=%d price=%d: tokens = %s, want %d", tickSize, side, size, price, tokens, want)
+	}
+	if !isMultiple(tokens, roundConfig.Size) {
+		t.Fatalf("tick %s %s size=%d price=%d: tokens %s exceed %d decimals", tickSize, side, size, price, tokens, roundConfig.Size)
+	}
+	if !isMultiple(usdc, roundConfig.Amount) {
+		t.Fatalf("tick %s %s size=%d price=%d: usdc %s exceeds %d decimals", tickSize, side, size, price, usdc, roundConfig.Amount)
+	}
+	// usdc / tokens 必须精确等于价格
+	lhs := new(big.Int).Mul(usdc, bigBaseUnit)
+	rhs := new(big.Int).Mul(tokens, big.NewInt(price))
+	if lhs.Cmp(rhs) != 0 {
+		t.Fatalf("tick %s %s size=%d price=%d: usdc %s / tokens %s != price", tickSize, side, size, price, usdc, tokens)
+	}
+}
+
+// checkMarketAmounts 验证市价订单金额满足精度规则
+func checkMarketAmounts(t *testing.T, tickSize, side string, amount, price int64) {
+	t.Helper()
+	roundConfig := RoundingConfig[tickSize]
+	sizeStep := step(roundConfig.Size)
+
+	_, maker, taker, err := MarketOrderAmounts(side, big.NewInt(amount), big.NewInt(price), roundConfig)
+	if err != nil {
+		// 只有金额不足以买到/卖出一个最小单位时才允许出错
+		budget := floorTo(big.NewInt(amount), sizeStep)
+		minCost := mulUnits(sizeStep, big.NewInt(price))
+		if side == "BUY" && budget.Cmp(minCost) < 0 || side == "SELL" && budget.Sign() == 0 {
+			return
+		}
+		t.Fatalf("tick %s %s amount=%d price=%d: %v", tickSize, side, amount, price, err)
+	}
+
+	if side == "BUY" {
+		usdc, tokens := maker, taker
+		budget := floorTo(big.NewInt(amount), sizeStep)
+		if !isMultiple(usdc, roundConfig.Amount) || !isMultiple(tokens, roundConfig.Size) {
+			t.Fatalf("tick %s BUY amount=%d price=%d: usdc %s / tokens %s break precision", tickSize, amount, price, usdc, tokens)
+		}
+		if usdc.Cmp(budget) > 0 {
+			t.Fatalf("tick %s BUY amount=%d price=%d: usdc %s exceeds budget %s", tickSize, amount, price, usdc, budget)
+		}
+		if new(big.Int).Mul(usdc, bigBaseUnit).Cmp(new(big.Int).Mul(tokens, big.NewInt(price))) != 0 {
+			t.Fatalf("tick %s BUY amount=%d price=%d: usdc %s / tokens %s != price", tickSize, amount, price, usdc, tokens)
+		}
+		// 再多买一个最小单位就会超出预算
+		next := new(big.Int).Add(tokens, sizeStep)
+		if new(big.Int).Mul(next, big.NewInt(price)).Cmp(new(big.Int).Mul(budget, bigBaseUnit)) <= 0 {
+			t.Fatalf("tick %s BUY amount=%d price=%d: tokens %s is not maximal", tickSize, amount, price, tokens)
+		}
+		return
+	}
+
+	tokens, usdc := maker, taker
+	if want := floorTo(big.NewInt(amount), sizeStep); tokens.Cmp(want) != 0 {
+		t.Fatalf("tick %s SELL amount=%d price=%d: tokens = %s, want %s", tickSize, amount, price, tokens, want)
+	}
+	if !isMultiple(usdc, roundConfig.Amount) {
+		t.Fatalf("tick %s SELL amount=%d price=%d: usdc %s exceeds %d decimals", tickSize, amount, price, usdc, roundConfig.Amount)
+	}
+	if new(big.Int).Mul(usdc, bigBaseUnit).Cmp(new(big.Int).Mul(tokens, big.NewInt(price))) != 0 {
+		t.Fatalf("tick %s SELL amount=%d price=%d: usdc %s / tokens %s != price", tickSize, amount, price, usdc, tokens)
+	}
+}
+
+func TestOrderAmountsAllPrices(t *testing.T) {
+	for tickSize := range RoundingConfig {
+		t.Run(tickSize, func(t *testing.T) {
+			for _, price := range tickPrices(tickSize) {
+				for _, size := range testSizes {
+					checkLimitAmounts(t, tickSize, "BUY", size, price)
+					checkLimitAmounts(t, tickSize, "SELL", size, price)
+				}
+			}
+		})
+	}
+}
+
+func TestMarketOrderAmountsAllPrices(t *testing.T) {
+	for tickSize := range RoundingConfig {
+		t.Run(tickSize, func(t *testing.T) {
+			for _, price := range tickPrices(tickSize) {
+				for _, amount := range testSizes {
+					checkMarketAmounts(t, tickSize, "BUY", amount, price)
+					checkMarketAmounts(t, tickSize, "SELL", amount, price)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderAmountsFloatAdapter(t *testing.T) {
+	ob := &OrderBuilder{}
+	tests := []struct {
+		name                string
+		side                string
+		size, price         float64
+		tickSize            string
+		wantMaker, wantTake int64
+	}{
+		// 0.29 * 100 在 float64 中为 28.999999999999996，旧实现会舍入为 0.28
+		{"buy 0.29 @ 0.57", "BUY", 0.29, 0.57, "0.01", 165_300, 290_000},
+		{"sell 0.29 @ 0.57", "SELL", 0.29, 0.57, "0.01", 290_000, 165_300},
+		{"buy 100 @ 0.56", "BUY", 100, 0.56, "0.01", 56_000_000, 100_000_000},
+		{"buy 21.04 @ 0.58", "BUY", 21.04, 0.58, "0.01", 12_203_200, 21_040_000},
+		{"sell 5 @ 0.0001", "SELL", 5, 0.0001, "0.0001", 5_000_000, 500},
+		{"price rounded to tick", "BUY", 10, 0.555, "0.01", 5_600_000, 10_000_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, maker, taker, err := ob.GetOrderAmounts(tt.side, tt.size, tt.price, RoundingConfig[tt.tickSize])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if maker.Int64() != tt.wantMaker || taker.Int64() != tt.wantTake {
+				t.Fatalf("maker/taker = %s/%s, want %d/%d", maker, taker, tt.wantMaker, tt.wantTake)
+			}
+		})
+	}
+}
+
+func TestMarketOrderAmountsFloatAdapter(t *testing.T) {
+	ob := &OrderBuilder{}
+	tests := []struct {
+		name                string
+		side                string
+		amount, price       float64
+		tickSize            string
+		wantMaker, wantTake int64
+	}{
+		{"buy $100 @ 0.56", "BUY", 100, 0.56, "0.01", 99_999_200, 178_570_000},
+		{"buy $1 @ 0.3", "BUY", 1, 0.3, "0.1", 999_000, 3_330_000},
+		{"sell 10 @ 0.57", "SELL", 10, 0.57, "0.01", 10_000_000, 5_700_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, maker, taker, err := ob.GetMarketOrderAmounts(tt.side, tt.amount, tt.price, RoundingConfig[tt.tickSize])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if maker.Int64() != tt.wantMaker || taker.Int64() != tt.wantTake {
+				t.Fatalf("maker/taker = %s/%s, want %d/%d", maker, taker, tt.wantMaker, tt.wantTake)
+			}
+		})
+	}
+}
+
+func TestOrderAmountsErrors(t *testing.T) {
+	roundConfig := RoundingConfig["0.01"]
+	tests := []struct {
+		name        string
+		side        string
+		size, price int64
+	}{
+		{"invalid side", "HOLD", 1_000_000, 500_000},
+		{"zero price", "BUY", 1_000_000, 0},
+		{"price rounds to zero", "BUY", 1_000_000, 4_999},
+		{"price of one", "SELL", 1_000_000, 1_000_000},
+		{"negative size", "BUY", -1_000_000, 500_000},
+		{"size below minimum", "BUY", 9_999, 500_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := OrderAmounts(tt.side, big.NewInt(tt.size), big.NewInt(tt.price), roundConfig); err == nil {
+				t.Fatal("expected error")
+			}
+			if _, _, _, err := MarketOrderAmounts(tt.side, big.NewInt(tt.size), big.NewInt(tt.price), roundConfig); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func FuzzOrderAmounts(f *testing.F) {
+	f.Add(uint8(1), uint32(57), uint64(290_000), false)
+	f.Add(uint8(3), uint32(1), uint64(987_654_321_000), true)
+	f.Add(uint8(0), uint32(9), uint64(7_123_456), true)
+	f.Add(uint8(2), uint32(999), uint64(10_000), false)
+
+	tickSizes := []string{"0.1", "0.01", "0.001", "0.0001"}
+	f.Fuzz(func(t *testing.T, tickIdx uint8, priceIdx uint32, size uint64, sell bool) {
+		tickSize := tickSizes[int(tickIdx)%len(tickSizes)]
+		prices := tickPrices(tickSize)
+		price := prices[int(priceIdx)%len(prices)]
+		amount := int64(size % (1 << 48))
+
+		side := "BUY"
+		if sell {
+			side = "SELL"
+		}
+		checkLimitAmounts(t, tickSize, side, amount, price)
+		checkMarketAmounts(t, tickSize, side, amount, price)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 	"strconv"
 	"strings"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
 )
 
 // RoundConfig 舍入配置
@@ -21,28 +23,39 @@ var RoundingConfig = map[string]RoundConfig{
 	"0.0001": {Price: 4, Size: 2, Amount: 6},
 }
 
+// 以下 float64 函数为兼容保留的适配层：不超过 6 位小数且在 Decimal 范围内时通过 decimal.Decimal 计算，
+// 避免 0.29 这类数值因二进制误差被舍入为 0.28；其他情况按原来的 float64 方式计算
+
 // RoundDown 向下舍入
 func RoundDown(x float64, sigDigits int) float64 {
+	if d, err := decimal.FromFloat(x); err == nil && sigDigits <= decimal.Places {
+		return d.RoundDown(sigDigits).Float64()
+	}
 	return math.Floor(x*math.Pow(10, float64(sigDigits))) / math.Pow(10, float64(sigDigits))
 }
 
 // RoundNormal 正常舍入
 func RoundNormal(x float64, sigDigits int) float64 {
+	if d, err := decimal.FromFloat(x); err == nil && sigDigits <= decimal.Places {
+		return d.Round(sigDigits).Float64()
+	}
 	return math.Round(x*math.Pow(10, float64(sigDigits))) / math.Pow(10, float64(sigDigits))
 }
 
 // RoundUp 向上舍入
 func RoundUp(x float64, sigDigits int) float64 {
+	if d, err := decimal.FromFloat(x); err == nil && sigDigits <= decimal.Places {
+		return d.RoundUp(sigDigits).Float64()
+	}
 	return math.Ceil(x*math.Pow(10, float64(sigDigits))) / math.Pow(10, float64(sigDigits))
 }
 
 // ToTokenDecimals 转换为代币小数位（6位）
 func ToTokenDecimals(x float64) int64 {
-	f := (math.Pow(10, 6)) * x
-	if DecimalPlaces(f) > 0 {
-		f = RoundNormal(f, 0)
+	if d, err := decimal.FromFloat(x); err == nil {
+		return d.Units()
 	}
-	return int64(f)
+	return int64(math.Round(x * 1e6))
 }
 
 // DecimalPlaces 计算小数位数
@@ -54,4 +67,3 @@ func DecimalPlaces(x float64) int {
 	}
 	return 0
 }
-
@@ -3,11 +3,11 @@ package order_builder
 import (
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/polymarket/go-order-utils/pkg/builder"
 	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
 )
 
 // Signer 签名器接口（避免循环导入）
@@ -42,106 +42,42 @@ func NewOrderBuilder(signer Signer, sigType int, funder string) (*OrderBuilder,
 }
 
 // GetOrderAmounts 获取订单金额（限价订单）
+// float64 适配层，价格和数量按 6 位小数四舍五入后由 GetOrderAmountsDecimal 计算
 func (ob *OrderBuilder) GetOrderAmounts(side string, size, price float64, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
-	rawPrice := RoundNormal(price, roundConfig.Price)
-
-	if side == "BUY" {
-		rawTakerAmt := RoundDown(size, roundConfig.Size)
-		rawMakerAmt := rawTakerAmt * rawPrice
-
-		if DecimalPlaces(rawMakerAmt) > roundConfig.Amount {
-			rawMakerAmt = RoundUp(rawMakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawMakerAmt) > roundConfig.Amount {
-				rawMakerAmt = RoundDown(rawMakerAmt, roundConfig.Amount)
-			}
-		}
-
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
-
-		return model.BUY, makerAmount, takerAmount, nil
-	} else if side == "SELL" {
-		rawMakerAmt := RoundDown(size, roundConfig.Size)
-		rawTakerAmt := rawMakerAmt * rawPrice
-
-		if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-			rawTakerAmt = RoundUp(rawTakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-				rawTakerAmt = RoundDown(rawTakerAmt, roundConfig.Amount)
-			}
-		}
-
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
-
-		return model.SELL, makerAmount, takerAmount, nil
+	sizeDec, err := decimal.FromFloat(size)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("invalid size: %w", err)
+	}
+	priceDec, err := decimal.FromFloat(price)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("invalid price: %w", err)
 	}
+	return ob.GetOrderAmountsDecimal(side, sizeDec, priceDec, roundConfig)
+}
 
-	return 0, nil, nil, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
+// GetOrderAmountsDecimal 获取订单金额（限价订单，定点小数），见 OrderAmounts
+func (ob *OrderBuilder) GetOrderAmountsDecimal(side string, size, price decimal.Decimal, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	return OrderAmounts(side, size.BigInt(), price.BigInt(), roundConfig)
 }
 
 // GetMarketOrderAmounts 获取市价订单金额
-// 精度要求（来自 Polymarket API）：
-// - BUY 订单：maker amount (USDC) 最多 4 位小数，taker amount (代币) 最多 2 位小数
-// - SELL 订单：maker amount (代币) 最多 2 位小数，taker amount (USDC) 最多 4 位小数
+// float64 适配层，金额和价格按 6 位小数四舍五入后由 GetMarketOrderAmountsDecimal 计算
 func (ob *OrderBuilder) GetMarketOrderAmounts(side string, amount, price float64, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
-	rawPrice := RoundNormal(price, roundConfig.Price)
-
-	if side == "BUY" {
-		// BUY: maker = USDC (最多 Amount 位小数), taker = 代币数量 (最多 Size 位小数)
-		// 1. 先计算 taker amount (代币数量)
-		rawMakerAmt := RoundDown(amount, roundConfig.Size)
-		rawTakerAmt := rawMakerAmt / rawPrice
-
-		// 2. taker amount（代币数量）必须舍入到 Size 位小数（通常是 2 位）
-		if DecimalPlaces(rawTakerAmt) > roundConfig.Size {
-			rawTakerAmt = RoundDown(rawTakerAmt, roundConfig.Size)
-		}
-
-		// 3. 关键修复：反向重新计算 maker amount，确保 maker = taker * price
-		// 这样可以保证 API 验证通过
-		rawMakerAmt = rawTakerAmt * rawPrice
-
-		// 4. 再次检查 maker amount 的精度
-		if DecimalPlaces(rawMakerAmt) > roundConfig.Amount {
-			// 尝试向上舍入一点点，看是否能解决精度问题
-			rawMakerAmt = RoundUp(rawMakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawMakerAmt) > roundConfig.Amount {
-				// 如果还是不行，强制截断
-				rawMakerAmt = RoundDown(rawMakerAmt, roundConfig.Amount)
-			}
-		}
-
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
-
-		return model.BUY, makerAmount, takerAmount, nil
-	} else if side == "SELL" {
-		// SELL: maker = 代币数量 (最多 Size 位小数), taker = USDC (最多 Amount 位小数)
-		rawMakerAmt := RoundDown(amount, roundConfig.Size)
-		rawTakerAmt := rawMakerAmt * rawPrice
-
-		// 1. taker amount（USDC）舍入到 Amount 位小数
-		if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-			rawTakerAmt = RoundUp(rawTakerAmt, roundConfig.Amount+4)
-			if DecimalPlaces(rawTakerAmt) > roundConfig.Amount {
-				rawTakerAmt = RoundDown(rawTakerAmt, roundConfig.Amount)
-			}
-		}
-
-		// 2. 关键修复：反向重算 maker amount，确保 maker * price == taker
-		rawMakerAmt = rawTakerAmt / rawPrice
-		if DecimalPlaces(rawMakerAmt) > roundConfig.Size {
-			rawMakerAmt = RoundDown(rawMakerAmt, roundConfig.Size)
-		}
-
-		makerAmount := big.NewInt(ToTokenDecimals(rawMakerAmt))
-		takerAmount := big.NewInt(ToTokenDecimals(rawTakerAmt))
-
-		return model.SELL, makerAmount, takerAmount, nil
+	amountDec, err := decimal.FromFloat(amount)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("invalid amount: %w", err)
 	}
+	priceDec, err := decimal.FromFloat(price)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("invalid price: %w", err)
+	}
+	return ob.GetMarketOrderAmountsDecimal(side, amountDec, priceDec, roundConfig)
+}
 
-	return 0, nil, nil, fmt.Errorf("order_args.side must be 'BUY' or 'SELL'")
+// GetMarketOrderAmountsDecimal 获取市价订单金额（定点小数），见 MarketOrderAmounts
+// BUY 时 amount 为 USDC 金额，SELL 时为代币数量
+func (ob *OrderBuilder) GetMarketOrderAmountsDecimal(side string, amount, price decimal.Decimal, roundConfig RoundConfig) (model.Side, *big.Int, *big.Int, error) {
+	return MarketOrderAmounts(side, amount.BigInt(), price.BigInt(), roundConfig)
 }
 
 // CreateOrder 创建并签名订单（限价订单）
@@ -164,74 +100,89 @@ type OrderSummary interface {
 	GetSize() string
 }
 
-// CalculateBuyMarketPrice 计算买入市价
+// CalculateBuyMarketPrice 计算买入市价（float64 适配层）
 func (ob *OrderBuilder) CalculateBuyMarketPrice(positions []interface{}, amountToMatch float64, orderType string) (float64, error) {
-	if len(positions) == 0 {
-		return 0, fmt.Errorf("no match")
-	}
-
-	sum := 0.0
-	for i := len(positions) - 1; i >= 0; i-- {
-		pos, ok := positions[i].(OrderSummary)
-		if !ok {
-			continue
-		}
-
-		price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-		size, _ := strconv.ParseFloat(pos.GetSize(), 64)
-		sum += size * price
-
-		if sum >= amountToMatch {
-			return price, nil
-		}
+	amount, err := decimal.FromFloat(amountToMatch)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %w", err)
 	}
+	price, err := ob.CalculateBuyMarketPriceDecimal(positions, amount, orderType)
+	return price.Float64(), err
+}
 
-	if orderType == "FOK" {
-		return 0, fmt.Errorf("no match")
-	}
+// CalculateBuyMarketPriceDecimal 计算买入市价：从最优价位开始累加 price * size，直到覆盖 amountToMatch（USDC）
+func (ob *OrderBuilder) CalculateBuyMarketPriceDecimal(positions []interface{}, amountToMatch decimal.Decimal, orderType string) (decimal.Decimal, error) {
+	return calculateMarketPrice(positions, amountToMatch, orderType, func(price, size decimal.Decimal) decimal.Decimal {
+		return size.Mul(price)
+	})
+}
 
-	// 返回第一个价格
-	if pos, ok := positions[0].(OrderSummary); ok {
-		price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-		return price, nil
+// CalculateSellMarketPrice 计算卖出市价（float64 适配层）
+func (ob *OrderBuilder) CalculateSellMarketPrice(positions []interface{}, amountToMatch float64, orderType string) (float64, error) {
+	amount, err := decimal.FromFloat(amountToMatch)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %w", err)
 	}
+	price, err := ob.CalculateSellMarketPriceDecimal(positions, amount, orderType)
+	return price.Float64(), err
+}
 
-	return 0, fmt.Errorf("invalid position format")
+// CalculateSellMarketPriceDecimal 计算卖出市价：从最优价位开始累加 size，直到覆盖 amountToMatch（代币数量）
+func (ob *OrderBuilder) CalculateSellMarketPriceDecimal(positions []interface{}, amountToMatch decimal.Decimal, orderType string) (decimal.Decimal, error) {
+	return calculateMarketPrice(positions, amountToMatch, orderType, func(_, size decimal.Decimal) decimal.Decimal {
+		return size
+	})
 }
 
-// CalculateSellMarketPrice 计算卖出市价
-func (ob *OrderBuilder) CalculateSellMarketPrice(positions []interface{}, amountToMatch float64, orderType string) (float64, error) {
+// calculateMarketPrice 从最后一个价位（最优价）向前累加 levelAmount，返回累计达到 amountToMatch 时的价格
+// 无法完全匹配时，FOK 返回错误，其它类型返回最差价位的价格
+func calculateMarketPrice(positions []interface{}, amountToMatch decimal.Decimal, orderType string, levelAmount func(price, size decimal.Decimal) decimal.Decimal) (decimal.Decimal, error) {
 	if len(positions) == 0 {
-		return 0, fmt.Errorf("no match")
+		return decimal.Zero, fmt.Errorf("no match")
 	}
 
-	sum := 0.0
+	sum := decimal.Zero
 	for i := len(positions) - 1; i >= 0; i-- {
 		pos, ok := positions[i].(OrderSummary)
 		if !ok {
 			continue
 		}
 
-		size, _ := strconv.ParseFloat(pos.GetSize(), 64)
-		sum += size
+		price, size, err := parseLevel(pos)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		sum = sum.Add(levelAmount(price, size))
 
-		if sum >= amountToMatch {
-			price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
+		if sum.Cmp(amountToMatch) >= 0 {
 			return price, nil
 		}
 	}
 
 	if orderType == "FOK" {
-		return 0, fmt.Errorf("no match")
+		return decimal.Zero, fmt.Errorf("no match")
 	}
 
 	// 返回第一个价格
 	if pos, ok := positions[0].(OrderSummary); ok {
-		price, _ := strconv.ParseFloat(pos.GetPrice(), 64)
-		return price, nil
+		price, _, err := parseLevel(pos)
+		return price, err
 	}
 
-	return 0, fmt.Errorf("invalid position format")
+	return decimal.Zero, fmt.Errorf("invalid position format")
+}
+
+// parseLevel 解析订单簿价位的价格和数量
+func parseLevel(pos OrderSummary) (decimal.Decimal, decimal.Decimal, error) {
+	price, err := decimal.Parse(pos.GetPrice())
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("invalid book level price: %w", err)
+	}
+	size, err := decimal.Parse(pos.GetSize())
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("invalid book level size: %w", err)
+	}
+	return price, size, nil
 }
 
 // BuildSignedOrder 构建已签名订单（导出方法，供主包使用）
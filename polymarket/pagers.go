@@ -0,0 +1,112 @@
+package polymarket
+
+import (
+	"context"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+	"github.com/wimgithub/Polymarket-golang/polymarket/rfq"
+)
+
+// Pager 分页迭代器，All 逐条迭代，Pages 逐页迭代，Cursor 返回可保存的恢复位置
+type Pager[T any] = pagination.Pager[T]
+
+// PageOptions 分页选项：起始游标（从保存的游标恢复）和最大页数
+type PageOptions = pagination.Options
+
+// MarketsPager 返回市场列表的分页迭代器
+func (c *ClobClient) MarketsPager(opts PageOptions) *Pager[Market] {
+	return pagination.New(c.fetchMarkets, opts)
+}
+
+// fetchMarkets 获取一页市场（pagination.Fetch）
+func (c *ClobClient) fetchMarkets(ctx context.Context, cursor string) ([]Market, string, error) {
+	p, err := c.GetMarketsTypedWithContext(ctx, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	return p.Data, p.NextCursor, nil
+}
+
+// SimplifiedMarketsPager 返回简化市场列表的分页迭代器
+func (c *ClobClient) SimplifiedMarketsPager(opts PageOptions) *Pager[SimplifiedMarket] {
+	return pagination.New(func(ctx context.Context, cursor string) ([]SimplifiedMarket, string, error) {
+		p, err := c.GetSimplifiedMarketsTypedWithContext(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	}, opts)
+}
+
+// SamplingMarketsPager 返回采样市场列表的分页迭代器
+func (c *ClobClient) SamplingMarketsPager(opts PageOptions) *Pager[Market] {
+	return pagination.New(func(ctx context.Context, cursor string) ([]Market, string, error) {
+		p, err := c.GetSamplingMarketsTypedWithContext(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	}, opts)
+}
+
+// SamplingSimplifiedMarketsPager 返回采样简化市场列表的分页迭代器
+func (c *ClobClient) SamplingSimplifiedMarketsPager(opts PageOptions) *Pager[SimplifiedMarket] {
+	return pagination.New(func(ctx context.Context, cursor string) ([]SimplifiedMarket, string, error) {
+		p, err := c.GetSamplingSimplifiedMarketsTypedWithContext(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	}, opts)
+}
+
+// OrdersPager 返回订单列表的分页迭代器
+// 需要L2认证
+func (c *ClobClient) OrdersPager(params *OpenOrderParams, opts PageOptions) *Pager[OpenOrder] {
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: Orders,
+	}
+	return pagination.New(signedPageFetch[OpenOrder](c, requestArgs, func(cursor string) string {
+		return openOrdersQuery(params, cursor).Path(Orders)
+	}), opts)
+}
+
+// TradesPager 返回交易历史的分页迭代器
+// 需要L2认证
+func (c *ClobClient) TradesPager(params *TradeParams, opts PageOptions) *Pager[Trade] {
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: Trades,
+	}
+	return pagination.New(signedPageFetch[Trade](c, requestArgs, func(cursor string) string {
+		return tradeQuery(params, cursor).Path(Trades)
+	}), opts)
+}
+
+// BuilderTradesPager 返回Builder交易记录的分页迭代器
+// 需要Builder认证（目前使用L2认证）
+func (c *ClobClient) BuilderTradesPager(params *TradeParams, opts PageOptions) *Pager[BuilderTrade] {
+	requestArgs := &RequestArgs{
+		Method:      "GET",
+		RequestPath: GetBuilderTrades,
+	}
+	return pagination.New(signedPageFetch[BuilderTrade](c, requestArgs, func(cursor string) string {
+		return tradeQuery(params, cursor).Path(GetBuilderTrades)
+	}), opts)
+}
+
+// RfqRequestsPager 返回RFQ请求列表的分页迭代器（便捷方法）
+func (c *ClobClient) RfqRequestsPager(params *rfq.GetRfqRequestsParams, opts PageOptions) *Pager[rfq.RfqRequest] {
+	return c.rfq.RfqRequestsPager(params, opts)
+}
+
+// RfqRequesterQuotesPager 返回针对自己请求的报价列表的分页迭代器（便捷方法）
+func (c *ClobClient) RfqRequesterQuotesPager(params *rfq.GetRfqQuotesParams, opts PageOptions) *Pager[rfq.RfqQuote] {
+	return c.rfq.RfqRequesterQuotesPager(params, opts)
+}
+
+// RfqQuoterQuotesPager 返回自己创建的报价列表的分页迭代器（便捷方法）
+func (c *ClobClient) RfqQuoterQuotesPager(params *rfq.GetRfqQuotesParams, opts PageOptions) *Pager[rfq.RfqQuote] {
+	return c.rfq.RfqQuoterQuotesPager(params, opts)
+}
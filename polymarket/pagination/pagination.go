@@ -0,0 +1,148 @@
+// Package pagination 提供基于游标的分页迭代器
+//
+// CLOB 和 RFQ 的列表接口都以 next_cursor 分页：第一页的游标为 StartCursor（或为空），
+// 最后一页返回 EndCursor。Pager 将逐页请求封装为 Go 1.23 的 range-over-func 迭代器，
+// 支持提前 break、限制最大页数，以及从保存的游标继续
+package pagination
+
+import (
+	"context"
+	"iter"
+)
+
+const (
+	// StartCursor 第一页的游标
+	StartCursor = "MA=="
+	// EndCursor 最后一页返回的游标，表示没有更多数据
+	EndCursor = "LTE="
+)
+
+// Fetch 获取 cursor 对应的一页数据，返回该页的数据和下一页的游标
+// cursor 为空表示第一页
+type Fetch[T any] func(ctx context.Context, cursor string) (items []T, nextCursor string, err error)
+
+// Options 分页选项
+type Options struct {
+	// Cursor 起始游标，为空时从第一页开始；传入 Pager.Cursor() 保存的值可继续上次的迭代
+	Cursor string
+	// MaxPages 最多请求的页数，<= 0 表示不限制
+	MaxPages int
+}
+
+// Pager 分页迭代器，记录迭代位置，不能并发使用
+type Pager[T any] struct {
+	fetch    Fetch[T]
+	maxPages int
+
+	cursor string // 下一次请求的游标
+	pages  int    // 已请求的页数
+	done   bool   // 已到达最后一页
+}
+
+// New 创建分页迭代器
+func New[T any](fetch Fetch[T], opts Options) *Pager[T] {
+	return &Pager[T]{
+		fetch:    fetch,
+		maxPages: opts.MaxPages,
+		cursor:   opts.Cursor,
+		done:     opts.Cursor == EndCursor,
+	}
+}
+
+// All 逐条迭代所有数据，请求出错时产出该错误并结束
+//
+// 在某一页中途 break 时，Cursor() 仍指向该页，继续迭代会重新返回该页的数据；
+// 需要精确到页的位置时使用 Pages
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.hasNext() {
+			items, next, err := p.fetchPage(ctx)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			p.advance(next)
+		}
+	}
+}
+
+// Pages 逐页迭代，请求出错时产出该错误并结束
+// 每页产出前游标已前移，break 后 Cursor() 指向下一页
+func (p *Pager[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		for p.hasNext() {
+			items, next, err := p.fetchPage(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			p.advance(next)
+			if !yield(items, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Collect 获取剩余的所有数据（受 MaxPages 限制）
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	var results []T
+	for items, err := range p.Pages(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, items...)
+	}
+	return results, nil
+}
+
+// Cursor 返回继续迭代所需的游标，可保存后通过 Options.Cursor 恢复
+// 已到达最后一页时返回 EndCursor
+func (p *Pager[T]) Cursor() string {
+	if p.done {
+		return EndCursor
+	}
+	return p.cursor
+}
+
+// Done 是否已到达最后一页
+func (p *Pager[T]) Done() bool {
+	return p.done
+}
+
+// PageCount 返回已请求的页数
+func (p *Pager[T]) PageCount() int {
+	return p.pages
+}
+
+// hasNext 是否还可以请求下一页
+func (p *Pager[T]) hasNext() bool {
+	return !p.done && (p.maxPages <= 0 || p.pages < p.maxPages)
+}
+
+// fetchPage 请求当前游标对应的一页
+func (p *Pager[T]) fetchPage(ctx context.Context) ([]T, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	items, next, err := p.fetch(ctx, p.cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	p.pages++
+	return items, next, nil
+}
+
+// advance 当前页处理完毕，前移到下一页
+func (p *Pager[T]) advance(next string) {
+	p.cursor = next
+	if next == "" || next == EndCursor {
+		p.done = true
+	}
+}
@@ -0,0 +1,239 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakePages 按游标返回固定分页数据，并记录请求的游标
+type fakePages struct {
+	pages   map[string][]int  // 游标 -> 该页数据
+	next    map[string]string // 游标 -> 下一页游标
+	fail    map[string]error  // 请求该游标时返回的错误
+	cursors []string
+}
+
+// threePages 三页数据：""/StartCursor -> "p2" -> "p3" -> EndCursor
+func threePages() *fakePages {
+	return &fakePages{
+		pages: map[string][]int{"": {1, 2}, StartCursor: {1, 2}, "p2": {3, 4}, "p3": {5}},
+		next:  map[string]string{"": "p2", StartCursor: "p2", "p2": "p3", "p3": EndCursor},
+		fail:  map[string]error{},
+	}
+}
+
+func (f *fakePages) fetch(ctx context.Context, cursor string) ([]int, string, error) {
+	f.cursors = append(f.cursors, cursor)
+	if err := f.fail[cursor]; err != nil {
+		return nil, "", err
+	}
+	return f.pages[cursor], f.next[cursor], nil
+}
+
+// collectAll 用 All 迭代，最多取 limit 条（<= 0 表示不限制）
+func collectAll(t *testing.T, p *Pager[int], ctx context.Context, limit int) ([]int, error) {
+	t.Helper()
+	var items []int
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+func TestCollect(t *testing.T) {
+	f := threePages()
+	p := New(f.fetch, Options{})
+	items, err := p.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(items, want) {
+		t.Fatalf("items = %v, want %v", items, want)
+	}
+	if want := []string{"", "p2", "p3"}; !reflect.DeepEqual(f.cursors, want) {
+		t.Fatalf("cursors = %v, want %v", f.cursors, want)
+	}
+	if !p.Done() || p.Cursor() != EndCursor || p.PageCount() != 3 {
+		t.Fatalf("done=%v cursor=%q pages=%d", p.Done(), p.Cursor(), p.PageCount())
+	}
+
+	// 已结束的迭代器不再请求
+	if items, err := p.Collect(context.Background()); err != nil || len(items) != 0 || len(f.cursors) != 3 {
+		t.Fatalf("collect after done = %v, %v (%d requests)", items, err, len(f.cursors))
+	}
+}
+
+func TestAllBreakMidPage(t *testing.T) {
+	f := threePages()
+	p := New(f.fetch, Options{})
+
+	// 在第二页中途 break：游标仍指向第二页
+	items, err := collectAll(t, p, context.Background(), 3)
+	if err != nil || !reflect.DeepEqual(items, []int{1, 2, 3}) {
+		t.Fatalf("first pass = %v, %v", items, err)
+	}
+	if p.Cursor() != "p2" || p.Done() {
+		t.Fatalf("cursor after break = %q, done = %v", p.Cursor(), p.Done())
+	}
+
+	// 继续迭代会重新返回第二页的数据
+	items, err = collectAll(t, p, context.Background(), 0)
+	if err != nil || !reflect.DeepEqual(items, []int{3, 4, 5}) {
+		t.Fatalf("resumed = %v, %v", items, err)
+	}
+
+	// 通过保存的游标恢复也是同样的行为
+	f2 := threePages()
+	resumed := New(f2.fetch, Options{Cursor: "p2"})
+	items, err = collectAll(t, resumed, context.Background(), 0)
+	if err != nil || !reflect.DeepEqual(items, []int{3, 4, 5}) {
+		t.Fatalf("resumed from saved cursor = %v, %v", items, err)
+	}
+	if want := []string{"p2", "p3"}; !reflect.DeepEqual(f2.cursors, want) {
+		t.Fatalf("cursors = %v, want %v", f2.cursors, want)
+	}
+}
+
+func TestPagesBreak(t *testing.T) {
+	f := threePages()
+	p := New(f.fetch, Options{})
+	for items, err := range p.Pages(context.Background()) {
+		if err != nil || !reflect.DeepEqual(items, []int{1, 2}) {
+			t.Fatalf("first page = %v, %v", items, err)
+		}
+		break
+	}
+	// Pages 产出前已前移，break 后从下一页继续
+	if p.Cursor() != "p2" {
+		t.Fatalf("cursor after break = %q, want p2", p.Cursor())
+	}
+	items, err := p.Collect(context.Background())
+	if err != nil || !reflect.DeepEqual(items, []int{3, 4, 5}) {
+		t.Fatalf("resumed = %v, %v", items, err)
+	}
+}
+
+func TestMaxPages(t *testing.T) {
+	f := threePages()
+	p := New(f.fetch, Options{MaxPages: 2})
+	items, err := p.Collect(context.Background())
+	if err != nil || !reflect.DeepEqual(items, []int{1, 2, 3, 4}) {
+		t.Fatalf("items = %v, %v", items, err)
+	}
+	if p.Done() || p.Cursor() != "p3" || p.PageCount() != 2 {
+		t.Fatalf("done=%v cursor=%q pages=%d", p.Done(), p.Cursor(), p.PageCount())
+	}
+
+	// 达到上限后不再请求，用游标创建新的迭代器继续
+	if items, _ := p.Collect(context.Background()); len(items) != 0 || len(f.cursors) != 2 {
+		t.Fatalf("collect past MaxPages = %v (%d requests)", items, len(f.cursors))
+	}
+	rest, err := New(f.fetch, Options{Cursor: p.Cursor(), MaxPages: 2}).Collect(context.Background())
+	if err != nil || !reflect.DeepEqual(rest, []int{5}) {
+		t.Fatalf("rest = %v, %v", rest, err)
+	}
+}
+
+func TestCursorEnds(t *testing.T) {
+	tests := []struct {
+		name    string
+		cursor  string
+		next    string
+		want    []int
+		fetched int
+	}{
+		{"start at EndCursor", EndCursor, "", nil, 0},
+		{"empty next cursor ends", StartCursor, "", []int{1, 2}, 1},
+		{"EndCursor ends", StartCursor, EndCursor, []int{1, 2}, 1},
+	}
+	for _, tt := range tests {
+		f := &fakePages{
+			pages: map[string][]int{StartCursor: {1, 2}},
+			next:  map[string]string{StartCursor: tt.next},
+		}
+		p := New(f.fetch, Options{Cursor: tt.cursor})
+		items, err := p.Collect(context.Background())
+		if err != nil || !reflect.DeepEqual(items, tt.want) || len(f.cursors) != tt.fetched {
+			t.Errorf("%s: items = %v, err = %v, %d requests", tt.name, items, err, len(f.cursors))
+		}
+		if !p.Done() || p.Cursor() != EndCursor {
+			t.Errorf("%s: done=%v cursor=%q", tt.name, p.Done(), p.Cursor())
+		}
+	}
+}
+
+func TestErrorEndsIteration(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	f := threePages()
+	f.fail["p2"] = errBoom
+	p := New(f.fetch, Options{})
+	items, err := collectAll(t, p, context.Background(), 0)
+	if !errors.Is(err, errBoom) || !reflect.DeepEqual(items, []int{1, 2}) {
+		t.Fatalf("All = %v, %v", items, err)
+	}
+	// 失败的页不计数，游标停在失败的页，可重试
+	if p.Cursor() != "p2" || p.PageCount() != 1 || p.Done() {
+		t.Fatalf("cursor=%q pages=%d done=%v", p.Cursor(), p.PageCount(), p.Done())
+	}
+	delete(f.fail, "p2")
+	items, err = p.Collect(context.Background())
+	if err != nil || !reflect.DeepEqual(items, []int{3, 4, 5}) {
+		t.Fatalf("retry = %v, %v", items, err)
+	}
+
+	f = threePages()
+	f.fail["p3"] = errBoom
+	if items, err := New(f.fetch, Options{}).Collect(context.Background()); !errors.Is(err, errBoom) || items != nil {
+		t.Fatalf("Collect = %v, %v", items, err)
+	}
+
+	f = threePages()
+	f.fail[""] = errBoom
+	n := 0
+	for _, err := range New(f.fetch, Options{}).Pages(context.Background()) {
+		n++
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("Pages error = %v", err)
+		}
+	}
+	if n != 1 {
+		t.Fatalf("Pages yielded %d times after error, want 1", n)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := threePages()
+	p := New(func(ctx context.Context, cursor string) ([]int, string, error) {
+		// 请求第二页后取消：第三页不再请求
+		if cursor == "p2" {
+			cancel()
+		}
+		return f.fetch(ctx, cursor)
+	}, Options{})
+	items, err := collectAll(t, p, ctx, 0)
+	if !errors.Is(err, context.Canceled) || !reflect.DeepEqual(items, []int{1, 2, 3, 4}) {
+		t.Fatalf("items = %v, err = %v", items, err)
+	}
+	if want := []string{"", "p2"}; !reflect.DeepEqual(f.cursors, want) {
+		t.Fatalf("cursors = %v, want %v", f.cursors, want)
+	}
+	if p.Cursor() != "p3" {
+		t.Fatalf("cursor = %q, want p3", p.Cursor())
+	}
+
+	// 已取消的 context 不发出请求
+	f = threePages()
+	if _, err := New(f.fetch, Options{}).Collect(ctx); !errors.Is(err, context.Canceled) || len(f.cursors) != 0 {
+		t.Fatalf("Collect = %v, %d requests", err, len(f.cursors))
+	}
+}
@@ -0,0 +1,205 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
+)
+
+// EndpointFamily 限流使用的端点分组
+type EndpointFamily string
+
+const (
+	FamilyBook    EndpointFamily = "book"    // /book、/books
+	FamilyPrice   EndpointFamily = "price"   // /price(s)、/midpoint(s)、/spread(s)、/last-trade(s)-price(s)
+	FamilyOrder   EndpointFamily = "order"   // POST /order、/orders
+	FamilyCancel  EndpointFamily = "cancel"  // DELETE /order、/orders，/cancel-all，/cancel-market-orders
+	FamilyTrades  EndpointFamily = "trades"  // /data/trades
+	FamilyData    EndpointFamily = "data"    // /data/orders、/data/order/ 等其他 /data/* 以及 /notifications
+	FamilyAuth    EndpointFamily = "auth"    // /auth/*
+	FamilyRFQ     EndpointFamily = "rfq"     // /rfq/*
+	FamilyMarkets EndpointFamily = "markets" // /markets、/sampling-*、/simplified-markets
+	FamilyDefault EndpointFamily = "default" // 其他端点
+)
+
+// RateLimit 单个端点分组的令牌桶参数
+type RateLimit struct {
+	Rate  float64 // 持续速率（每秒请求数），<=0 表示不限流
+	Burst int     // 桶容量（允许的突发请求数）
+}
+
+// RateLimitMode 超出限流时的行为
+type RateLimitMode int
+
+const (
+	RateLimitBlock    RateLimitMode = iota // 阻塞等待，直到有可用令牌或 ctx 结束
+	RateLimitFailFast                      // 立即返回 ErrClientRateLimited
+)
+
+// DefaultRateLimits 默认限流参数
+// 根据 Polymarket 公布的限额（10秒窗口的突发上限 + 持续速率）取近似值，
+// 官方限额调整时可通过 SetLimit 覆盖
+func DefaultRateLimits() map[EndpointFamily]RateLimit {
+	return map[EndpointFamily]RateLimit{
+		FamilyBook:    {Rate: 50, Burst: 500},
+		FamilyPrice:   {Rate: 50, Burst: 500},
+		FamilyOrder:   {Rate: 60, Burst: 3500},  // 3500/10s 突发，36000/10min 持续
+		FamilyCancel:  {Rate: 50, Burst: 3000},  // 3000/10s 突发，30000/10min 持续
+		FamilyTrades:  {Rate: 15, Burst: 150},   // 150/10s
+		FamilyData:    {Rate: 15, Burst: 150},   // 150/10s
+		FamilyAuth:    {Rate: 10, Burst: 100},   // 100/10s
+		FamilyRFQ:     {Rate: 10, Burst: 100},   // 未公布，保守取值
+		FamilyMarkets: {Rate: 25, Burst: 250},   // 250/10s
+		FamilyDefault: {Rate: 500, Burst: 5000}, // 5000/10s 通用限额
+	}
+}
+
+// RateLimiter 按端点分组的令牌桶限流器（并发安全）
+// 使用同一 API Key 的多个 ClobClient 可以共享同一个 RateLimiter
+type RateLimiter struct {
+	mu      sync.Mutex
+	mode    RateLimitMode
+	limits  map[EndpointFamily]RateLimit
+	buckets map[EndpointFamily]*tokenBucket
+}
+
+// NewRateLimiter 创建使用默认限额的限流器
+func NewRateLimiter(mode RateLimitMode) *RateLimiter {
+	return &RateLimiter{
+		mode:    mode,
+		limits:  DefaultRateLimits(),
+		buckets: make(map[EndpointFamily]*tokenBucket),
+	}
+}
+
+// SetLimit 设置某个端点分组的限额（会重置该分组的令牌桶）
+func (l *RateLimiter) SetLimit(family EndpointFamily, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits[family] = limit
+	delete(l.buckets, family)
+}
+
+// SetMode 设置超出限流时的行为
+func (l *RateLimiter) SetMode(mode RateLimitMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mode = mode
+}
+
+// Wait 为一次请求获取令牌
+// 阻塞模式下等待直到获取成功或 ctx 结束；快速失败模式下令牌不足时立即返回 ErrClientRateLimited
+func (l *RateLimiter) Wait(ctx context.Context, method, path string) error {
+	family := ClassifyEndpoint(method, path)
+
+	l.mu.Lock()
+	limit, ok := l.limits[family]
+	if !ok {
+		limit = l.limits[FamilyDefault]
+	}
+	if limit.Rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	b, ok := l.buckets[family]
+	if !ok {
+		b = newTokenBucket(limit)
+		l.buckets[family] = b
+	}
+	mode := l.mode
+
+	now := time.Now()
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+	if mode == RateLimitFailFast {
+		l.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrClientRateLimited, family)
+	}
+
+	// 预占令牌并计算需要等待的时间
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.tokens--
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		// 未发出请求，归还预占的令牌
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// tokenBucket 令牌桶
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// refill 按经过的时间补充令牌
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// ClassifyEndpoint 根据请求方法和路径确定端点分组
+func ClassifyEndpoint(method, path string) EndpointFamily {
+	path = query.StripQuery(path)
+
+	switch {
+	case path == GetOrderBook || path == GetOrderBooks:
+		return FamilyBook
+	case path == PostOrder || path == PostOrders:
+		if method == http.MethodDelete {
+			return FamilyCancel
+		}
+		return FamilyOrder
+	case path == CancelAll || path == CancelMarketOrders:
+		return FamilyCancel
+	case path == Trades:
+		return FamilyTrades
+	case strings.HasPrefix(path, "/data/") || path == GetNotifications:
+		return FamilyData
+	case strings.HasPrefix(path, "/auth/"):
+		return FamilyAuth
+	case strings.HasPrefix(path, "/rfq/"):
+		return FamilyRFQ
+	case path == MidPoint || path == MidPoints || path == Price || path == GetPrices ||
+		path == GetSpread || path == GetSpreads || path == GetLastTradePrice || path == GetLastTradesPrices:
+		return FamilyPrice
+	case strings.HasPrefix(path, "/markets") || strings.HasPrefix(path, "/sampling-") ||
+		strings.HasPrefix(path, "/simplified-markets"):
+		return FamilyMarkets
+	}
+	return FamilyDefault
+}
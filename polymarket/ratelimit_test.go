@@ -0,0 +1,133 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   EndpointFamily
+	}{
+		{http.MethodGet, "/book?token_id=1", FamilyBook},
+		{http.MethodPost, GetOrderBooks, FamilyBook},
+		{http.MethodPost, PostOrder, FamilyOrder},
+		{http.MethodPost, PostOrders, FamilyOrder},
+		{http.MethodDelete, PostOrder, FamilyCancel},
+		{http.MethodDelete, PostOrders, FamilyCancel},
+		{http.MethodDelete, CancelAll, FamilyCancel},
+		{http.MethodDelete, CancelMarketOrders, FamilyCancel},
+		{http.MethodGet, Trades + "?next_cursor=MA%3D%3D", FamilyTrades},
+		{http.MethodGet, Orders, FamilyData},
+		{http.MethodGet, "/data/order/0xabc", FamilyData},
+		{http.MethodGet, GetNotifications, FamilyData},
+		{http.MethodGet, DeriveAPIKey, FamilyAuth},
+		{http.MethodGet, "/rfq/data/requests", FamilyRFQ},
+		{http.MethodGet, MidPoint, FamilyPrice},
+		{http.MethodPost, GetPrices, FamilyPrice},
+		{http.MethodGet, GetLastTradePrice, FamilyPrice},
+		{http.MethodGet, "/markets/0xabc", FamilyMarkets},
+		{http.MethodGet, "/sampling-markets", FamilyMarkets},
+		{http.MethodGet, "/simplified-markets", FamilyMarkets},
+		{http.MethodGet, Time, FamilyDefault},
+		{http.MethodGet, "/tick-size?token_id=1", FamilyDefault},
+	}
+	for _, tt := range tests {
+		if got := ClassifyEndpoint(tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s: got %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitFailFast)
+	limiter.SetLimit(FamilyBook, RateLimit{Rate: 0.01, Burst: 2})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx, http.MethodGet, GetOrderBook); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if err := limiter.Wait(ctx, http.MethodGet, GetOrderBook); !errors.Is(err, ErrClientRateLimited) {
+		t.Fatalf("expected ErrClientRateLimited, got %v", err)
+	}
+	// 其他分组使用各自的令牌桶
+	if err := limiter.Wait(ctx, http.MethodGet, MidPoint); err != nil {
+		t.Fatal(err)
+	}
+
+	// 限流的请求不会发出
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := NewHTTPClient(server.URL)
+	client.SetRateLimiter(limiter)
+	if _, err := client.GetWithContext(ctx, GetOrderBook, nil); !errors.Is(err, ErrClientRateLimited) {
+		t.Fatalf("expected ErrClientRateLimited, got %v", err)
+	}
+	if sent.Load() != 0 {
+		t.Fatal("rate limited request was sent")
+	}
+}
+
+func TestRateLimiterBlocks(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitBlock)
+	limiter.SetLimit(FamilyOrder, RateLimit{Rate: 20, Burst: 1})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, http.MethodPost, PostOrder); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 首个请求使用突发令牌，之后每个请求等待约 50ms
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("three requests at 20/s with burst 1 took %v", elapsed)
+	}
+
+	// Rate <= 0 不限流
+	limiter.SetLimit(FamilyOrder, RateLimit{Rate: 0})
+	start = time.Now()
+	for i := 0; i < 100; i++ {
+		if err := limiter.Wait(ctx, http.MethodPost, PostOrder); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("unlimited family blocked for %v", elapsed)
+	}
+}
+
+func TestRateLimiterReturnsTokenOnCancel(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitBlock)
+	limiter.SetLimit(FamilyBook, RateLimit{Rate: 1, Burst: 1})
+	if err := limiter.Wait(context.Background(), http.MethodGet, GetOrderBook); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, http.MethodGet, GetOrderBook); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	// 被取消的请求归还预占的令牌：桶中约为 0（已补充约 10ms），而不是 -1
+	limiter.mu.Lock()
+	tokens := limiter.buckets[FamilyBook].tokens
+	limiter.mu.Unlock()
+	if tokens < -0.5 {
+		t.Fatalf("token not returned: %.3f", tokens)
+	}
+}
@@ -0,0 +1,138 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy HTTP 请求重试策略
+//
+// 幂等请求（GET，以及 /books、/midpoints 等只读 POST）在遇到连接错误、429 或 5xx 时自动重试；
+// 下单、撤单等非幂等请求默认不重试，只有设置 RetryNonIdempotent 后，
+// 且能确定请求没有到达服务器（请求未写出到连接，或服务器返回 429）时才会重试。
+type RetryPolicy struct {
+	MaxAttempts        int           // 最大尝试次数（含首次请求），<=1 表示不重试
+	InitialBackoff     time.Duration // 首次重试前的等待时间
+	MaxBackoff         time.Duration // 单次等待时间上限（同样限制 Retry-After）
+	Multiplier         float64       // 每次重试等待时间的增长倍数
+	Jitter             float64       // 随机抖动比例（0~1），避免多个客户端同时重试
+	RetryNonIdempotent bool          // 是否允许重试下单、撤单等非幂等请求
+}
+
+// DefaultRetryPolicy 默认重试策略：最多尝试3次，仅重试幂等请求
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetryPolicy 不重试的策略
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// backoff 计算第 attempt 次重试（从1开始）前的等待时间
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (rand.Float64()*2 - 1)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
+
+// wait 计算第 attempt 次重试前的等待时间：取退避时间和 Retry-After 中较大者，
+// Retry-After 不超过 MaxBackoff
+func (p *RetryPolicy) wait(attempt int, retryAfter time.Duration) time.Duration {
+	if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+		retryAfter = p.MaxBackoff
+	}
+	d := p.backoff(attempt)
+	if retryAfter > d {
+		d = retryAfter
+	}
+	return d
+}
+
+// shouldRetry 判断一次失败的尝试是否可以重试
+func (p *RetryPolicy) shouldRetry(idempotent bool, o *attemptOutcome) bool {
+	if errors.Is(o.err, context.Canceled) || errors.Is(o.err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(o.err, &apiErr) {
+		if !apiErr.Retryable {
+			return false
+		}
+		// 429 表示服务器未处理该请求，非幂等请求也可以安全重试
+		return idempotent || (p.RetryNonIdempotent && apiErr.StatusCode == http.StatusTooManyRequests)
+	}
+
+	if !o.transport {
+		// 序列化、读取响应等本地错误不重试
+		return false
+	}
+	return idempotent || (p.RetryNonIdempotent && !o.wrote)
+}
+
+// attemptOutcome 单次请求尝试的结果
+type attemptOutcome struct {
+	err        error
+	transport  bool          // 错误是否来自连接/传输层
+	wrote      bool          // 请求是否已完整写出到连接（可能已到达服务器）
+	retryAfter time.Duration // 服务器返回的 Retry-After
+}
+
+// parseRetryAfter 解析 Retry-After 响应头（秒数或 HTTP 日期）
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepContext 等待指定时间，ctx 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,238 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryClient 创建使用 policy 的 HTTP 客户端，重试等待不实际休眠，只记录等待时间
+func newRetryClient(baseURL string, policy *RetryPolicy) (*HTTPClient, *[]time.Duration) {
+	client := NewHTTPClient(baseURL)
+	client.SetRetryPolicy(policy)
+	var waits []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return client, &waits
+}
+
+func TestRetryByStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		idempotent  bool
+		nonIdem     bool
+		status      int
+		wantAttempt int32
+	}{
+		{"get 429", http.MethodGet, false, false, http.StatusTooManyRequests, 3},
+		{"get 500", http.MethodGet, false, false, http.StatusInternalServerError, 3},
+		{"get 503", http.MethodGet, false, false, http.StatusServiceUnavailable, 3},
+		{"get 400", http.MethodGet, false, false, http.StatusBadRequest, 1},
+		{"get 404", http.MethodGet, false, false, http.StatusNotFound, 1},
+		{"read-only post 502", http.MethodPost, true, false, http.StatusBadGateway, 3},
+		{"order post 503", http.MethodPost, false, false, http.StatusServiceUnavailable, 1},
+		{"order post 429", http.MethodPost, false, false, http.StatusTooManyRequests, 1},
+		{"order post 429 opted in", http.MethodPost, false, true, http.StatusTooManyRequests, 3},
+		{"order post 503 opted in", http.MethodPost, false, true, http.StatusServiceUnavailable, 1},
+		{"order delete 504 opted in", http.MethodDelete, false, true, http.StatusGatewayTimeout, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"error":"failed"}`))
+			}))
+			defer server.Close()
+
+			policy := DefaultRetryPolicy()
+			policy.RetryNonIdempotent = tt.nonIdem
+			client, _ := newRetryClient(server.URL, policy)
+			_, err := client.Call(context.Background(), tt.method, "/order", map[string]string{"a": "b"}, CallOptions{Idempotent: tt.idempotent})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("err = %v", err)
+			}
+			if got := attempts.Load(); got != tt.wantAttempt {
+				t.Fatalf("attempts = %d, want %d", got, tt.wantAttempt)
+			}
+		})
+	}
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	// 服务器读完请求后断开连接：请求已写出，可能已被处理
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		received.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.RetryNonIdempotent = true
+	client, _ := newRetryClient(server.URL, policy)
+
+	if _, err := client.Call(context.Background(), http.MethodPost, "/order", map[string]string{"a": "b"}, CallOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := received.Load(); got != 1 {
+		t.Fatalf("written order request retried: %d attempts", got)
+	}
+
+	received.Store(0)
+	if _, err := client.Call(context.Background(), http.MethodGet, "/book", nil, CallOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := received.Load(); got != 3 {
+		t.Fatalf("idempotent request attempts = %d, want 3", got)
+	}
+
+	// 连接建立前失败：请求没有写出，开启 RetryNonIdempotent 后可以重试
+	for _, optIn := range []bool{false, true} {
+		var sent atomic.Int32
+		policy := DefaultRetryPolicy()
+		policy.RetryNonIdempotent = optIn
+		client, _ := newRetryClient(server.URL, policy)
+		client.Use(func(http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+				sent.Add(1)
+				return nil, errors.New("dial failed")
+			})
+		})
+		if _, err := client.Call(context.Background(), http.MethodPost, "/order", map[string]string{"a": "b"}, CallOptions{}); err == nil {
+			t.Fatal("expected error")
+		}
+		want := int32(1)
+		if optIn {
+			want = 3
+		}
+		if got := sent.Load(); got != want {
+			t.Fatalf("RetryNonIdempotent=%v: attempts = %d, want %d", optIn, got, want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		maxBackoff time.Duration
+		want       time.Duration
+	}{
+		{"honored", "2", 5 * time.Second, 2 * time.Second},
+		{"capped at max backoff", "120", 5 * time.Second, 5 * time.Second},
+		{"shorter than backoff", "0", 5 * time.Second, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, waits := newRetryClient(server.URL, &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: 100 * time.Millisecond,
+				MaxBackoff:     tt.maxBackoff,
+				Multiplier:     2,
+			})
+			if _, err := client.Call(context.Background(), http.MethodGet, "/book", nil, CallOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if len(*waits) != 1 || (*waits)[0] != tt.want {
+				t.Fatalf("waits = %v, want [%v]", *waits, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryReSignsHeaders(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		timestamps = map[string][]string{}
+		signatures = map[string][]string{}
+		client     *ClobClient
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		timestamps[r.URL.Path] = append(timestamps[r.URL.Path], r.Header.Get(PolyTimestamp))
+		signatures[r.URL.Path] = append(signatures[r.URL.Path], r.Header.Get(PolySignature))
+		first := len(timestamps[r.URL.Path]) == 1
+		mu.Unlock()
+
+		if first {
+			// 首次尝试后时钟前移一小时，重试必须使用新的时间戳重新签名
+			client.clock.offset.Add(int64(time.Hour))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == DeriveAPIKey {
+			w.Write([]byte(`{"apiKey":"key","secret":"c2VjcmV0","passphrase":"pass"}`))
+			return
+		}
+		w.Write([]byte(`{"apiKeys":[]}`))
+	}))
+	defer server.Close()
+
+	client = newPagedClient(t, server.URL)
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2})
+	client.httpClient.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	if _, err := client.DeriveAPIKey(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAPIKeys(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{DeriveAPIKey, GetAPIKeys} {
+		ts := timestamps[path]
+		if len(ts) != 2 {
+			t.Fatalf("%s: %d attempts", path, len(ts))
+		}
+		first, _ := strconv.Atoi(ts[0])
+		second, _ := strconv.Atoi(ts[1])
+		if second-first < 3599 {
+			t.Fatalf("%s: retry reused timestamp %s -> %s", path, ts[0], ts[1])
+		}
+		if signatures[path][0] == signatures[path][1] {
+			t.Fatalf("%s: retry reused signature", path)
+		}
+	}
+
+	// L2 签名覆盖重试时的时间戳
+	second, _ := strconv.Atoi(timestamps[GetAPIKeys][1])
+	want, err := BuildHMACSignature(client.creds.APISecret, second, http.MethodGet, GetAPIKeys, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signatures[GetAPIKeys][1] != want {
+		t.Fatal("retry signature does not match its timestamp")
+	}
+}
@@ -1,7 +1,13 @@
 package rfq
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/query"
 )
 
 // HTTPClientInterface HTTP客户端接口
@@ -9,6 +15,13 @@ type HTTPClientInterface interface {
 	Get(path string, headers map[string]string) (interface{}, error)
 	Post(path string, headers map[string]string, body interface{}) (interface{}, error)
 	Delete(path string, headers map[string]string, body interface{}) (interface{}, error)
+	GetWithContext(ctx context.Context, path string, headers map[string]string) (interface{}, error)
+	PostWithContext(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error)
+	DeleteWithContext(ctx context.Context, path string, headers map[string]string, body interface{}) (interface{}, error)
+	// SignedRequestWithContext 发送需要认证的请求，每次尝试（包括重试）前调用 sign 重新生成认证头
+	SignedRequestWithContext(ctx context.Context, method, path string, body interface{}, sign func() (map[string]string, error)) (interface{}, error)
+	// SignedRequestIntoWithContext 与 SignedRequestWithContext 相同，但将JSON响应解析到 out
+	SignedRequestIntoWithContext(ctx context.Context, method, path string, body interface{}, sign func() (map[string]string, error), out interface{}) error
 }
 
 // SignedOrderData 签名订单数据（用于避免循环导入）
@@ -31,8 +44,8 @@ type SignedOrderData struct {
 // OrderCreationArgs 订单创建参数
 type OrderCreationArgs struct {
 	TokenID    string
-	Price      float64
-	Size       float64
+	Price      decimal.Decimal
+	Size       decimal.Decimal
 	Side       string
 	Expiration int
 }
@@ -43,274 +56,205 @@ type ClobClientInterface interface {
 	GetHTTPClient() HTTPClientInterface
 	GetHost() string
 	CreateLevel2HeadersInternal(method, path string, body interface{}) (map[string]string, error)
+	CreateLevel2HeadersWithContext(ctx context.Context, method, path string, body interface{}) (map[string]string, error)
 	GetAPICreds() (apiKey string)
 	CreateOrderForRFQ(args *OrderCreationArgs) (*SignedOrderData, error)
+	CreateOrderForRFQWithContext(ctx context.Context, args *OrderCreationArgs) (*SignedOrderData, error)
 }
 
 // RfqClient RFQ客户端
 type RfqClient struct {
 	parent ClobClientInterface
+	logger *slog.Logger
 }
 
 // NewRfqClient 创建新的RFQ客户端
 func NewRfqClient(parent ClobClientInterface) *RfqClient {
 	return &RfqClient{
 		parent: parent,
+		logger: slog.New(slog.DiscardHandler),
 	}
 }
 
+// SetLogger 设置日志记录器，nil 表示不输出日志
+func (r *RfqClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	r.logger = logger
+}
+
 // ensureL2Auth 确保L2认证
 func (r *RfqClient) ensureL2Auth() error {
 	return r.parent.AssertLevel2Auth()
 }
 
-// getL2Headers 获取L2认证头
-func (r *RfqClient) getL2Headers(method, endpoint string, body interface{}) (map[string]string, error) {
-	return r.parent.CreateLevel2HeadersInternal(method, endpoint, body)
+// l2Signer 返回生成L2认证头的函数，HTTP客户端在每次尝试（包括重试）前调用以刷新时间戳
+func (r *RfqClient) l2Signer(ctx context.Context, method, endpoint string, body interface{}) func() (map[string]string, error) {
+	return func() (map[string]string, error) {
+		return r.parent.CreateLevel2HeadersWithContext(ctx, method, endpoint, body)
+	}
 }
 
 // CreateRfqRequest 创建RFQ请求
 func (r *RfqClient) CreateRfqRequest(request *RfqUserRequest) (interface{}, error) {
-	if err := r.ensureL2Auth(); err != nil {
-		return nil, err
-	}
+	return r.CreateRfqRequestWithContext(context.Background(), request)
+}
 
-	headers, err := r.getL2Headers("POST", "/rfq/request", request)
-	if err != nil {
+// CreateRfqRequestWithContext 创建RFQ请求（支持context）
+func (r *RfqClient) CreateRfqRequestWithContext(ctx context.Context, request *RfqUserRequest) (interface{}, error) {
+	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Post("/rfq/request", headers, request)
+	return httpClient.SignedRequestWithContext(ctx, "POST", "/rfq/request", request, r.l2Signer(ctx, "POST", "/rfq/request", request))
 }
 
 // CancelRfqRequest 取消RFQ请求
 func (r *RfqClient) CancelRfqRequest(params *CancelRfqRequestParams) (interface{}, error) {
-	if err := r.ensureL2Auth(); err != nil {
-		return nil, err
-	}
+	return r.CancelRfqRequestWithContext(context.Background(), params)
+}
 
-	headers, err := r.getL2Headers("DELETE", "/rfq/request", params)
-	if err != nil {
+// CancelRfqRequestWithContext 取消RFQ请求（支持context）
+func (r *RfqClient) CancelRfqRequestWithContext(ctx context.Context, params *CancelRfqRequestParams) (interface{}, error) {
+	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Delete("/rfq/request", headers, params)
+	return r.parent.GetHTTPClient().SignedRequestWithContext(ctx, "DELETE", "/rfq/request", params, r.l2Signer(ctx, "DELETE", "/rfq/request", params))
 }
 
 // GetRfqRequests 获取RFQ请求列表
 func (r *RfqClient) GetRfqRequests(params *GetRfqRequestsParams) (interface{}, error) {
+	return r.GetRfqRequestsWithContext(context.Background(), params)
+}
+
+// GetRfqRequestsWithContext 获取RFQ请求列表（支持context）
+func (r *RfqClient) GetRfqRequestsWithContext(ctx context.Context, params *GetRfqRequestsParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 构建查询参数
-	path := "/rfq/data/requests"
-	if params != nil {
-		path += "?"
-		if params.TokenID != "" {
-			path += fmt.Sprintf("token_id=%s&", params.TokenID)
-		}
-		if params.Side != "" {
-			path += fmt.Sprintf("side=%s&", params.Side)
-		}
-		if params.Status != "" {
-			path += fmt.Sprintf("status=%s&", params.Status)
-		}
-		// 移除末尾的&
-		if len(path) > 0 && path[len(path)-1] == '&' {
-			path = path[:len(path)-1]
-		}
-	}
-
-	headers, err := r.getL2Headers("GET", "/rfq/data/requests", nil)
-	if err != nil {
-		return nil, err
-	}
+	path := requestsQuery(params).Path("/rfq/data/requests")
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.SignedRequestWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", "/rfq/data/requests", nil))
 }
 
 // CreateRfqQuote 创建RFQ报价
 func (r *RfqClient) CreateRfqQuote(quote *RfqUserQuote) (interface{}, error) {
-	if err := r.ensureL2Auth(); err != nil {
-		return nil, err
-	}
+	return r.CreateRfqQuoteWithContext(context.Background(), quote)
+}
 
-	headers, err := r.getL2Headers("POST", "/rfq/quote", quote)
-	if err != nil {
+// CreateRfqQuoteWithContext 创建RFQ报价（支持context）
+func (r *RfqClient) CreateRfqQuoteWithContext(ctx context.Context, quote *RfqUserQuote) (interface{}, error) {
+	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Post("/rfq/quote", headers, quote)
+	return r.parent.GetHTTPClient().SignedRequestWithContext(ctx, "POST", "/rfq/quote", quote, r.l2Signer(ctx, "POST", "/rfq/quote", quote))
 }
 
 // CancelRfqQuote 取消RFQ报价
 func (r *RfqClient) CancelRfqQuote(params *CancelRfqQuoteParams) (interface{}, error) {
-	if err := r.ensureL2Auth(); err != nil {
-		return nil, err
-	}
+	return r.CancelRfqQuoteWithContext(context.Background(), params)
+}
 
-	headers, err := r.getL2Headers("DELETE", "/rfq/quote", params)
-	if err != nil {
+// CancelRfqQuoteWithContext 取消RFQ报价（支持context）
+func (r *RfqClient) CancelRfqQuoteWithContext(ctx context.Context, params *CancelRfqQuoteParams) (interface{}, error) {
+	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
-	return r.parent.GetHTTPClient().Delete("/rfq/quote", headers, params)
+	return r.parent.GetHTTPClient().SignedRequestWithContext(ctx, "DELETE", "/rfq/quote", params, r.l2Signer(ctx, "DELETE", "/rfq/quote", params))
 }
 
 // GetRfqQuotes 获取RFQ报价列表（旧接口，建议使用 GetRfqRequesterQuotes 或 GetRfqQuoterQuotes）
 // Deprecated: 使用 GetRfqRequesterQuotes 或 GetRfqQuoterQuotes
 func (r *RfqClient) GetRfqQuotes(params *GetRfqQuotesParams) (interface{}, error) {
+	return r.GetRfqQuotesWithContext(context.Background(), params)
+}
+
+// GetRfqQuotesWithContext 获取RFQ报价列表（支持context）
+// Deprecated: 使用 GetRfqRequesterQuotesWithContext 或 GetRfqQuoterQuotesWithContext
+func (r *RfqClient) GetRfqQuotesWithContext(ctx context.Context, params *GetRfqQuotesParams) (interface{}, error) {
 	// 默认使用 requester 视角
-	return r.GetRfqRequesterQuotes(params)
+	return r.GetRfqRequesterQuotesWithContext(ctx, params)
 }
 
 // GetRfqRequesterQuotes 获取针对自己请求的报价列表（请求方视角）
 // 返回别人对你的 RFQ 请求做出的报价
 func (r *RfqClient) GetRfqRequesterQuotes(params *GetRfqQuotesParams) (interface{}, error) {
+	return r.GetRfqRequesterQuotesWithContext(context.Background(), params)
+}
+
+// GetRfqRequesterQuotesWithContext 获取针对自己请求的报价列表（支持context）
+func (r *RfqClient) GetRfqRequesterQuotesWithContext(ctx context.Context, params *GetRfqQuotesParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 构建查询参数
-	path := "/rfq/data/requester/quotes"
-	if params != nil {
-		path += "?"
-		if params.RequestID != "" {
-			path += fmt.Sprintf("request_id=%s&", params.RequestID)
-		}
-		if params.TokenID != "" {
-			path += fmt.Sprintf("token_id=%s&", params.TokenID)
-		}
-		if params.Side != "" {
-			path += fmt.Sprintf("side=%s&", params.Side)
-		}
-		if params.Status != "" {
-			path += fmt.Sprintf("status=%s&", params.Status)
-		}
-		if len(params.QuoteIDs) > 0 {
-			for _, qid := range params.QuoteIDs {
-				path += fmt.Sprintf("quoteIds=%s&", qid)
-			}
-		}
-		// 移除末尾的&
-		if len(path) > 0 && path[len(path)-1] == '&' {
-			path = path[:len(path)-1]
-		}
-	}
-
-	headers, err := r.getL2Headers("GET", "/rfq/data/requester/quotes", nil)
-	if err != nil {
-		return nil, err
-	}
+	path := quotesQuery(params).Path("/rfq/data/requester/quotes")
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.SignedRequestWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", "/rfq/data/requester/quotes", nil))
 }
 
 // GetRfqQuoterQuotes 获取自己创建的报价列表（报价方视角）
 // 返回你对别人 RFQ 请求做出的报价
 func (r *RfqClient) GetRfqQuoterQuotes(params *GetRfqQuotesParams) (interface{}, error) {
+	return r.GetRfqQuoterQuotesWithContext(context.Background(), params)
+}
+
+// GetRfqQuoterQuotesWithContext 获取自己创建的报价列表（支持context）
+func (r *RfqClient) GetRfqQuoterQuotesWithContext(ctx context.Context, params *GetRfqQuotesParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 构建查询参数
-	path := "/rfq/data/quoter/quotes"
-	if params != nil {
-		path += "?"
-		if params.RequestID != "" {
-			path += fmt.Sprintf("request_id=%s&", params.RequestID)
-		}
-		if params.TokenID != "" {
-			path += fmt.Sprintf("token_id=%s&", params.TokenID)
-		}
-		if params.Side != "" {
-			path += fmt.Sprintf("side=%s&", params.Side)
-		}
-		if params.Status != "" {
-			path += fmt.Sprintf("status=%s&", params.Status)
-		}
-		if len(params.QuoteIDs) > 0 {
-			for _, qid := range params.QuoteIDs {
-				path += fmt.Sprintf("quoteIds=%s&", qid)
-			}
-		}
-		// 移除末尾的&
-		if len(path) > 0 && path[len(path)-1] == '&' {
-			path = path[:len(path)-1]
-		}
-	}
-
-	headers, err := r.getL2Headers("GET", "/rfq/data/quoter/quotes", nil)
-	if err != nil {
-		return nil, err
-	}
+	path := quotesQuery(params).Path("/rfq/data/quoter/quotes")
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.SignedRequestWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", "/rfq/data/quoter/quotes", nil))
 }
 
 // GetRfqBestQuote 获取最佳RFQ报价
 func (r *RfqClient) GetRfqBestQuote(params *GetRfqBestQuoteParams) (interface{}, error) {
+	return r.GetRfqBestQuoteWithContext(context.Background(), params)
+}
+
+// GetRfqBestQuoteWithContext 获取最佳RFQ报价（支持context）
+func (r *RfqClient) GetRfqBestQuoteWithContext(ctx context.Context, params *GetRfqBestQuoteParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/rfq/data/best-quote?token_id=%s&side=%s&size=%.6f",
-		params.TokenID, params.Side, params.Size)
-
-	headers, err := r.getL2Headers("GET", "/rfq/data/best-quote", nil)
-	if err != nil {
-		return nil, err
-	}
+	path := bestQuoteQuery(params).Path("/rfq/data/best-quote")
 
 	httpClient := r.parent.GetHTTPClient()
-	return httpClient.Get(path, headers)
+	return httpClient.SignedRequestWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", "/rfq/data/best-quote", nil))
 }
 
 // AcceptQuote 接受报价（请求方）
 // 此方法会获取报价详情，创建签名订单，然后提交接受请求
 func (r *RfqClient) AcceptQuote(params *AcceptQuoteParams) (interface{}, error) {
+	return r.AcceptQuoteWithContext(context.Background(), params)
+}
+
+// AcceptQuoteWithContext 接受报价（支持context）
+func (r *RfqClient) AcceptQuoteWithContext(ctx context.Context, params *AcceptQuoteParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 步骤1: 获取报价详情（使用 requester 视角）
-	quotesResp, err := r.GetRfqRequesterQuotes(&GetRfqQuotesParams{
-		QuoteIDs: []string{params.QuoteID},
-	})
+	rfqQuote, err := r.findQuote(ctx, r.GetRfqRequesterQuotesTypedWithContext, params.QuoteID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get RFQ quotes: %w", err)
-	}
-
-	// 解析响应
-	respMap, ok := quotesResp.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format")
-	}
-
-	dataList, ok := respMap["data"].([]interface{})
-	if !ok || len(dataList) == 0 {
-		return nil, fmt.Errorf("RFQ quote not found")
-	}
-
-	// 找到对应的报价
-	var rfqQuote map[string]interface{}
-	for _, item := range dataList {
-		quote, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		if quote["quoteId"] == params.QuoteID {
-			rfqQuote = quote
-			break
-		}
-	}
-	if rfqQuote == nil {
-		return nil, fmt.Errorf("RFQ quote with ID %s not found", params.QuoteID)
+		return nil, err
 	}
 
 	// 步骤2: 构建订单创建参数
@@ -319,20 +263,16 @@ func (r *RfqClient) AcceptQuote(params *AcceptQuoteParams) (interface{}, error)
 		return nil, fmt.Errorf("failed to get order creation payload: %w", err)
 	}
 
-	priceStr, _ := rfqQuote["price"].(string)
-	price := 0.0
-	fmt.Sscanf(priceStr, "%f", &price)
-
 	orderArgs := &OrderCreationArgs{
 		TokenID:    orderCreationPayload.Token,
-		Price:      price,
+		Price:      rfqQuote.Price,
 		Size:       orderCreationPayload.Size,
 		Side:       orderCreationPayload.Side,
 		Expiration: params.Expiration,
 	}
 
 	// 步骤3: 创建签名订单
-	order, err := r.parent.CreateOrderForRFQ(orderArgs)
+	order, err := r.parent.CreateOrderForRFQWithContext(ctx, orderArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -357,88 +297,59 @@ func (r *RfqClient) AcceptQuote(params *AcceptQuoteParams) (interface{}, error)
 		"signature":     order.Signature,
 	}
 
-	headers, err := r.getL2Headers("POST", "/rfq/request/accept", acceptPayload)
-	if err != nil {
-		return nil, err
-	}
-
-	return r.parent.GetHTTPClient().Post("/rfq/request/accept", headers, acceptPayload)
+	r.logger.InfoContext(ctx, "rfq quote accepting",
+		"request_id", params.RequestID,
+		"quote_id", params.QuoteID,
+		"token_id", order.TokenID,
+		"side", orderCreationPayload.Side,
+	)
+	return r.parent.GetHTTPClient().SignedRequestWithContext(ctx, "POST", "/rfq/request/accept", acceptPayload, r.l2Signer(ctx, "POST", "/rfq/request/accept", acceptPayload))
 }
 
 // ApproveOrder 批准订单（报价方）
 // 此方法会获取报价详情，创建签名订单，然后提交批准请求
 func (r *RfqClient) ApproveOrder(params *ApproveOrderParams) (interface{}, error) {
+	return r.ApproveOrderWithContext(context.Background(), params)
+}
+
+// ApproveOrderWithContext 批准订单（支持context）
+func (r *RfqClient) ApproveOrderWithContext(ctx context.Context, params *ApproveOrderParams) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
 	// 步骤1: 获取报价详情（使用 quoter 视角）
-	quotesResp, err := r.GetRfqQuoterQuotes(&GetRfqQuotesParams{
-		QuoteIDs: []string{params.QuoteID},
-	})
+	rfqQuote, err := r.findQuote(ctx, r.GetRfqQuoterQuotesTypedWithContext, params.QuoteID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get RFQ quotes: %w", err)
-	}
-
-	// 解析响应
-	respMap, ok := quotesResp.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response format")
-	}
-
-	dataList, ok := respMap["data"].([]interface{})
-	if !ok || len(dataList) == 0 {
-		return nil, fmt.Errorf("RFQ quote not found")
-	}
-
-	// 找到对应的报价
-	var rfqQuote map[string]interface{}
-	for _, item := range dataList {
-		quote, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		if quote["quoteId"] == params.QuoteID {
-			rfqQuote = quote
-			break
-		}
-	}
-	if rfqQuote == nil {
-		return nil, fmt.Errorf("RFQ quote with ID %s not found", params.QuoteID)
+		return nil, err
 	}
 
 	// 步骤2: 根据报价详情创建订单
 	// 报价方使用自己报价的 side
-	side, _ := rfqQuote["side"].(string)
+	side := rfqQuote.Side
 	if side == "" {
 		side = "BUY"
 	}
 
 	// 根据 side 确定 size
-	var size float64
+	size := rfqQuote.SizeOut
 	if side == "BUY" {
-		sizeStr, _ := rfqQuote["sizeIn"].(string)
-		fmt.Sscanf(sizeStr, "%f", &size)
-	} else {
-		sizeStr, _ := rfqQuote["sizeOut"].(string)
-		fmt.Sscanf(sizeStr, "%f", &size)
+		size = rfqQuote.SizeIn
+	}
+	if size.IsZero() {
+		return nil, fmt.Errorf("missing sizeIn/sizeOut for RFQ quote %s", params.QuoteID)
 	}
-
-	tokenID, _ := rfqQuote["token"].(string)
-	priceStr, _ := rfqQuote["price"].(string)
-	price := 0.0
-	fmt.Sscanf(priceStr, "%f", &price)
 
 	orderArgs := &OrderCreationArgs{
-		TokenID:    tokenID,
-		Price:      price,
+		TokenID:    rfqQuote.Token,
+		Price:      rfqQuote.Price,
 		Size:       size,
 		Side:       side,
 		Expiration: params.Expiration,
 	}
 
 	// 步骤3: 创建签名订单
-	order, err := r.parent.CreateOrderForRFQ(orderArgs)
+	order, err := r.parent.CreateOrderForRFQWithContext(ctx, orderArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -463,45 +374,82 @@ func (r *RfqClient) ApproveOrder(params *ApproveOrderParams) (interface{}, error
 		"signature":     order.Signature,
 	}
 
-	headers, err := r.getL2Headers("POST", "/rfq/quote/approve", approvePayload)
-	if err != nil {
-		return nil, err
-	}
-
-	return r.parent.GetHTTPClient().Post("/rfq/quote/approve", headers, approvePayload)
+	r.logger.InfoContext(ctx, "rfq order approving",
+		"request_id", params.RequestID,
+		"quote_id", params.QuoteID,
+		"token_id", order.TokenID,
+		"side", side,
+	)
+	return r.parent.GetHTTPClient().SignedRequestWithContext(ctx, "POST", "/rfq/quote/approve", approvePayload, r.l2Signer(ctx, "POST", "/rfq/quote/approve", approvePayload))
 }
 
 // GetRfqConfig 获取RFQ配置
 func (r *RfqClient) GetRfqConfig() (interface{}, error) {
+	return r.GetRfqConfigWithContext(context.Background())
+}
+
+// GetRfqConfigWithContext 获取RFQ配置（支持context）
+func (r *RfqClient) GetRfqConfigWithContext(ctx context.Context) (interface{}, error) {
 	if err := r.ensureL2Auth(); err != nil {
 		return nil, err
 	}
 
-	headers, err := r.getL2Headers("GET", "/rfq/config", nil)
-	if err != nil {
-		return nil, err
+	return r.parent.GetHTTPClient().SignedRequestWithContext(ctx, "GET", "/rfq/config", nil, r.l2Signer(ctx, "GET", "/rfq/config", nil))
+}
+
+// requestsQuery RFQ请求列表查询参数
+func requestsQuery(params *GetRfqRequestsParams) *query.Builder {
+	q := query.New()
+	if params != nil {
+		q.String("token_id", params.TokenID).
+			String("side", params.Side).
+			String("status", params.Status).
+			String("next_cursor", params.NextCursor)
 	}
+	return q
+}
+
+// quotesQuery RFQ报价列表查询参数（quoteIds 以重复键的形式传递）
+func quotesQuery(params *GetRfqQuotesParams) *query.Builder {
+	q := query.New()
+	if params != nil {
+		q.String("request_id", params.RequestID).
+			String("token_id", params.TokenID).
+			String("side", params.Side).
+			String("status", params.Status).
+			String("next_cursor", params.NextCursor).
+			Repeat("quoteIds", params.QuoteIDs)
+	}
+	return q
+}
 
-	return r.parent.GetHTTPClient().Get("/rfq/config", headers)
+// bestQuoteQuery 最佳报价查询参数
+func bestQuoteQuery(params *GetRfqBestQuoteParams) *query.Builder {
+	q := query.New()
+	if params != nil {
+		q.String("token_id", params.TokenID).
+			String("side", params.Side).
+			String("size", strconv.FormatFloat(params.Size, 'f', decimal.Places, 64))
+	}
+	return q
 }
 
 // OrderCreationResult 订单创建结果
 type OrderCreationResult struct {
 	Token string
 	Side  string
-	Size  float64
+	Size  decimal.Decimal
 }
 
 // getRequestOrderCreationPayload 根据报价详情构建订单创建参数
 // 与 Python 的 _get_request_order_creation_payload 对应
-func (r *RfqClient) getRequestOrderCreationPayload(quote map[string]interface{}) (*OrderCreationResult, error) {
-	rawMatchType, _ := quote["matchType"].(string)
-	matchType := MatchType(rawMatchType)
+func (r *RfqClient) getRequestOrderCreationPayload(quote *RfqQuote) (*OrderCreationResult, error) {
+	matchType := quote.MatchType
 	if matchType == "" {
 		matchType = MatchTypeComplementary
 	}
 
-	side, _ := quote["side"].(string)
+	side := quote.Side
 	if side == "" {
 		side = "BUY"
 	}
@@ -510,8 +458,7 @@ func (r *RfqClient) getRequestOrderCreationPayload(quote map[string]interface{})
 	case MatchTypeComplementary:
 		// 对于 BUY <> SELL 和 SELL <> BUY
 		// 订单的 side 与报价的 side 相反
-		token, _ := quote["token"].(string)
-		if token == "" {
+		if quote.Token == "" {
 			return nil, fmt.Errorf("missing token for COMPLEMENTARY match")
 		}
 
@@ -522,21 +469,16 @@ func (r *RfqClient) getRequestOrderCreationPayload(quote map[string]interface{})
 			side = "BUY"
 		}
 
-		var sizeStr string
+		size := quote.SizeIn
 		if side == "BUY" {
-			sizeStr, _ = quote["sizeOut"].(string)
-		} else {
-			sizeStr, _ = quote["sizeIn"].(string)
+			size = quote.SizeOut
 		}
-		if sizeStr == "" {
+		if size.IsZero() {
 			return nil, fmt.Errorf("missing sizeIn/sizeOut for COMPLEMENTARY match")
 		}
 
-		size := 0.0
-		fmt.Sscanf(sizeStr, "%f", &size)
-
 		return &OrderCreationResult{
-			Token: token,
+			Token: quote.Token,
 			Side:  side,
 			Size:  size,
 		}, nil
@@ -544,31 +486,25 @@ func (r *RfqClient) getRequestOrderCreationPayload(quote map[string]interface{})
 	case MatchTypeMint, MatchTypeMerge:
 		// BUY <> BUY, SELL <> SELL
 		// 订单的 side 与报价的 side 相同
-		token, _ := quote["complement"].(string)
-		if token == "" {
+		if quote.Complement == "" {
 			return nil, fmt.Errorf("missing complement token for MINT/MERGE match")
 		}
 
-		var sizeStr string
+		size := quote.SizeOut
 		if side == "BUY" {
-			sizeStr, _ = quote["sizeIn"].(string)
-		} else {
-			sizeStr, _ = quote["sizeOut"].(string)
+			size = quote.SizeIn
 		}
-		if sizeStr == "" {
+		if size.IsZero() {
 			return nil, fmt.Errorf("missing sizeIn/sizeOut for MINT/MERGE match")
 		}
 
-		size := 0.0
-		fmt.Sscanf(sizeStr, "%f", &size)
-
 		return &OrderCreationResult{
-			Token: token,
+			Token: quote.Complement,
 			Side:  side,
 			Size:  size,
 		}, nil
 
 	default:
-		return nil, fmt.Errorf("invalid match type: %s", rawMatchType)
+		return nil, fmt.Errorf("invalid match type: %s", quote.MatchType)
 	}
 }
@@ -0,0 +1,29 @@
+package rfq
+
+import "testing"
+
+func TestQueryParams(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"requests nil params", requestsQuery(nil).Path("/rfq/data/requests"), "/rfq/data/requests"},
+		{"requests empty params", requestsQuery(&GetRfqRequestsParams{}).Path("/rfq/data/requests"), "/rfq/data/requests"},
+		{"requests all fields", requestsQuery(&GetRfqRequestsParams{TokenID: "123", Side: "BUY", Status: "ACTIVE", NextCursor: "MA=="}).Path("/rfq/data/requests"),
+			"/rfq/data/requests?next_cursor=MA%3D%3D&side=BUY&status=ACTIVE&token_id=123"},
+		{"quotes nil params", quotesQuery(nil).Path("/rfq/data/requester/quotes"), "/rfq/data/requester/quotes"},
+		{"quotes repeated ids", quotesQuery(&GetRfqQuotesParams{RequestID: "r-1", QuoteIDs: []string{"q1", "", "q 2"}, NextCursor: "LTE="}).Path("/rfq/data/requester/quotes"),
+			"/rfq/data/requester/quotes?next_cursor=LTE%3D&quoteIds=q1&quoteIds=q+2&request_id=r-1"},
+		{"quotes all fields", quotesQuery(&GetRfqQuotesParams{RequestID: "r-1", TokenID: "123", Side: "SELL", Status: "ACTIVE"}).Encode(),
+			"request_id=r-1&side=SELL&status=ACTIVE&token_id=123"},
+		{"best quote nil params", bestQuoteQuery(nil).Path("/rfq/data/best-quote"), "/rfq/data/best-quote"},
+		{"best quote", bestQuoteQuery(&GetRfqBestQuoteParams{TokenID: "123", Side: "BUY", Size: 12.5}).Path("/rfq/data/best-quote"),
+			"/rfq/data/best-quote?side=BUY&size=12.500000&token_id=123"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s:\n got  %s\n want %s", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package rfq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+)
+
+// GetRfqRequestsTyped 获取RFQ请求列表的一页（返回类型化结果）
+func (r *RfqClient) GetRfqRequestsTyped(params *GetRfqRequestsParams) (*RfqRequestsPage, error) {
+	return r.GetRfqRequestsTypedWithContext(context.Background(), params)
+}
+
+// GetRfqRequestsTypedWithContext 获取RFQ请求列表的一页（返回类型化结果，支持context）
+func (r *RfqClient) GetRfqRequestsTypedWithContext(ctx context.Context, params *GetRfqRequestsParams) (*RfqRequestsPage, error) {
+	if err := r.ensureL2Auth(); err != nil {
+		return nil, err
+	}
+
+	path := requestsQuery(params).Path("/rfq/data/requests")
+
+	var page RfqRequestsPage
+	if err := r.parent.GetHTTPClient().SignedRequestIntoWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", "/rfq/data/requests", nil), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetRfqRequesterQuotesTyped 获取针对自己请求的报价列表的一页（请求方视角，返回类型化结果）
+func (r *RfqClient) GetRfqRequesterQuotesTyped(params *GetRfqQuotesParams) (*RfqQuotesPage, error) {
+	return r.GetRfqRequesterQuotesTypedWithContext(context.Background(), params)
+}
+
+// GetRfqRequesterQuotesTypedWithContext 获取针对自己请求的报价列表的一页（返回类型化结果，支持context）
+func (r *RfqClient) GetRfqRequesterQuotesTypedWithContext(ctx context.Context, params *GetRfqQuotesParams) (*RfqQuotesPage, error) {
+	return r.getQuotesPage(ctx, "/rfq/data/requester/quotes", params)
+}
+
+// GetRfqQuoterQuotesTyped 获取自己创建的报价列表的一页（报价方视角，返回类型化结果）
+func (r *RfqClient) GetRfqQuoterQuotesTyped(params *GetRfqQuotesParams) (*RfqQuotesPage, error) {
+	return r.GetRfqQuoterQuotesTypedWithContext(context.Background(), params)
+}
+
+// GetRfqQuoterQuotesTypedWithContext 获取自己创建的报价列表的一页（返回类型化结果，支持context）
+func (r *RfqClient) GetRfqQuoterQuotesTypedWithContext(ctx context.Context, params *GetRfqQuotesParams) (*RfqQuotesPage, error) {
+	return r.getQuotesPage(ctx, "/rfq/data/quoter/quotes", params)
+}
+
+// GetRfqBestQuoteTyped 获取最佳RFQ报价（返回类型化结果）
+func (r *RfqClient) GetRfqBestQuoteTyped(params *GetRfqBestQuoteParams) (*RfqQuote, error) {
+	return r.GetRfqBestQuoteTypedWithContext(context.Background(), params)
+}
+
+// GetRfqBestQuoteTypedWithContext 获取最佳RFQ报价（返回类型化结果，支持context）
+func (r *RfqClient) GetRfqBestQuoteTypedWithContext(ctx context.Context, params *GetRfqBestQuoteParams) (*RfqQuote, error) {
+	if err := r.ensureL2Auth(); err != nil {
+		return nil, err
+	}
+
+	path := bestQuoteQuery(params).Path("/rfq/data/best-quote")
+
+	var quote RfqQuote
+	if err := r.parent.GetHTTPClient().SignedRequestIntoWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", "/rfq/data/best-quote", nil), &quote); err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// GetRfqConfigTyped 获取RFQ配置（返回类型化结果）
+func (r *RfqClient) GetRfqConfigTyped() (RfqConfig, error) {
+	return r.GetRfqConfigTypedWithContext(context.Background())
+}
+
+// GetRfqConfigTypedWithContext 获取RFQ配置（返回类型化结果，支持context）
+func (r *RfqClient) GetRfqConfigTypedWithContext(ctx context.Context) (RfqConfig, error) {
+	if err := r.ensureL2Auth(); err != nil {
+		return nil, err
+	}
+
+	var config RfqConfig
+	if err := r.parent.GetHTTPClient().SignedRequestIntoWithContext(ctx, "GET", "/rfq/config", nil, r.l2Signer(ctx, "GET", "/rfq/config", nil), &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// getQuotesPage 获取报价列表的一页，endpoint 决定请求方或报价方视角
+func (r *RfqClient) getQuotesPage(ctx context.Context, endpoint string, params *GetRfqQuotesParams) (*RfqQuotesPage, error) {
+	if err := r.ensureL2Auth(); err != nil {
+		return nil, err
+	}
+
+	path := quotesQuery(params).Path(endpoint)
+
+	var page RfqQuotesPage
+	if err := r.parent.GetHTTPClient().SignedRequestIntoWithContext(ctx, "GET", path, nil, r.l2Signer(ctx, "GET", endpoint, nil), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// findQuote 按报价ID获取报价详情
+func (r *RfqClient) findQuote(ctx context.Context, fetch func(context.Context, *GetRfqQuotesParams) (*RfqQuotesPage, error), quoteID string) (*RfqQuote, error) {
+	page, err := fetch(ctx, &GetRfqQuotesParams{QuoteIDs: []string{quoteID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RFQ quotes: %w", err)
+	}
+	quote, ok := page.Quote(quoteID)
+	if !ok {
+		return nil, fmt.Errorf("RFQ quote with ID %s not found", quoteID)
+	}
+	return quote, nil
+}
+
+// RfqRequestsPager 返回RFQ请求列表的分页迭代器，params.NextCursor 被忽略，起始位置由 opts.Cursor 决定
+func (r *RfqClient) RfqRequestsPager(params *GetRfqRequestsParams, opts pagination.Options) *pagination.Pager[RfqRequest] {
+	var base GetRfqRequestsParams
+	if params != nil {
+		base = *params
+	}
+	return pagination.New(func(ctx context.Context, cursor string) ([]RfqRequest, string, error) {
+		p := base
+		p.NextCursor = cursor
+		page, err := r.GetRfqRequestsTypedWithContext(ctx, &p)
+		if err != nil {
+			return nil, "", err
+		}
+		return page.Data, page.NextCursor, nil
+	}, opts)
+}
+
+// RfqRequesterQuotesPager 返回针对自己请求的报价列表的分页迭代器（请求方视角）
+func (r *RfqClient) RfqRequesterQuotesPager(params *GetRfqQuotesParams, opts pagination.Options) *pagination.Pager[RfqQuote] {
+	return r.quotesPager("/rfq/data/requester/quotes", params, opts)
+}
+
+// RfqQuoterQuotesPager 返回自己创建的报价列表的分页迭代器（报价方视角）
+func (r *RfqClient) RfqQuoterQuotesPager(params *GetRfqQuotesParams, opts pagination.Options) *pagination.Pager[RfqQuote] {
+	return r.quotesPager("/rfq/data/quoter/quotes", params, opts)
+}
+
+// quotesPager 报价列表的分页迭代器，params.NextCursor 被忽略，起始位置由 opts.Cursor 决定
+func (r *RfqClient) quotesPager(endpoint string, params *GetRfqQuotesParams, opts pagination.Options) *pagination.Pager[RfqQuote] {
+	var base GetRfqQuotesParams
+	if params != nil {
+		base = *params
+	}
+	return pagination.New(func(ctx context.Context, cursor string) ([]RfqQuote, string, error) {
+		p := base
+		p.NextCursor = cursor
+		page, err := r.getQuotesPage(ctx, endpoint, &p)
+		if err != nil {
+			return nil, "", err
+		}
+		return page.Data, page.NextCursor, nil
+	}, opts)
+}
@@ -46,6 +46,8 @@ type GetRfqRequestsParams struct {
 	TokenID string `json:"token_id,omitempty"`
 	Side    string `json:"side,omitempty"`
 	Status  string `json:"status,omitempty"`
+	// NextCursor 分页游标，为空时从第一页开始
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // GetRfqQuotesParams 获取RFQ报价参数
@@ -55,6 +57,8 @@ type GetRfqQuotesParams struct {
 	Side      string   `json:"side,omitempty"`
 	Status    string   `json:"status,omitempty"`
 	QuoteIDs  []string `json:"quote_ids,omitempty"` // 指定报价ID列表
+	// NextCursor 分页游标，为空时从第一页开始
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // GetRfqBestQuoteParams 获取最佳RFQ报价参数
@@ -75,20 +79,6 @@ const (
 	MatchTypeMerge         MatchType = "MERGE"
 )
 
-// RfqQuoteResponse RFQ报价响应
-type RfqQuoteResponse struct {
-	QuoteID   string    `json:"quoteId"`
-	RequestID string    `json:"requestId"`
-	Token     string    `json:"token"`
-	Complement string   `json:"complement,omitempty"`
-	Side      string    `json:"side"`
-	Price     string    `json:"price"`
-	SizeIn    string    `json:"sizeIn"`
-	SizeOut   string    `json:"sizeOut"`
-	MatchType MatchType `json:"matchType"`
-	Status    string    `json:"status"`
-}
-
 // COLLATERAL_TOKEN_DECIMALS USDC小数位数
 const COLLATERAL_TOKEN_DECIMALS = 6
 
@@ -0,0 +1,216 @@
+package rfq
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/timestamp"
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+)
+
+// RfqState RFQ请求/报价状态
+type RfqState string
+
+const (
+	RfqStateActive    RfqState = "ACTIVE"
+	RfqStateInactive  RfqState = "INACTIVE"
+	RfqStateMatched   RfqState = "MATCHED"
+	RfqStateCancelled RfqState = "CANCELLED"
+	RfqStateExpired   RfqState = "EXPIRED"
+)
+
+// IsActive 是否仍可报价/接受
+func (s RfqState) IsActive() bool {
+	return s == RfqStateActive
+}
+
+// UnmarshalJSON 统一为大写，并兼容 "STATE_ACTIVE"、"canceled" 等写法
+func (s *RfqState) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+			*s = ""
+			return nil
+		}
+		return err
+	}
+	raw = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(raw)), "STATE_")
+	if raw == "CANCELED" {
+		raw = string(RfqStateCancelled)
+	}
+	*s = RfqState(raw)
+	return nil
+}
+
+// RfqRequest RFQ请求（GetRfqRequests 返回）
+type RfqRequest struct {
+	RequestID    string          `json:"requestId"`
+	UserAddress  string          `json:"userAddress"`
+	ProxyAddress string          `json:"proxyAddress"`
+	Condition    string          `json:"condition"`
+	Token        string          `json:"token"`
+	Complement   string          `json:"complement"`
+	Side         string          `json:"side"` // BUY 或 SELL
+	SizeIn       decimal.Decimal `json:"sizeIn"`
+	SizeOut      decimal.Decimal `json:"sizeOut"`
+	Price        decimal.Decimal `json:"price"`
+	State        RfqState        `json:"state"`
+	Expiry       time.Time       `json:"expiry"` // 零值表示未设置
+}
+
+// UnmarshalJSON 解析时间戳，并兼容以 status 返回的状态
+func (r *RfqRequest) UnmarshalJSON(data []byte) error {
+	type alias RfqRequest
+	aux := struct {
+		*alias
+		Status RfqState `json:"status"`
+		Expiry unixTime `json:"expiry"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if r.State == "" {
+		r.State = aux.Status
+	}
+	r.Expiry = time.Time(aux.Expiry)
+	return nil
+}
+
+// RfqQuote RFQ报价（GetRfqRequesterQuotes / GetRfqQuoterQuotes / GetRfqBestQuote 返回）
+type RfqQuote struct {
+	QuoteID      string          `json:"quoteId"`
+	RequestID    string          `json:"requestId"`
+	UserAddress  string          `json:"userAddress"`
+	ProxyAddress string          `json:"proxyAddress"`
+	Condition    string          `json:"condition"`
+	Token        string          `json:"token"`
+	Complement   string          `json:"complement"`
+	Side         string          `json:"side"` // 报价方的方向，BUY 或 SELL
+	SizeIn       decimal.Decimal `json:"sizeIn"`
+	SizeOut      decimal.Decimal `json:"sizeOut"`
+	Price        decimal.Decimal `json:"price"`
+	MatchType    MatchType       `json:"matchType"`
+	State        RfqState        `json:"state"`
+	Expiry       time.Time       `json:"expiry"` // 零值表示未设置
+}
+
+// UnmarshalJSON 解析时间戳，并兼容以 status 返回的状态
+func (q *RfqQuote) UnmarshalJSON(data []byte) error {
+	type alias RfqQuote
+	aux := struct {
+		*alias
+		Status RfqState `json:"status"`
+		Expiry unixTime `json:"expiry"`
+	}{alias: (*alias)(q)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if q.State == "" {
+		q.State = aux.Status
+	}
+	q.Expiry = time.Time(aux.Expiry)
+	return nil
+}
+
+// RfqQuoteResponse RFQ报价响应
+//
+// Deprecated: 使用 RfqQuote
+type RfqQuoteResponse = RfqQuote
+
+// RfqRequestsPage RFQ请求列表的一页
+type RfqRequestsPage struct {
+	Data       []RfqRequest `json:"data"`
+	NextCursor string       `json:"next_cursor"` // 最后一页为 "LTE="
+	Limit      int          `json:"limit"`
+	Count      int          `json:"count"`
+}
+
+// HasMore 是否还有下一页
+func (p *RfqRequestsPage) HasMore() bool {
+	return p.NextCursor != "" && p.NextCursor != pagination.EndCursor
+}
+
+// RfqQuotesPage RFQ报价列表的一页
+type RfqQuotesPage struct {
+	Data       []RfqQuote `json:"data"`
+	NextCursor string     `json:"next_cursor"` // 最后一页为 "LTE="
+	Limit      int        `json:"limit"`
+	Count      int        `json:"count"`
+}
+
+// HasMore 是否还有下一页
+func (p *RfqQuotesPage) HasMore() bool {
+	return p.NextCursor != "" && p.NextCursor != pagination.EndCursor
+}
+
+// Quote 按报价ID查找
+func (p *RfqQuotesPage) Quote(quoteID string) (*RfqQuote, bool) {
+	for i := range p.Data {
+		if p.Data[i].QuoteID == quoteID {
+			return &p.Data[i], true
+		}
+	}
+	return nil, false
+}
+
+// RfqConfig RFQ配置（GetRfqConfig 返回）
+// 配置项随服务端版本变化，按键读取
+type RfqConfig map[string]json.RawMessage
+
+// String 读取字符串配置项，不存在或类型不符时返回 false
+func (c RfqConfig) String(key string) (string, bool) {
+	var s string
+	if raw, ok := c[key]; ok && json.Unmarshal(raw, &s) == nil {
+		return s, true
+	}
+	return "", false
+}
+
+// Decimal 读取数值配置项（字符串或数字），不存在或无法解析时返回 false
+func (c RfqConfig) Decimal(key string) (decimal.Decimal, bool) {
+	var d decimal.Decimal
+	if raw, ok := c[key]; ok && d.UnmarshalJSON(raw) == nil {
+		return d, true
+	}
+	return decimal.Zero, false
+}
+
+// Int 读取整数配置项（字符串或数字），不存在或无法解析时返回 false
+func (c RfqConfig) Int(key string) (int64, bool) {
+	raw, ok := c[key]
+	if !ok {
+		return 0, false
+	}
+	s := strings.Trim(string(bytes.TrimSpace(raw)), `"`)
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// Bool 读取布尔配置项，不存在或类型不符时返回 false
+func (c RfqConfig) Bool(key string) (bool, bool) {
+	var b bool
+	if raw, ok := c[key]; ok && json.Unmarshal(raw, &b) == nil {
+		return b, true
+	}
+	return false, false
+}
+
+// unixTime 兼容秒级/毫秒级 Unix 时间戳（字符串或数字）以及 RFC3339 字符串，空值为零时间
+type unixTime time.Time
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (t *unixTime) UnmarshalJSON(data []byte) error {
+	parsed, err := timestamp.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*t = unixTime(parsed)
+	return nil
+}
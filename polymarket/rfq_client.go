@@ -1,6 +1,8 @@
 package polymarket
 
 import (
+	"context"
+
 	"github.com/wimgithub/Polymarket-golang/polymarket/rfq"
 )
 
@@ -9,47 +11,147 @@ func (c *ClobClient) CreateRfqRequest(request *rfq.RfqUserRequest) (interface{},
 	return c.rfq.CreateRfqRequest(request)
 }
 
+// CreateRfqRequestWithContext 创建RFQ请求（便捷方法，支持context）
+func (c *ClobClient) CreateRfqRequestWithContext(ctx context.Context, request *rfq.RfqUserRequest) (interface{}, error) {
+	return c.rfq.CreateRfqRequestWithContext(ctx, request)
+}
+
 // CancelRfqRequest 取消RFQ请求（便捷方法）
 func (c *ClobClient) CancelRfqRequest(params *rfq.CancelRfqRequestParams) (interface{}, error) {
 	return c.rfq.CancelRfqRequest(params)
 }
 
+// CancelRfqRequestWithContext 取消RFQ请求（便捷方法，支持context）
+func (c *ClobClient) CancelRfqRequestWithContext(ctx context.Context, params *rfq.CancelRfqRequestParams) (interface{}, error) {
+	return c.rfq.CancelRfqRequestWithContext(ctx, params)
+}
+
 // GetRfqRequests 获取RFQ请求列表（便捷方法）
 func (c *ClobClient) GetRfqRequests(params *rfq.GetRfqRequestsParams) (interface{}, error) {
 	return c.rfq.GetRfqRequests(params)
 }
 
+// GetRfqRequestsWithContext 获取RFQ请求列表（便捷方法，支持context）
+func (c *ClobClient) GetRfqRequestsWithContext(ctx context.Context, params *rfq.GetRfqRequestsParams) (interface{}, error) {
+	return c.rfq.GetRfqRequestsWithContext(ctx, params)
+}
+
 // CreateRfqQuote 创建RFQ报价（便捷方法）
 func (c *ClobClient) CreateRfqQuote(quote *rfq.RfqUserQuote) (interface{}, error) {
 	return c.rfq.CreateRfqQuote(quote)
 }
 
+// CreateRfqQuoteWithContext 创建RFQ报价（便捷方法，支持context）
+func (c *ClobClient) CreateRfqQuoteWithContext(ctx context.Context, quote *rfq.RfqUserQuote) (interface{}, error) {
+	return c.rfq.CreateRfqQuoteWithContext(ctx, quote)
+}
+
 // CancelRfqQuote 取消RFQ报价（便捷方法）
 func (c *ClobClient) CancelRfqQuote(params *rfq.CancelRfqQuoteParams) (interface{}, error) {
 	return c.rfq.CancelRfqQuote(params)
 }
 
+// CancelRfqQuoteWithContext 取消RFQ报价（便捷方法，支持context）
+func (c *ClobClient) CancelRfqQuoteWithContext(ctx context.Context, params *rfq.CancelRfqQuoteParams) (interface{}, error) {
+	return c.rfq.CancelRfqQuoteWithContext(ctx, params)
+}
+
 // GetRfqQuotes 获取RFQ报价列表（便捷方法）
 func (c *ClobClient) GetRfqQuotes(params *rfq.GetRfqQuotesParams) (interface{}, error) {
 	return c.rfq.GetRfqQuotes(params)
 }
 
+// GetRfqQuotesWithContext 获取RFQ报价列表（便捷方法，支持context）
+func (c *ClobClient) GetRfqQuotesWithContext(ctx context.Context, params *rfq.GetRfqQuotesParams) (interface{}, error) {
+	return c.rfq.GetRfqQuotesWithContext(ctx, params)
+}
+
 // GetRfqBestQuote 获取最佳RFQ报价（便捷方法）
 func (c *ClobClient) GetRfqBestQuote(params *rfq.GetRfqBestQuoteParams) (interface{}, error) {
 	return c.rfq.GetRfqBestQuote(params)
 }
 
+// GetRfqBestQuoteWithContext 获取最佳RFQ报价（便捷方法，支持context）
+func (c *ClobClient) GetRfqBestQuoteWithContext(ctx context.Context, params *rfq.GetRfqBestQuoteParams) (interface{}, error) {
+	return c.rfq.GetRfqBestQuoteWithContext(ctx, params)
+}
+
 // AcceptRfqQuote 接受RFQ报价（便捷方法）
 func (c *ClobClient) AcceptRfqQuote(params *rfq.AcceptQuoteParams) (interface{}, error) {
 	return c.rfq.AcceptQuote(params)
 }
 
+// AcceptRfqQuoteWithContext 接受RFQ报价（便捷方法，支持context）
+func (c *ClobClient) AcceptRfqQuoteWithContext(ctx context.Context, params *rfq.AcceptQuoteParams) (interface{}, error) {
+	return c.rfq.AcceptQuoteWithContext(ctx, params)
+}
+
 // ApproveRfqOrder 批准RFQ订单（便捷方法）
 func (c *ClobClient) ApproveRfqOrder(params *rfq.ApproveOrderParams) (interface{}, error) {
 	return c.rfq.ApproveOrder(params)
 }
 
+// ApproveRfqOrderWithContext 批准RFQ订单（便捷方法，支持context）
+func (c *ClobClient) ApproveRfqOrderWithContext(ctx context.Context, params *rfq.ApproveOrderParams) (interface{}, error) {
+	return c.rfq.ApproveOrderWithContext(ctx, params)
+}
+
 // GetRfqConfig 获取RFQ配置（便捷方法）
 func (c *ClobClient) GetRfqConfig() (interface{}, error) {
 	return c.rfq.GetRfqConfig()
 }
+
+// GetRfqConfigWithContext 获取RFQ配置（便捷方法，支持context）
+func (c *ClobClient) GetRfqConfigWithContext(ctx context.Context) (interface{}, error) {
+	return c.rfq.GetRfqConfigWithContext(ctx)
+}
+
+// GetRfqRequestsTyped 获取RFQ请求列表的一页（便捷方法，返回类型化结果）
+func (c *ClobClient) GetRfqRequestsTyped(params *rfq.GetRfqRequestsParams) (*rfq.RfqRequestsPage, error) {
+	return c.rfq.GetRfqRequestsTyped(params)
+}
+
+// GetRfqRequestsTypedWithContext 获取RFQ请求列表的一页（便捷方法，返回类型化结果，支持context）
+func (c *ClobClient) GetRfqRequestsTypedWithContext(ctx context.Context, params *rfq.GetRfqRequestsParams) (*rfq.RfqRequestsPage, error) {
+	return c.rfq.GetRfqRequestsTypedWithContext(ctx, params)
+}
+
+// GetRfqRequesterQuotesTyped 获取针对自己请求的报价列表的一页（便捷方法，返回类型化结果）
+func (c *ClobClient) GetRfqRequesterQuotesTyped(params *rfq.GetRfqQuotesParams) (*rfq.RfqQuotesPage, error) {
+	return c.rfq.GetRfqRequesterQuotesTyped(params)
+}
+
+// GetRfqRequesterQuotesTypedWithContext 获取针对自己请求的报价列表的一页（便捷方法，返回类型化结果，支持context）
+func (c *ClobClient) GetRfqRequesterQuotesTypedWithContext(ctx context.Context, params *rfq.GetRfqQuotesParams) (*rfq.RfqQuotesPage, error) {
+	return c.rfq.GetRfqRequesterQuotesTypedWithContext(ctx, params)
+}
+
+// GetRfqQuoterQuotesTyped 获取自己创建的报价列表的一页（便捷方法，返回类型化结果）
+func (c *ClobClient) GetRfqQuoterQuotesTyped(params *rfq.GetRfqQuotesParams) (*rfq.RfqQuotesPage, error) {
+	return c.rfq.GetRfqQuoterQuotesTyped(params)
+}
+
+// GetRfqQuoterQuotesTypedWithContext 获取自己创建的报价列表的一页（便捷方法，返回类型化结果，支持context）
+func (c *ClobClient) GetRfqQuoterQuotesTypedWithContext(ctx context.Context, params *rfq.GetRfqQuotesParams) (*rfq.RfqQuotesPage, error) {
+	return c.rfq.GetRfqQuoterQuotesTypedWithContext(ctx, params)
+}
+
+// GetRfqBestQuoteTyped 获取最佳RFQ报价（便捷方法，返回类型化结果）
+func (c *ClobClient) GetRfqBestQuoteTyped(params *rfq.GetRfqBestQuoteParams) (*rfq.RfqQuote, error) {
+	return c.rfq.GetRfqBestQuoteTyped(params)
+}
+
+// GetRfqBestQuoteTypedWithContext 获取最佳RFQ报价（便捷方法，返回类型化结果，支持context）
+func (c *ClobClient) GetRfqBestQuoteTypedWithContext(ctx context.Context, params *rfq.GetRfqBestQuoteParams) (*rfq.RfqQuote, error) {
+	return c.rfq.GetRfqBestQuoteTypedWithContext(ctx, params)
+}
+
+// GetRfqConfigTyped 获取RFQ配置（便捷方法，返回类型化结果）
+func (c *ClobClient) GetRfqConfigTyped() (rfq.RfqConfig, error) {
+	return c.rfq.GetRfqConfigTyped()
+}
+
+// GetRfqConfigTypedWithContext 获取RFQ配置（便捷方法，返回类型化结果，支持context）
+func (c *ClobClient) GetRfqConfigTypedWithContext(ctx context.Context) (rfq.RfqConfig, error) {
+	return c.rfq.GetRfqConfigTypedWithContext(ctx)
+}
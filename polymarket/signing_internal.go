@@ -146,10 +146,13 @@ func BuildHMACSignature(secret string, timestamp int, method string, requestPath
 	return base64.URLEncoding.EncodeToString(digest), nil
 }
 
-// CreateLevel1Headers 创建L1认证头
+// CreateLevel1Headers 创建L1认证头（使用本地时间戳）
 func CreateLevel1Headers(signer *Signer, nonce *int) (map[string]string, error) {
-	timestamp := int(time.Now().Unix())
+	return CreateLevel1HeadersAt(signer, nonce, int(time.Now().Unix()))
+}
 
+// CreateLevel1HeadersAt 使用指定的 Unix 时间戳（秒）创建L1认证头，用于校正本地时钟偏差
+func CreateLevel1HeadersAt(signer *Signer, nonce *int, timestamp int) (map[string]string, error) {
 	n := 0
 	if nonce != nil {
 		n = *nonce
@@ -182,10 +185,13 @@ func CreateLevel1Headers(signer *Signer, nonce *int) (map[string]string, error)
 	return headers, nil
 }
 
-// CreateLevel2Headers 创建L2认证头
+// CreateLevel2Headers 创建L2认证头（使用本地时间戳）
 func CreateLevel2Headers(signer *Signer, creds *ApiCreds, requestArgs *RequestArgs) (map[string]string, error) {
-	timestamp := int(time.Now().Unix())
+	return CreateLevel2HeadersAt(signer, creds, requestArgs, int(time.Now().Unix()))
+}
 
+// CreateLevel2HeadersAt 使用指定的 Unix 时间戳（秒）创建L2认证头，用于校正本地时钟偏差
+func CreateLevel2HeadersAt(signer *Signer, creds *ApiCreds, requestArgs *RequestArgs, timestamp int) (map[string]string, error) {
 	// 优先使用预序列化的body
 	var bodyForSig interface{}
 	if requestArgs.SerializedBody != nil {
@@ -222,10 +228,13 @@ const (
 	PolyBuilderTimestamp  = "POLY_BUILDER_TIMESTAMP"
 )
 
-// CreateBuilderHeaders 创建 Builder 认证头（用于 Gasless 交易）
+// CreateBuilderHeaders 创建 Builder 认证头（用于 Gasless 交易，使用本地时间戳）
 func CreateBuilderHeaders(creds *ApiCreds, requestArgs *RequestArgs) (map[string]string, error) {
-	timestamp := int(time.Now().Unix())
+	return CreateBuilderHeadersAt(creds, requestArgs, int(time.Now().Unix()))
+}
 
+// CreateBuilderHeadersAt 使用指定的 Unix 时间戳（秒）创建 Builder 认证头，用于校正本地时钟偏差
+func CreateBuilderHeadersAt(creds *ApiCreds, requestArgs *RequestArgs, timestamp int) (map[string]string, error) {
 	// 优先使用预序列化的body
 	var bodyForSig interface{}
 	if requestArgs.SerializedBody != nil {
@@ -0,0 +1,141 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeSyncInterval 默认的服务器时间同步间隔
+const DefaultTimeSyncInterval = 5 * time.Minute
+
+// serverClock 记录本地时钟与服务器时钟的偏差
+type serverClock struct {
+	offset atomic.Int64 // 服务器时间 - 本地时间（纳秒）
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	onSkew func(skew, rtt time.Duration)
+}
+
+// Now 返回按服务器时钟校正后的当前时间（未同步时为本地时间）
+func (c *ClobClient) Now() time.Time {
+	return time.Now().Add(time.Duration(c.clock.offset.Load()))
+}
+
+// ClockSkew 返回最近一次测得的时钟偏差（服务器时间 - 本地时间），未同步时为0
+func (c *ClobClient) ClockSkew() time.Duration {
+	return time.Duration(c.clock.offset.Load())
+}
+
+// timestamp 返回用于签名的 Unix 时间戳（秒）
+func (c *ClobClient) timestamp() int {
+	return int(c.Now().Unix())
+}
+
+// SetClockSkewHandler 设置时钟偏差回调（每次同步成功后调用，可用于上报指标）
+func (c *ClobClient) SetClockSkewHandler(fn func(skew, rtt time.Duration)) {
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+	c.clock.onSkew = fn
+}
+
+// SyncServerTime 调用 GetServerTime 测量时钟偏差，之后所有 L1/L2 签名都使用校正后的时间戳
+// 服务器时间精度为秒，小于1秒的偏差会被忽略
+func (c *ClobClient) SyncServerTime(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	resp, err := c.GetServerTimeWithContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get server time: %w", err)
+	}
+	rtt := time.Since(start)
+
+	seconds, err := parseServerTime(resp)
+	if err != nil {
+		return 0, err
+	}
+
+	// 服务器时间被截断到秒，取该秒的中点；本地时间取请求往返的中点
+	serverNow := time.Unix(seconds, 0).Add(500 * time.Millisecond)
+	skew := serverNow.Sub(start.Add(rtt / 2))
+	if skew > -time.Second && skew < time.Second {
+		skew = 0
+	}
+	c.clock.offset.Store(int64(skew))
+
+	c.httpClient.Logger().InfoContext(ctx, "clock skew measured",
+		"skew", skew,
+		"rtt", rtt,
+	)
+
+	c.clock.mu.Lock()
+	onSkew := c.clock.onSkew
+	c.clock.mu.Unlock()
+	if onSkew != nil {
+		onSkew(skew, rtt)
+	}
+
+	return skew, nil
+}
+
+// StartTimeSync 立即同步一次服务器时间，然后按 interval 定期同步，直到 ctx 结束或调用 StopTimeSync
+// 返回首次同步的错误（即使首次同步失败，定期同步仍会继续）
+func (c *ClobClient) StartTimeSync(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultTimeSyncInterval
+	}
+
+	c.StopTimeSync()
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.clock.mu.Lock()
+	c.clock.cancel = cancel
+	c.clock.mu.Unlock()
+
+	_, err := c.SyncServerTime(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := c.SyncServerTime(ctx); err != nil && ctx.Err() == nil {
+					c.httpClient.Logger().WarnContext(ctx, "clock sync failed", "error", err)
+				}
+			}
+		}
+	}()
+
+	return err
+}
+
+// StopTimeSync 停止定期同步（已测得的偏差继续生效）
+func (c *ClobClient) StopTimeSync() {
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+	if c.clock.cancel != nil {
+		c.clock.cancel()
+		c.clock.cancel = nil
+	}
+}
+
+// parseServerTime 解析 /time 返回的 Unix 时间戳（秒）
+func parseServerTime(resp interface{}) (int64, error) {
+	switch v := resp.(type) {
+	case float64:
+		return int64(v), nil
+	case string:
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid server time: %q", v)
+		}
+		return seconds, nil
+	}
+	return 0, fmt.Errorf("invalid server time response: %v", resp)
+}
@@ -0,0 +1,160 @@
+package polymarket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// timeServer 返回 now + offset 作为 /time 响应的假服务器，并记录认证请求的时间戳
+type timeServer struct {
+	*httptest.Server
+	offset atomic.Int64 // 服务器时钟相对本地时钟的偏差（秒）
+	body   func(seconds int64) string
+
+	mu         sync.Mutex
+	timestamps map[string]string
+}
+
+func newTimeServer(t *testing.T) *timeServer {
+	s := &timeServer{
+		body:       func(seconds int64) string { return strconv.FormatInt(seconds, 10) },
+		timestamps: make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case Time:
+			w.Write([]byte(s.body(time.Now().Unix() + s.offset.Load())))
+		case DeriveAPIKey:
+			s.record(r)
+			w.Write([]byte(`{"apiKey":"key","secret":"c2VjcmV0","passphrase":"pass"}`))
+		default:
+			s.record(r)
+			w.Write([]byte(`{"apiKeys":[]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *timeServer) record(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timestamps[r.URL.Path] = r.Header.Get(PolyTimestamp)
+}
+
+func (s *timeServer) timestamp(t *testing.T, path string) int64 {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts, err := strconv.ParseInt(s.timestamps[path], 10, 64)
+	if err != nil {
+		t.Fatalf("%s: invalid timestamp %q", path, s.timestamps[path])
+	}
+	return ts
+}
+
+func TestSyncServerTime(t *testing.T) {
+	server := newTimeServer(t)
+	client := newPagedClient(t, server.URL)
+	ctx := context.Background()
+
+	var reported atomic.Int64
+	client.SetClockSkewHandler(func(skew, rtt time.Duration) { reported.Store(int64(skew)) })
+
+	// 服务器时钟快一小时
+	server.offset.Store(3600)
+	skew, err := client.SyncServerTime(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skew < time.Hour-time.Second || skew > time.Hour+time.Second {
+		t.Fatalf("skew = %v", skew)
+	}
+	if client.ClockSkew() != skew || time.Duration(reported.Load()) != skew {
+		t.Fatalf("skew not stored or reported: %v, %v", client.ClockSkew(), time.Duration(reported.Load()))
+	}
+
+	// L1 和 L2 签名使用校正后的时间戳
+	if _, err := client.DeriveAPIKey(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAPIKeys(); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Now().Add(skew).Unix()
+	for _, path := range []string{DeriveAPIKey, GetAPIKeys} {
+		if ts := server.timestamp(t, path); ts < want-2 || ts > want+2 {
+			t.Fatalf("%s: timestamp %d, want about %d", path, ts, want)
+		}
+	}
+
+	// 小于1秒的偏差视为0（字符串形式的响应同样支持）
+	server.offset.Store(0)
+	server.body = func(seconds int64) string { return `"` + strconv.FormatInt(seconds, 10) + `"` }
+	skew, err = client.SyncServerTime(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skew != 0 || client.ClockSkew() != 0 {
+		t.Fatalf("sub-second skew not zeroed: %v", skew)
+	}
+	if _, err := client.GetAPIKeys(); err != nil {
+		t.Fatal(err)
+	}
+	if ts, now := server.timestamp(t, GetAPIKeys), time.Now().Unix(); ts < now-1 || ts > now {
+		t.Fatalf("timestamp %d, want about %d", ts, now)
+	}
+
+	// 无效响应返回错误，保留之前的偏差
+	server.body = func(int64) string { return `{"time":"soon"}` }
+	if _, err := client.SyncServerTime(ctx); err == nil {
+		t.Fatal("expected error for invalid response")
+	}
+}
+
+func TestStartTimeSync(t *testing.T) {
+	server := newTimeServer(t)
+	client := newPagedClient(t, server.URL)
+
+	skews := make(chan time.Duration, 16)
+	client.SetClockSkewHandler(func(skew, rtt time.Duration) {
+		select {
+		case skews <- skew:
+		default:
+		}
+	})
+
+	server.offset.Store(-120)
+	if err := client.StartTimeSync(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	defer client.StopTimeSync()
+	if skew := <-skews; skew > -119*time.Second || skew < -121*time.Second {
+		t.Fatalf("initial skew = %v", skew)
+	}
+
+	// 定期同步跟随服务器时钟的变化
+	server.offset.Store(60)
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case skew := <-skews:
+			if skew > 59*time.Second {
+				client.StopTimeSync()
+				if got := client.ClockSkew(); got < 59*time.Second || got > 61*time.Second {
+					t.Fatalf("ClockSkew = %v", got)
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("periodic sync did not pick up the new skew")
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// TokenInfo 代币（市场中的一个结果）的元数据，包含下单所需的全部市场参数
+type TokenInfo struct {
+	TokenID      string
+	ConditionID  string
+	Outcome      string // 如 "Yes" / "No"
+	ComplementID string // 同一市场中另一个结果的 token ID（非二元市场为空）
+	Question     string
+	MarketSlug   string
+	TickSize     TickSize // 无法识别时为空
+	NegRisk      bool
+	MinOrderSize decimal.Decimal
+	TakerBaseFee int // 市场的 taker_base_fee（基点），下单使用的手续费率来自 GetFeeRateBps
+}
+
+// OrderOptions 返回可直接用于 CreateOrder 的选项（tick size 和 neg risk）
+func (t *TokenInfo) OrderOptions() *PartialCreateOrderOptions {
+	negRisk := t.NegRisk
+	opts := &PartialCreateOrderOptions{NegRisk: &negRisk}
+	if t.TickSize != "" {
+		tickSize := t.TickSize
+		opts.TickSize = &tickSize
+	}
+	return opts
+}
+
+// SetMarketCatalog 设置解析代币时优先查询的本地市场目录，nil 表示不使用
+func (c *ClobClient) SetMarketCatalog(catalog *MarketCatalog) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.catalog = catalog
+}
+
+// ResolveToken 解析代币的元数据（带缓存）
+//
+// 依次查询缓存、SetMarketCatalog 设置的市场目录，最后请求服务器（订单簿获取所属市场，
+// 再获取市场详情）。同一市场的所有代币会一起缓存，并填充 GetTickSize / GetNegRisk
+// 的缓存，之后对这些代币调用 CreateOrder 无需再请求 tick size 和 neg risk
+func (c *ClobClient) ResolveToken(ctx context.Context, tokenID string) (*TokenInfo, error) {
+	if info, ok := c.cachedTokenInfo(tokenID); ok {
+		return info, nil
+	}
+
+	c.mu.RLock()
+	catalog := c.catalog
+	c.mu.RUnlock()
+	if catalog != nil {
+		if market, ok := catalog.ByToken(tokenID); ok {
+			c.cacheMarket(&market)
+			if info, ok := c.cachedTokenInfo(tokenID); ok {
+				return info, nil
+			}
+		}
+	}
+
+	book, err := c.GetOrderBookWithContext(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve market for token %s: %w", tokenID, err)
+	}
+	if book.Market == "" {
+		return nil, fmt.Errorf("failed to resolve market for token %s: empty market in order book", tokenID)
+	}
+	if _, err := c.loadMarket(ctx, book.Market); err != nil {
+		return nil, err
+	}
+
+	info, ok := c.cachedTokenInfo(tokenID)
+	if !ok {
+		return nil, fmt.Errorf("token %s not found in market %s", tokenID, book.Market)
+	}
+	return info, nil
+}
+
+// ResolveOutcome 按 condition ID 和结果名称（不区分大小写，如 "Yes"）解析代币的元数据（带缓存）
+// 市场中没有该结果时返回 ErrOutcomeNotFound
+func (c *ClobClient) ResolveOutcome(ctx context.Context, conditionID, outcome string) (*TokenInfo, error) {
+	if info, ok := c.cachedOutcome(conditionID, outcome); ok {
+		return info, nil
+	}
+
+	c.mu.RLock()
+	catalog := c.catalog
+	c.mu.RUnlock()
+
+	cached := false
+	if catalog != nil {
+		if market, ok := catalog.Market(conditionID); ok {
+			c.cacheMarket(&market)
+			cached = true
+		}
+	}
+	if !cached {
+		if _, err := c.loadMarket(ctx, conditionID); err != nil {
+			return nil, err
+		}
+	}
+
+	if info, ok := c.cachedOutcome(conditionID, outcome); ok {
+		return info, nil
+	}
+	return nil, fmt.Errorf("%w: %q in market %s", ErrOutcomeNotFound, outcome, conditionID)
+}
+
+// loadMarket 获取市场详情并缓存其所有代币
+func (c *ClobClient) loadMarket(ctx context.Context, conditionID string) (*Market, error) {
+	market, err := c.GetMarketTypedWithContext(ctx, conditionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", conditionID, err)
+	}
+	c.cacheMarket(market)
+	return market, nil
+}
+
+// cacheMarket 缓存市场中所有代币的元数据，并填充 tick size / neg risk 缓存
+// 手续费率不从市场数据填充：taker_base_fee 与 /fee-rate 返回的费率不是同一来源
+func (c *ClobClient) cacheMarket(m *Market) {
+	tickSize := m.TickSize()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tokenIDs := make([]string, 0, len(m.Tokens))
+	for i, t := range m.Tokens {
+		if t.TokenID == "" {
+			continue
+		}
+		info := TokenInfo{
+			TokenID:      t.TokenID,
+			ConditionID:  m.ConditionID,
+			Outcome:      t.Outcome,
+			Question:     m.Question,
+			MarketSlug:   m.MarketSlug,
+			TickSize:     tickSize,
+			NegRisk:      m.NegRisk,
+			MinOrderSize: m.MinimumOrderSize,
+			TakerBaseFee: m.TakerBaseFee,
+		}
+		if len(m.Tokens) == 2 {
+			info.ComplementID = m.Tokens[1-i].TokenID
+		}
+		c.tokenInfo[t.TokenID] = info
+		tokenIDs = append(tokenIDs, t.TokenID)
+
+		if tickSize != "" {
+			c.tickSizes[t.TokenID] = tickSize
+		}
+		c.negRisk[t.TokenID] = m.NegRisk
+	}
+	c.conditionTokens[m.ConditionID] = tokenIDs
+}
+
+// cachedTokenInfo 从缓存读取代币元数据
+func (c *ClobClient) cachedTokenInfo(tokenID string) (*TokenInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	info, ok := c.tokenInfo[tokenID]
+	if !ok {
+		return nil, false
+	}
+	return &info, true
+}
+
+// cachedOutcome 从缓存按结果名称读取代币元数据
+func (c *ClobClient) cachedOutcome(conditionID, outcome string) (*TokenInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, tokenID := range c.conditionTokens[conditionID] {
+		if info := c.tokenInfo[tokenID]; strings.EqualFold(info.Outcome, outcome) {
+			return &info, true
+		}
+	}
+	return nil, false
+}
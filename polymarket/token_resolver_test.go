@@ -0,0 +1,137 @@
+package polymarket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// marketServer 提供订单簿、市场详情和市场参数接口，并统计各路径的请求次数
+type marketServer struct {
+	*httptest.Server
+
+	noMarket bool // 订单簿不返回所属市场
+
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func newMarketServer(t *testing.T) *marketServer {
+	s := &marketServer{hits: map[string]int{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if strings.HasPrefix(path, GetMarket) {
+			path = GetMarket
+		}
+		s.mu.Lock()
+		s.hits[path]++
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch path {
+		case GetOrderBook:
+			market := "0xcond"
+			if s.noMarket {
+				market = ""
+			}
+			fmt.Fprintf(w, `{"market":%q,"asset_id":%q,"bids":[],"asks":[],"tick_size":"0.01"}`, market, r.URL.Query().Get("token_id"))
+		case GetMarket:
+			w.Write([]byte(`{"condition_id":"0xcond","question":"Q?","minimum_tick_size":"0.001","minimum_order_size":"5",
+				"neg_risk":true,"taker_base_fee":1000,
+				"tokens":[{"token_id":"111","outcome":"Yes","price":"0.5"},{"token_id":"222","outcome":"No","price":"0.5"}]}`))
+		case GetFeeRate:
+			w.Write([]byte(`{"base_fee":0}`))
+		case GetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":"0.01"}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *marketServer) count(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+func TestCreateOrderResolvesMarketOnCacheMiss(t *testing.T) {
+	server := newMarketServer(t)
+	client := newPagedClient(t, server.URL)
+
+	order, err := client.CreateOrder(&OrderArgs{TokenID: "111", Price: 0.505, Size: 10, Side: BUY}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tick size 和 neg risk 来自市场详情，手续费率来自 /fee-rate 而不是 taker_base_fee
+	if server.count(GetTickSize) != 0 || server.count(GetNegRisk) != 0 {
+		t.Fatalf("fetched /tick-size %d times, /neg-risk %d times", server.count(GetTickSize), server.count(GetNegRisk))
+	}
+	if server.count(GetOrderBook) != 1 || server.count(GetMarket) != 1 || server.count(GetFeeRate) != 1 {
+		t.Fatalf("hits = %v", server.hits)
+	}
+	if order.FeeRateBps.Int64() != 0 {
+		t.Fatalf("fee rate = %s, want 0 from /fee-rate", order.FeeRateBps)
+	}
+	if !client.negRisk["222"] || client.tickSizes["222"] != TickSize0001 {
+		t.Fatal("complement token not cached")
+	}
+	if _, ok := client.feeRates["222"]; ok {
+		t.Fatal("fee rate cache seeded from market data")
+	}
+
+	// 同一市场的另一个代币不再请求市场参数
+	if _, err := client.CreateOrder(&OrderArgs{TokenID: "222", Price: 0.5, Size: 10, Side: SELL}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if server.count(GetOrderBook) != 1 || server.count(GetMarket) != 1 || server.count(GetFeeRate) != 2 {
+		t.Fatalf("hits after second order = %v", server.hits)
+	}
+
+	info, err := client.ResolveToken(t.Context(), "222")
+	if err != nil || info.ComplementID != "111" || info.TakerBaseFee != 1000 || info.MinOrderSize.String() != "5" {
+		t.Fatalf("ResolveToken = %+v, %v", info, err)
+	}
+}
+
+func TestCreateOrderFallsBackWhenResolveFails(t *testing.T) {
+	server := newMarketServer(t)
+	server.noMarket = true
+	client := newPagedClient(t, server.URL)
+
+	// 订单簿不包含所属市场时回退到 /tick-size 和 /neg-risk
+	order, err := client.CreateOrder(&OrderArgs{TokenID: "111", Price: 0.5, Size: 10, Side: BUY}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.count(GetOrderBook) != 1 || server.count(GetTickSize) != 1 || server.count(GetNegRisk) != 1 || server.count(GetMarket) != 0 {
+		t.Fatalf("hits = %v", server.hits)
+	}
+	if order.FeeRateBps.Int64() != 0 || client.tickSizes["111"] != TickSize001 || client.negRisk["111"] {
+		t.Fatalf("unexpected market parameters: fee %s, tick size %q", order.FeeRateBps, client.tickSizes["111"])
+	}
+}
+
+func TestCreateOrderRemembersFailedResolve(t *testing.T) {
+	server := newMarketServer(t)
+	server.noMarket = true
+	client := newPagedClient(t, server.URL)
+
+	// 调用方提供 neg risk 时不会缓存 neg risk，失败记录避免下一笔订单再次解析
+	negRisk := false
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateOrder(&OrderArgs{TokenID: "111", Price: 0.5, Size: 10, Side: BUY}, &PartialCreateOrderOptions{NegRisk: &negRisk}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if server.count(GetOrderBook) != 1 || server.count(GetTickSize) != 1 || server.count(GetNegRisk) != 0 {
+		t.Fatalf("hits = %v", server.hits)
+	}
+}
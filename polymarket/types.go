@@ -1,7 +1,10 @@
 package polymarket
 
 import (
+	"fmt"
+
 	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
 )
 
 // ApiCreds API凭证
@@ -54,6 +57,74 @@ type MarketOrderArgs struct {
 	OrderType   OrderType `json:"order_type"`   // 订单类型
 }
 
+// DecimalOrderArgs 限价订单参数（定点小数）
+type DecimalOrderArgs struct {
+	TokenID    string          `json:"token_id"`     // 条件代币资产ID
+	Price      decimal.Decimal `json:"price"`        // 订单价格
+	Size       decimal.Decimal `json:"size"`         // 条件代币数量
+	Side       string          `json:"side"`         // BUY 或 SELL
+	FeeRateBps int             `json:"fee_rate_bps"` // 手续费率（基点）
+	Nonce      int             `json:"nonce"`        // 用于链上取消的nonce
+	Expiration int             `json:"expiration"`   // 订单过期时间戳
+	Taker      string          `json:"taker"`        // 订单接受者地址，零地址表示公开订单
+}
+
+// Decimal 转换为定点小数参数，价格和数量按 6 位小数四舍五入，超出 Decimal 范围时返回错误
+func (a *OrderArgs) Decimal() (*DecimalOrderArgs, error) {
+	price, err := decimal.FromFloat(a.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+	size, err := decimal.FromFloat(a.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size: %w", err)
+	}
+	return &DecimalOrderArgs{
+		TokenID:    a.TokenID,
+		Price:      price,
+		Size:       size,
+		Side:       a.Side,
+		FeeRateBps: a.FeeRateBps,
+		Nonce:      a.Nonce,
+		Expiration: a.Expiration,
+		Taker:      a.Taker,
+	}, nil
+}
+
+// DecimalMarketOrderArgs 市价订单参数（定点小数）
+type DecimalMarketOrderArgs struct {
+	TokenID    string          `json:"token_id"`     // 条件代币资产ID
+	Amount     decimal.Decimal `json:"amount"`       // BUY: 美元金额, SELL: 份额数量
+	Side       string          `json:"side"`         // BUY 或 SELL
+	Price      decimal.Decimal `json:"price"`        // 订单价格（可选，为 0 时根据订单簿计算）
+	FeeRateBps int             `json:"fee_rate_bps"` // 手续费率（基点）
+	Nonce      int             `json:"nonce"`        // 用于链上取消的nonce
+	Taker      string          `json:"taker"`        // 订单接受者地址
+	OrderType  OrderType       `json:"order_type"`   // 订单类型
+}
+
+// Decimal 转换为定点小数参数，金额和价格按 6 位小数四舍五入，超出 Decimal 范围时返回错误
+func (a *MarketOrderArgs) Decimal() (*DecimalMarketOrderArgs, error) {
+	amount, err := decimal.FromFloat(a.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	price, err := decimal.FromFloat(a.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+	return &DecimalMarketOrderArgs{
+		TokenID:    a.TokenID,
+		Amount:     amount,
+		Side:       a.Side,
+		Price:      price,
+		FeeRateBps: a.FeeRateBps,
+		Nonce:      a.Nonce,
+		Taker:      a.Taker,
+		OrderType:  a.OrderType,
+	}, nil
+}
+
 // TradeParams 交易查询参数
 type TradeParams struct {
 	ID           string `json:"id,omitempty"`
@@ -82,17 +153,28 @@ type OrderSummary struct {
 	Size  string `json:"size"`
 }
 
+// PriceDecimal 解析价格
+func (s OrderSummary) PriceDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(s.Price)
+}
+
+// SizeDecimal 解析数量
+func (s OrderSummary) SizeDecimal() (decimal.Decimal, error) {
+	return decimal.Parse(s.Size)
+}
+
 // OrderBookSummary 订单簿摘要
 type OrderBookSummary struct {
-	Market        string         `json:"market"`
-	AssetID       string         `json:"asset_id"`
-	Timestamp     string         `json:"timestamp"`
-	Bids          []OrderSummary `json:"bids"`
-	Asks          []OrderSummary `json:"asks"`
-	MinOrderSize  string         `json:"min_order_size"`
-	NegRisk       bool           `json:"neg_risk"`
-	TickSize      string         `json:"tick_size"`
-	Hash          string         `json:"hash"`
+	Market         string         `json:"market"`
+	AssetID        string         `json:"asset_id"`
+	Timestamp      string         `json:"timestamp"`
+	Bids           []OrderSummary `json:"bids"`
+	Asks           []OrderSummary `json:"asks"`
+	MinOrderSize   string         `json:"min_order_size"`
+	NegRisk        bool           `json:"neg_risk"`
+	TickSize       string         `json:"tick_size"`
+	Hash           string         `json:"hash"`
+	LastTradePrice string         `json:"last_trade_price,omitempty"`
 }
 
 // AssetType 资产类型
@@ -0,0 +1,236 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// MarketToken 市场中的结果代币
+type MarketToken struct {
+	TokenID string          `json:"token_id"`
+	Outcome string          `json:"outcome"` // 如 "Yes" / "No"
+	Price   decimal.Decimal `json:"price"`
+	Winner  bool            `json:"winner"` // 市场结算后获胜的结果为 true
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (t *MarketToken) UnmarshalJSON(data []byte) error {
+	type alias MarketToken
+	aux := struct {
+		*alias
+		Price flexDecimal `json:"price"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.Price = decimal.Decimal(aux.Price)
+	return nil
+}
+
+// RewardRate 流动性奖励的每日发放速率
+type RewardRate struct {
+	AssetAddress     string          `json:"asset_address"` // 奖励代币地址
+	RewardsDailyRate decimal.Decimal `json:"rewards_daily_rate"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (r *RewardRate) UnmarshalJSON(data []byte) error {
+	type alias RewardRate
+	aux := struct {
+		*alias
+		RewardsDailyRate flexDecimal `json:"rewards_daily_rate"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.RewardsDailyRate = decimal.Decimal(aux.RewardsDailyRate)
+	return nil
+}
+
+// MarketRewards 市场的流动性奖励配置
+type MarketRewards struct {
+	Rates     []RewardRate    `json:"rates"`
+	MinSize   decimal.Decimal `json:"min_size"`   // 计入奖励的最小挂单数量
+	MaxSpread decimal.Decimal `json:"max_spread"` // 计入奖励的最大价差（单位：美分）
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (r *MarketRewards) UnmarshalJSON(data []byte) error {
+	type alias MarketRewards
+	aux := struct {
+		*alias
+		MinSize   flexDecimal `json:"min_size"`
+		MaxSpread flexDecimal `json:"max_spread"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.MinSize = decimal.Decimal(aux.MinSize)
+	r.MaxSpread = decimal.Decimal(aux.MaxSpread)
+	return nil
+}
+
+// Market 市场（GetMarket / GetMarkets / GetSamplingMarkets 返回）
+type Market struct {
+	ConditionID             string          `json:"condition_id"`
+	QuestionID              string          `json:"question_id"`
+	Question                string          `json:"question"`
+	Description             string          `json:"description"`
+	MarketSlug              string          `json:"market_slug"`
+	Tokens                  []MarketToken   `json:"tokens"`
+	Rewards                 MarketRewards   `json:"rewards"`
+	MinimumOrderSize        decimal.Decimal `json:"minimum_order_size"`
+	MinimumTickSize         decimal.Decimal `json:"minimum_tick_size"`
+	NegRisk                 bool            `json:"neg_risk"`
+	NegRiskMarketID         string          `json:"neg_risk_market_id"`
+	NegRiskRequestID        string          `json:"neg_risk_request_id"`
+	Active                  bool            `json:"active"`
+	Closed                  bool            `json:"closed"`
+	Archived                bool            `json:"archived"`
+	AcceptingOrders         bool            `json:"accepting_orders"`
+	AcceptingOrderTimestamp time.Time       `json:"accepting_order_timestamp"`
+	EnableOrderBook         bool            `json:"enable_order_book"`
+	EndDate                 time.Time       `json:"end_date_iso"`    // 零值表示未设置
+	GameStartTime           time.Time       `json:"game_start_time"` // 体育类市场的开赛时间
+	SecondsDelay            int             `json:"seconds_delay"`   // 延迟撮合秒数
+	MakerBaseFee            int             `json:"maker_base_fee"`  // 基点
+	TakerBaseFee            int             `json:"taker_base_fee"`  // 基点
+	Fpmm                    string          `json:"fpmm"`
+	Icon                    string          `json:"icon"`
+	Image                   string          `json:"image"`
+	Tags                    []string        `json:"tags"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值和时间戳
+func (m *Market) UnmarshalJSON(data []byte) error {
+	type alias Market
+	aux := struct {
+		*alias
+		MinimumOrderSize        flexDecimal `json:"minimum_order_size"`
+		MinimumTickSize         flexDecimal `json:"minimum_tick_size"`
+		AcceptingOrderTimestamp flexTime    `json:"accepting_order_timestamp"`
+		EndDate                 flexTime    `json:"end_date_iso"`
+		GameStartTime           flexTime    `json:"game_start_time"`
+		SecondsDelay            flexInt     `json:"seconds_delay"`
+		MakerBaseFee            flexInt     `json:"maker_base_fee"`
+		TakerBaseFee            flexInt     `json:"taker_base_fee"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.MinimumOrderSize = decimal.Decimal(aux.MinimumOrderSize)
+	m.MinimumTickSize = decimal.Decimal(aux.MinimumTickSize)
+	m.AcceptingOrderTimestamp = time.Time(aux.AcceptingOrderTimestamp)
+	m.EndDate = time.Time(aux.EndDate)
+	m.GameStartTime = time.Time(aux.GameStartTime)
+	m.SecondsDelay = int(aux.SecondsDelay)
+	m.MakerBaseFee = int(aux.MakerBaseFee)
+	m.TakerBaseFee = int(aux.TakerBaseFee)
+	return nil
+}
+
+// TickSize 返回市场的最小价格变动单位，无法识别时返回空字符串
+func (m *Market) TickSize() TickSize {
+	return tickSizeFromDecimal(m.MinimumTickSize)
+}
+
+// Token 按结果名称（不区分大小写）查找代币
+func (m *Market) Token(outcome string) (MarketToken, bool) {
+	return findToken(m.Tokens, outcome)
+}
+
+// Winner 返回获胜的代币，市场未结算时返回 false
+func (m *Market) Winner() (MarketToken, bool) {
+	return findWinner(m.Tokens)
+}
+
+// OrderOptions 返回可直接用于 CreateOrder 的选项（tick size 和 neg risk），
+// 无需再次请求服务器
+func (m *Market) OrderOptions() *PartialCreateOrderOptions {
+	negRisk := m.NegRisk
+	opts := &PartialCreateOrderOptions{NegRisk: &negRisk}
+	if tickSize := m.TickSize(); tickSize != "" {
+		opts.TickSize = &tickSize
+	}
+	return opts
+}
+
+// SimplifiedMarket 简化市场（GetSimplifiedMarkets / GetSamplingSimplifiedMarkets 返回）
+type SimplifiedMarket struct {
+	ConditionID     string        `json:"condition_id"`
+	Tokens          []MarketToken `json:"tokens"`
+	Rewards         MarketRewards `json:"rewards"`
+	Active          bool          `json:"active"`
+	Closed          bool          `json:"closed"`
+	Archived        bool          `json:"archived"`
+	AcceptingOrders bool          `json:"accepting_orders"`
+}
+
+// Token 按结果名称（不区分大小写）查找代币
+func (m *SimplifiedMarket) Token(outcome string) (MarketToken, bool) {
+	return findToken(m.Tokens, outcome)
+}
+
+// Winner 返回获胜的代币，市场未结算时返回 false
+func (m *SimplifiedMarket) Winner() (MarketToken, bool) {
+	return findWinner(m.Tokens)
+}
+
+// MarketsPage 市场列表的一页
+type MarketsPage struct {
+	Data       []Market `json:"data"`
+	NextCursor string   `json:"next_cursor"` // 最后一页为 EndCursor
+	Limit      int      `json:"limit"`
+	Count      int      `json:"count"`
+}
+
+// HasMore 是否还有下一页
+func (p *MarketsPage) HasMore() bool {
+	return p.NextCursor != "" && p.NextCursor != EndCursor
+}
+
+// SimplifiedMarketsPage 简化市场列表的一页
+type SimplifiedMarketsPage struct {
+	Data       []SimplifiedMarket `json:"data"`
+	NextCursor string             `json:"next_cursor"` // 最后一页为 EndCursor
+	Limit      int                `json:"limit"`
+	Count      int                `json:"count"`
+}
+
+// HasMore 是否还有下一页
+func (p *SimplifiedMarketsPage) HasMore() bool {
+	return p.NextCursor != "" && p.NextCursor != EndCursor
+}
+
+// findToken 按结果名称（不区分大小写）查找代币
+func findToken(tokens []MarketToken, outcome string) (MarketToken, bool) {
+	for _, t := range tokens {
+		if strings.EqualFold(t.Outcome, outcome) {
+			return t, true
+		}
+	}
+	return MarketToken{}, false
+}
+
+// findWinner 查找获胜的代币
+func findWinner(tokens []MarketToken) (MarketToken, bool) {
+	for _, t := range tokens {
+		if t.Winner {
+			return t, true
+		}
+	}
+	return MarketToken{}, false
+}
+
+// tickSizeFromDecimal 将数值形式的 tick size 转为 TickSize，不是已知值时返回空字符串
+func tickSizeFromDecimal(v decimal.Decimal) TickSize {
+	tickSize := TickSize(v.String())
+	switch tickSize {
+	case TickSize01, TickSize001, TickSize0001, TickSize00001:
+		return tickSize
+	}
+	return ""
+}
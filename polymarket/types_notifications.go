@@ -0,0 +1,171 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// NotificationType 通知类型
+type NotificationType int
+
+const (
+	NotificationOrderCancelled NotificationType = 1 // 订单被取消（如市场关闭、余额不足）
+	NotificationOrderFilled    NotificationType = 2 // 订单成交（包括部分成交）
+	NotificationMarketResolved NotificationType = 4 // 市场已结算
+)
+
+// String 返回通知类型名称
+func (t NotificationType) String() string {
+	switch t {
+	case NotificationOrderCancelled:
+		return "ORDER_CANCELLED"
+	case NotificationOrderFilled:
+		return "ORDER_FILLED"
+	case NotificationMarketResolved:
+		return "MARKET_RESOLVED"
+	}
+	return "UNKNOWN(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Notification 通知（GetNotifications 返回）
+// Payload 保留原始 JSON，按类型通过 OrderCancelled / OrderFilled / MarketResolved 解析
+type Notification struct {
+	ID        int64            `json:"id"`
+	Type      NotificationType `json:"type"`
+	Owner     string           `json:"owner"` // API Key
+	Timestamp time.Time        `json:"timestamp"`
+	Payload   json.RawMessage  `json:"payload"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值和时间戳
+func (n *Notification) UnmarshalJSON(data []byte) error {
+	type alias Notification
+	aux := struct {
+		*alias
+		ID        flexInt  `json:"id"`
+		Type      flexInt  `json:"type"`
+		Timestamp flexTime `json:"timestamp"`
+	}{alias: (*alias)(n)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	n.ID = int64(aux.ID)
+	n.Type = NotificationType(aux.Type)
+	n.Timestamp = time.Time(aux.Timestamp)
+	return nil
+}
+
+// OrderCancelled 解析订单取消通知的内容，类型不匹配时返回错误
+func (n *Notification) OrderCancelled() (*OrderCancelledPayload, error) {
+	var p OrderCancelledPayload
+	if err := n.decodePayload(NotificationOrderCancelled, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// OrderFilled 解析订单成交通知的内容，类型不匹配时返回错误
+func (n *Notification) OrderFilled() (*OrderFilledPayload, error) {
+	var p OrderFilledPayload
+	if err := n.decodePayload(NotificationOrderFilled, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// MarketResolved 解析市场结算通知的内容，类型不匹配时返回错误
+func (n *Notification) MarketResolved() (*MarketResolvedPayload, error) {
+	var p MarketResolvedPayload
+	if err := n.decodePayload(NotificationMarketResolved, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// decodePayload 检查类型并解析 Payload
+func (n *Notification) decodePayload(want NotificationType, out interface{}) error {
+	if n.Type != want {
+		return fmt.Errorf("notification %d is %s, not %s", n.ID, n.Type, want)
+	}
+	if len(n.Payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(n.Payload, out); err != nil {
+		return fmt.Errorf("failed to decode %s payload of notification %d: %w", n.Type, n.ID, err)
+	}
+	return nil
+}
+
+// OrderCancelledPayload 订单取消通知的内容
+type OrderCancelledPayload struct {
+	OrderID      string          `json:"order_id"`
+	AssetID      string          `json:"asset_id"`
+	Market       string          `json:"market"` // condition_id
+	Question     string          `json:"question"`
+	Outcome      string          `json:"outcome"`
+	Side         string          `json:"side"`
+	Price        decimal.Decimal `json:"price"`
+	OriginalSize decimal.Decimal `json:"original_size"`
+	SizeMatched  decimal.Decimal `json:"size_matched"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (p *OrderCancelledPayload) UnmarshalJSON(data []byte) error {
+	type alias OrderCancelledPayload
+	aux := struct {
+		*alias
+		Price        flexDecimal `json:"price"`
+		OriginalSize flexDecimal `json:"original_size"`
+		SizeMatched  flexDecimal `json:"size_matched"`
+	}{alias: (*alias)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Price = decimal.Decimal(aux.Price)
+	p.OriginalSize = decimal.Decimal(aux.OriginalSize)
+	p.SizeMatched = decimal.Decimal(aux.SizeMatched)
+	return nil
+}
+
+// OrderFilledPayload 订单成交通知的内容
+type OrderFilledPayload struct {
+	OrderID         string          `json:"order_id"`
+	TradeID         string          `json:"trade_id"`
+	AssetID         string          `json:"asset_id"`
+	Market          string          `json:"market"` // condition_id
+	Question        string          `json:"question"`
+	Outcome         string          `json:"outcome"`
+	Side            string          `json:"side"`
+	Price           decimal.Decimal `json:"price"`
+	MatchedSize     decimal.Decimal `json:"matched_size"`
+	Status          TradeStatus     `json:"status"`
+	TransactionHash string          `json:"transaction_hash"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (p *OrderFilledPayload) UnmarshalJSON(data []byte) error {
+	type alias OrderFilledPayload
+	aux := struct {
+		*alias
+		Price       flexDecimal `json:"price"`
+		MatchedSize flexDecimal `json:"matched_size"`
+	}{alias: (*alias)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Price = decimal.Decimal(aux.Price)
+	p.MatchedSize = decimal.Decimal(aux.MatchedSize)
+	return nil
+}
+
+// MarketResolvedPayload 市场结算通知的内容
+type MarketResolvedPayload struct {
+	Market         string `json:"market"` // condition_id
+	Question       string `json:"question"`
+	WinningOutcome string `json:"winning_outcome"`
+	WinningAssetID string `json:"winning_asset_id"`
+}
@@ -0,0 +1,77 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// TokenPrice 单个 token 的买卖价格（GetPrices 返回）
+// 未请求的方向为 0
+type TokenPrice struct {
+	Buy  decimal.Decimal `json:"BUY"`  // 买入价（最优卖单价格）
+	Sell decimal.Decimal `json:"SELL"` // 卖出价（最优买单价格）
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (p *TokenPrice) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Buy  flexDecimal `json:"BUY"`
+		Sell flexDecimal `json:"SELL"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Buy = decimal.Decimal(aux.Buy)
+	p.Sell = decimal.Decimal(aux.Sell)
+	return nil
+}
+
+// Side 返回指定方向的价格
+func (p TokenPrice) Side(side string) decimal.Decimal {
+	if side == SELL {
+		return p.Sell
+	}
+	return p.Buy
+}
+
+// LastTrade 最后成交（GetLastTradePrice / GetLastTradesPrices 返回）
+type LastTrade struct {
+	TokenID string          `json:"token_id"` // 单个查询时由请求参数填充
+	Price   decimal.Decimal `json:"price"`
+	Side    string          `json:"side"` // BUY 或 SELL
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (t *LastTrade) UnmarshalJSON(data []byte) error {
+	type alias LastTrade
+	aux := struct {
+		*alias
+		Price flexDecimal `json:"price"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.Price = decimal.Decimal(aux.Price)
+	return nil
+}
+
+// singleValue 解析单值响应（如 {"mid": "0.55"}），字段缺失时返回错误
+func singleValue(resp map[string]*flexDecimal, key, tokenID string) (decimal.Decimal, error) {
+	v, ok := resp[key]
+	if !ok || v == nil {
+		return decimal.Zero, fmt.Errorf("%w: %s (no %q field)", ErrTokenNotInResponse, tokenID, key)
+	}
+	return decimal.Decimal(*v), nil
+}
+
+// requireTokens 检查批量响应是否包含所有请求的 token
+func requireTokens[V any](resp map[string]V, params []BookParams) error {
+	for _, p := range params {
+		if _, ok := resp[p.TokenID]; !ok {
+			return fmt.Errorf("%w: %s", ErrTokenNotInResponse, p.TokenID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// OrderStatus 订单状态
+type OrderStatus string
+
+const (
+	OrderStatusLive      OrderStatus = "LIVE"      // 挂单中
+	OrderStatusMatched   OrderStatus = "MATCHED"   // 已完全成交
+	OrderStatusCanceled  OrderStatus = "CANCELED"  // 已取消
+	OrderStatusDelayed   OrderStatus = "DELAYED"   // 延迟撮合中
+	OrderStatusUnmatched OrderStatus = "UNMATCHED" // 未成交（延迟撮合后未成交）
+)
+
+// TradeStatus 成交状态
+type TradeStatus string
+
+const (
+	TradeStatusMatched   TradeStatus = "MATCHED"   // 已撮合，等待上链
+	TradeStatusMined     TradeStatus = "MINED"     // 已上链，等待最终确认
+	TradeStatusConfirmed TradeStatus = "CONFIRMED" // 已最终确认
+	TradeStatusRetrying  TradeStatus = "RETRYING"  // 上链失败，正在重试
+	TradeStatusFailed    TradeStatus = "FAILED"    // 最终失败
+)
+
+// IsFinal 是否为终态（CONFIRMED 或 FAILED）
+func (s TradeStatus) IsFinal() bool {
+	return s == TradeStatusConfirmed || s == TradeStatusFailed
+}
+
+// OpenOrder 订单（GetOrders / GetOrder 返回）
+type OpenOrder struct {
+	ID              string          `json:"id"`
+	Status          OrderStatus     `json:"status"`
+	Owner           string          `json:"owner"`         // API Key
+	MakerAddress    string          `json:"maker_address"` // 资金持有者地址
+	Market          string          `json:"market"`        // condition_id
+	AssetID         string          `json:"asset_id"`      // token_id
+	Side            string          `json:"side"`          // BUY 或 SELL
+	OriginalSize    decimal.Decimal `json:"original_size"`
+	SizeMatched     decimal.Decimal `json:"size_matched"`
+	Price           decimal.Decimal `json:"price"`
+	Outcome         string          `json:"outcome"`
+	OrderType       OrderType       `json:"order_type"`
+	AssociateTrades []string        `json:"associate_trades"`
+	Expiration      time.Time       `json:"expiration"` // 零值表示不过期
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// RemainingSize 剩余未成交数量
+func (o *OpenOrder) RemainingSize() decimal.Decimal {
+	return o.OriginalSize.Sub(o.SizeMatched)
+}
+
+// UnmarshalJSON 解析字符串形式的数值和时间戳
+func (o *OpenOrder) UnmarshalJSON(data []byte) error {
+	type alias OpenOrder
+	aux := struct {
+		*alias
+		OriginalSize flexDecimal `json:"original_size"`
+		SizeMatched  flexDecimal `json:"size_matched"`
+		Price        flexDecimal `json:"price"`
+		Expiration   flexTime    `json:"expiration"`
+		CreatedAt    flexTime    `json:"created_at"`
+	}{alias: (*alias)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.OriginalSize = decimal.Decimal(aux.OriginalSize)
+	o.SizeMatched = decimal.Decimal(aux.SizeMatched)
+	o.Price = decimal.Decimal(aux.Price)
+	o.Expiration = time.Time(aux.Expiration)
+	o.CreatedAt = time.Time(aux.CreatedAt)
+	return nil
+}
+
+// MakerOrder 成交中的 maker 订单
+type MakerOrder struct {
+	OrderID       string          `json:"order_id"`
+	Owner         string          `json:"owner"`
+	MakerAddress  string          `json:"maker_address"`
+	MatchedAmount decimal.Decimal `json:"matched_amount"`
+	Price         decimal.Decimal `json:"price"`
+	FeeRateBps    int             `json:"fee_rate_bps"`
+	AssetID       string          `json:"asset_id"`
+	Outcome       string          `json:"outcome"`
+	Side          string          `json:"side"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值
+func (m *MakerOrder) UnmarshalJSON(data []byte) error {
+	type alias MakerOrder
+	aux := struct {
+		*alias
+		MatchedAmount flexDecimal `json:"matched_amount"`
+		Price         flexDecimal `json:"price"`
+		FeeRateBps    flexInt     `json:"fee_rate_bps"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.MatchedAmount = decimal.Decimal(aux.MatchedAmount)
+	m.Price = decimal.Decimal(aux.Price)
+	m.FeeRateBps = int(aux.FeeRateBps)
+	return nil
+}
+
+// Trade 成交记录（GetTrades 返回）
+type Trade struct {
+	ID              string          `json:"id"`
+	TakerOrderID    string          `json:"taker_order_id"`
+	Market          string          `json:"market"`
+	AssetID         string          `json:"asset_id"`
+	Side            string          `json:"side"`
+	Size            decimal.Decimal `json:"size"`
+	Price           decimal.Decimal `json:"price"`
+	FeeRateBps      int             `json:"fee_rate_bps"`
+	Status          TradeStatus     `json:"status"`
+	MatchTime       time.Time       `json:"match_time"`
+	LastUpdate      time.Time       `json:"last_update"`
+	Outcome         string          `json:"outcome"`
+	BucketIndex     int             `json:"bucket_index"`
+	Owner           string          `json:"owner"`
+	MakerAddress    string          `json:"maker_address"`
+	MakerOrders     []MakerOrder    `json:"maker_orders"`
+	TransactionHash string          `json:"transaction_hash"`
+	TraderSide      string          `json:"trader_side"` // TAKER 或 MAKER
+}
+
+// UnmarshalJSON 解析字符串形式的数值和时间戳
+func (t *Trade) UnmarshalJSON(data []byte) error {
+	type alias Trade
+	aux := struct {
+		*alias
+		Size        flexDecimal `json:"size"`
+		Price       flexDecimal `json:"price"`
+		FeeRateBps  flexInt     `json:"fee_rate_bps"`
+		MatchTime   flexTime    `json:"match_time"`
+		LastUpdate  flexTime    `json:"last_update"`
+		BucketIndex flexInt     `json:"bucket_index"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.Size = decimal.Decimal(aux.Size)
+	t.Price = decimal.Decimal(aux.Price)
+	t.FeeRateBps = int(aux.FeeRateBps)
+	t.MatchTime = time.Time(aux.MatchTime)
+	t.LastUpdate = time.Time(aux.LastUpdate)
+	t.BucketIndex = int(aux.BucketIndex)
+	return nil
+}
+
+// BuilderTrade Builder 成交记录（GetBuilderTrades 返回，字段为驼峰命名）
+type BuilderTrade struct {
+	ID              string          `json:"id"`
+	TradeType       string          `json:"tradeType"`
+	TakerOrderHash  string          `json:"takerOrderHash"`
+	Builder         string          `json:"builder"`
+	Market          string          `json:"market"`
+	AssetID         string          `json:"assetId"`
+	Side            string          `json:"side"`
+	Size            decimal.Decimal `json:"size"`
+	SizeUsdc        decimal.Decimal `json:"sizeUsdc"`
+	Price           decimal.Decimal `json:"price"`
+	Status          TradeStatus     `json:"status"`
+	Outcome         string          `json:"outcome"`
+	OutcomeIndex    int             `json:"outcomeIndex"`
+	Owner           string          `json:"owner"`
+	Maker           string          `json:"maker"`
+	TransactionHash string          `json:"transactionHash"`
+	MatchTime       time.Time       `json:"matchTime"`
+	BucketIndex     int             `json:"bucketIndex"`
+	Fee             decimal.Decimal `json:"fee"`
+	FeeUsdc         decimal.Decimal `json:"feeUsdc"`
+	ErrMsg          string          `json:"err_msg"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+// UnmarshalJSON 解析字符串形式的数值和时间戳
+func (t *BuilderTrade) UnmarshalJSON(data []byte) error {
+	type alias BuilderTrade
+	aux := struct {
+		*alias
+		Size         flexDecimal `json:"size"`
+		SizeUsdc     flexDecimal `json:"sizeUsdc"`
+		Price        flexDecimal `json:"price"`
+		OutcomeIndex flexInt     `json:"outcomeIndex"`
+		MatchTime    flexTime    `json:"matchTime"`
+		BucketIndex  flexInt     `json:"bucketIndex"`
+		Fee          flexDecimal `json:"fee"`
+		FeeUsdc      flexDecimal `json:"feeUsdc"`
+		CreatedAt    flexTime    `json:"createdAt"`
+		UpdatedAt    flexTime    `json:"updatedAt"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.Size = decimal.Decimal(aux.Size)
+	t.SizeUsdc = decimal.Decimal(aux.SizeUsdc)
+	t.Price = decimal.Decimal(aux.Price)
+	t.OutcomeIndex = int(aux.OutcomeIndex)
+	t.MatchTime = time.Time(aux.MatchTime)
+	t.BucketIndex = int(aux.BucketIndex)
+	t.Fee = decimal.Decimal(aux.Fee)
+	t.FeeUsdc = decimal.Decimal(aux.FeeUsdc)
+	t.CreatedAt = time.Time(aux.CreatedAt)
+	t.UpdatedAt = time.Time(aux.UpdatedAt)
+	return nil
+}
+
+// page 分页响应
+type page[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor"`
+}
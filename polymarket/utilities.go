@@ -1,6 +1,7 @@
 package polymarket
 
 import (
+	"bytes"
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/hex"
@@ -10,17 +11,19 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
 )
 
 // ParseRawOrderBookSummary 解析原始订单簿摘要
+// 数值字段保留服务器返回的字符串；若为 JSON 数字则格式化为不带指数的十进制形式
 func ParseRawOrderBookSummary(rawObs map[string]interface{}) (*OrderBookSummary, error) {
 	bids := []OrderSummary{}
 	if bidsRaw, ok := rawObs["bids"].([]interface{}); ok {
 		for _, bidRaw := range bidsRaw {
 			if bid, ok := bidRaw.(map[string]interface{}); ok {
 				bids = append(bids, OrderSummary{
-					Price: fmt.Sprintf("%v", bid["price"]),
-					Size:  fmt.Sprintf("%v", bid["size"]),
+					Price: getNumberString(bid, "price"),
+					Size:  getNumberString(bid, "size"),
 				})
 			}
 		}
@@ -31,48 +34,93 @@ func ParseRawOrderBookSummary(rawObs map[string]interface{}) (*OrderBookSummary,
 		for _, askRaw := range asksRaw {
 			if ask, ok := askRaw.(map[string]interface{}); ok {
 				asks = append(asks, OrderSummary{
-					Price: fmt.Sprintf("%v", ask["price"]),
-					Size:  fmt.Sprintf("%v", ask["size"]),
+					Price: getNumberString(ask, "price"),
+					Size:  getNumberString(ask, "size"),
 				})
 			}
 		}
 	}
 
 	obs := &OrderBookSummary{
-		Market:       getString(rawObs, "market"),
-		AssetID:      getString(rawObs, "asset_id"),
-		Timestamp:    getString(rawObs, "timestamp"),
-		MinOrderSize: getString(rawObs, "min_order_size"),
-		NegRisk:      getBool(rawObs, "neg_risk"),
-		TickSize:     getString(rawObs, "tick_size"),
-		Bids:         bids,
-		Asks:         asks,
-		Hash:         getString(rawObs, "hash"),
+		Market:         getString(rawObs, "market"),
+		AssetID:        getString(rawObs, "asset_id"),
+		Timestamp:      getNumberString(rawObs, "timestamp"),
+		MinOrderSize:   getNumberString(rawObs, "min_order_size"),
+		NegRisk:        getBool(rawObs, "neg_risk"),
+		TickSize:       getNumberString(rawObs, "tick_size"),
+		LastTradePrice: getNumberString(rawObs, "last_trade_price"),
+		Bids:           bids,
+		Asks:           asks,
+		Hash:           getString(rawObs, "hash"),
 	}
 
 	return obs, nil
 }
 
-// GenerateOrderBookSummaryHash 生成订单簿摘要哈希
+// GenerateOrderBookSummaryHash 生成订单簿摘要哈希（不修改输入）
+//
+// 按 /book 响应的字段顺序序列化（hash 字段置空），数值保持原字符串，然后计算 SHA1。
+// 该序列化方式是对服务器算法的推断，尚未用真实 /book 响应及其 hash 验证，
+// 结果可能与服务器给出的 hash 不同
 func GenerateOrderBookSummaryHash(orderbook *OrderBookSummary) string {
-	// 临时清空hash
-	originalHash := orderbook.Hash
-	orderbook.Hash = ""
+	hash := sha1.Sum(canonicalOrderBookJSON(orderbook))
+	return hex.EncodeToString(hash[:])
+}
 
-	// 序列化为JSON
-	jsonData, err := json.Marshal(orderbook)
-	if err != nil {
-		orderbook.Hash = originalHash
-		return ""
+// canonicalOrderBookJSON 按服务器的规范形式序列化订单簿摘要（hash 为空字符串）
+//
+//	{"market":...,"asset_id":...,"timestamp":...,"hash":"","bids":[{"price":...,"size":...}],
+//	 "asks":[...],"min_order_size":...,"tick_size":...,"neg_risk":...,"last_trade_price":...}
+//
+// 字符串不做 HTML 转义，last_trade_price 仅在非空时出现
+func canonicalOrderBookJSON(orderbook *OrderBookSummary) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"market":`)
+	writeJSONString(&buf, orderbook.Market)
+	buf.WriteString(`,"asset_id":`)
+	writeJSONString(&buf, orderbook.AssetID)
+	buf.WriteString(`,"timestamp":`)
+	writeJSONString(&buf, orderbook.Timestamp)
+	buf.WriteString(`,"hash":"","bids":`)
+	writeOrderSummaries(&buf, orderbook.Bids)
+	buf.WriteString(`,"asks":`)
+	writeOrderSummaries(&buf, orderbook.Asks)
+	buf.WriteString(`,"min_order_size":`)
+	writeJSONString(&buf, orderbook.MinOrderSize)
+	buf.WriteString(`,"tick_size":`)
+	writeJSONString(&buf, orderbook.TickSize)
+	buf.WriteString(`,"neg_risk":`)
+	buf.WriteString(strconv.FormatBool(orderbook.NegRisk))
+	if orderbook.LastTradePrice != "" {
+		buf.WriteString(`,"last_trade_price":`)
+		writeJSONString(&buf, orderbook.LastTradePrice)
 	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
 
-	// SHA1哈希
-	hash := sha1.Sum(jsonData)
-	hashStr := fmt.Sprintf("%x", hash)
+// writeOrderSummaries 序列化价位列表
+func writeOrderSummaries(buf *bytes.Buffer, summaries []OrderSummary) {
+	buf.WriteByte('[')
+	for i, s := range summaries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"price":`)
+		writeJSONString(buf, s.Price)
+		buf.WriteString(`,"size":`)
+		writeJSONString(buf, s.Size)
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+}
 
-	// 恢复hash
-	orderbook.Hash = hashStr
-	return hashStr
+// writeJSONString 序列化字符串（不做 HTML 转义）
+func writeJSONString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	buf.Truncate(buf.Len() - 1) // Encode 会追加换行
 }
 
 // OrderToJSON 将订单转换为JSON格式
@@ -141,15 +189,24 @@ func OrderToJSONWithPostOnly(order *SignedOrder, owner string, orderType OrderTy
 
 // IsTickSizeSmaller 检查tick size是否更小
 func IsTickSizeSmaller(a, b TickSize) bool {
-	aFloat, _ := strconv.ParseFloat(string(a), 64)
-	bFloat, _ := strconv.ParseFloat(string(b), 64)
-	return aFloat < bFloat
+	aDec, _ := a.Decimal()
+	bDec, _ := b.Decimal()
+	return aDec.Cmp(bDec) < 0
 }
 
 // PriceValid 检查价格是否有效
 func PriceValid(price float64, tickSize TickSize) bool {
-	tickSizeFloat, _ := strconv.ParseFloat(string(tickSize), 64)
-	return price >= tickSizeFloat && price <= 1.0-tickSizeFloat
+	d, err := decimal.FromFloat(price)
+	return err == nil && PriceValidDecimal(d, tickSize)
+}
+
+// PriceValidDecimal 检查价格是否在 [tickSize, 1 - tickSize] 范围内
+func PriceValidDecimal(price decimal.Decimal, tickSize TickSize) bool {
+	tick, err := tickSize.Decimal()
+	if err != nil {
+		return false
+	}
+	return price.Cmp(tick) >= 0 && price.Cmp(decimal.One.Sub(tick)) <= 0
 }
 
 // 辅助函数
@@ -160,6 +217,22 @@ func getString(m map[string]interface{}, key string) string {
 	return ""
 }
 
+// getNumberString 读取数值字段：字符串原样返回，JSON 数字格式化为不带指数的十进制形式，缺失或 null 为空字符串
+func getNumberString(m map[string]interface{}, key string) string {
+	switch v := m[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 func getBool(m map[string]interface{}, key string) bool {
 	if v, ok := m[key]; ok {
 		if b, ok := v.(bool); ok {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"log/slog"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
@@ -76,6 +77,8 @@ type BaseWeb3Client struct {
 	NegRiskAdapterAddress    common.Address
 	ProxyFactoryAddress      common.Address
 	SafeProxyFactoryAddress  common.Address
+
+	logger *slog.Logger
 }
 
 // NewBaseWeb3Client 创建基础 Web3 客户端
@@ -140,6 +143,8 @@ func NewBaseWeb3Client(
 		NegRiskAdapterAddress:    NegRiskAdapterAddress,
 		ProxyFactoryAddress:      ProxyFactoryAddress,
 		SafeProxyFactoryAddress:  SafeProxyFactoryAddress,
+
+		logger: slog.New(slog.DiscardHandler),
 	}
 
 	// 设置地址（根据签名类型）
@@ -513,3 +518,30 @@ func stripHexPrefix(s string) string {
 	return s
 }
 
+// SetLogger 设置日志记录器（交易提交、上链、中继状态等事件），nil 表示不输出日志
+func (c *BaseWeb3Client) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	c.logger = logger
+}
+
+// Logger 获取日志记录器
+func (c *BaseWeb3Client) Logger() *slog.Logger {
+	return c.logger
+}
+
+// logTxMined 记录交易上链结果
+func (c *BaseWeb3Client) logTxMined(operationName string, receipt *TransactionReceipt) {
+	attrs := []any{
+		"operation", operationName,
+		"tx_hash", receipt.TxHash.Hex(),
+		"block", receipt.BlockNumber,
+		"gas_used", receipt.GasUsed,
+	}
+	if receipt.Status == 1 {
+		c.logger.Info("tx mined", append(attrs, "status", "succeeded")...)
+	} else {
+		c.logger.Warn("tx mined", append(attrs, "status", "failed")...)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,6 +28,7 @@ type PolymarketGaslessWeb3Client struct {
 	relayConfig  RelayConfig
 	builderCreds *polymarket.ApiCreds
 	httpClient   *http.Client
+	now          func() time.Time
 }
 
 // NewPolymarketGaslessWeb3Client 创建新的PolymarketGaslessWeb3Client
@@ -57,9 +59,24 @@ func NewPolymarketGaslessWeb3Client(
 		relayConfig:    DefaultRelayConfig,
 		builderCreds:   builderCreds,
 		httpClient:     &http.Client{},
+		now:            time.Now,
 	}, nil
 }
 
+// Use 在中继请求（nonce、签名服务、提交交易）的传输层追加中间件，
+// 可与 ClobClient.Use 传入相同的中间件，使 CLOB 与中继流量共享同一套日志/指标逻辑
+func (c *PolymarketGaslessWeb3Client) Use(middlewares ...polymarket.Middleware) {
+	c.httpClient = polymarket.WrapHTTPClient(c.httpClient, middlewares...)
+}
+
+// SetTimeSource 设置 Builder 签名使用的时间来源，可传入 ClobClient.Now 以使用校正后的服务器时间；nil 表示使用本地时间
+func (c *PolymarketGaslessWeb3Client) SetTimeSource(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	c.now = now
+}
+
 // Execute 通过无gas中继执行交易
 func (c *PolymarketGaslessWeb3Client) Execute(to common.Address, data []byte, operationName string, metadata string) (*TransactionReceipt, error) {
 	var body *RelaySubmitRequest
@@ -91,9 +108,12 @@ func (c *PolymarketGaslessWeb3Client) Execute(to common.Address, data []byte, op
 		return nil, err
 	}
 
-	fmt.Printf("Gasless txn submitted: %s\n", resp.TransactionHash)
-	fmt.Printf("Transaction ID: %s\n", resp.TransactionID)
-	fmt.Printf("State: %s\n", resp.State)
+	c.logger.Info("relay tx submitted",
+		"operation", operationName,
+		"tx_hash", resp.TransactionHash,
+		"transaction_id", resp.TransactionID,
+		"state", resp.State,
+	)
 
 	// 等待确认
 	if resp.TransactionHash != "" {
@@ -102,11 +122,7 @@ func (c *PolymarketGaslessWeb3Client) Execute(to common.Address, data []byte, op
 			return nil, err
 		}
 
-		if receipt.Status == 1 {
-			fmt.Printf("%s succeeded\n", operationName)
-		} else {
-			fmt.Printf("%s failed\n", operationName)
-		}
+		c.logTxMined(operationName, receipt)
 
 		return receipt, nil
 	}
@@ -126,7 +142,7 @@ func (c *PolymarketGaslessWeb3Client) getRelayNonce(walletType string) (int, err
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to get nonce: %s", string(body))
+		return 0, fmt.Errorf("failed to get nonce: %w", polymarket.NewAPIError("GET", url, resp, body))
 	}
 
 	var result struct {
@@ -157,7 +173,7 @@ func (c *PolymarketGaslessWeb3Client) getRelayPayload(walletType string) (relayA
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", 0, fmt.Errorf("failed to get relay payload: %s", string(body))
+		return "", 0, fmt.Errorf("failed to get relay payload: %w", polymarket.NewAPIError("GET", url, resp, body))
 	}
 
 	var result struct {
@@ -382,7 +398,7 @@ func (c *PolymarketGaslessWeb3Client) getRelayHeaders(body *RelaySubmitRequest)
 
 		if resp.StatusCode != http.StatusOK {
 			respBody, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("sign server error: %s", string(respBody))
+			return nil, fmt.Errorf("sign server error: %w", polymarket.NewAPIError("POST", c.relayConfig.SignURL, resp, respBody))
 		}
 
 		var headers map[string]string
@@ -400,7 +416,7 @@ func (c *PolymarketGaslessWeb3Client) getRelayHeaders(body *RelaySubmitRequest)
 		Body:        body,
 	}
 
-	headers, err := polymarket.CreateBuilderHeaders(c.builderCreds, requestArgs)
+	headers, err := polymarket.CreateBuilderHeadersAt(c.builderCreds, requestArgs, int(c.now().Unix()))
 	if err != nil {
 		return nil, err
 	}
@@ -434,7 +450,7 @@ func (c *PolymarketGaslessWeb3Client) submitToRelay(body *RelaySubmitRequest, he
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("relay error: %s", string(respBody))
+		return nil, fmt.Errorf("relay error: %w", polymarket.NewAPIError("POST", url, resp, respBody))
 	}
 
 	var result RelayResponse
@@ -614,9 +630,12 @@ func (c *PolymarketGaslessWeb3Client) ExecuteBatch(calls []ProxyCall, operationN
 		return nil, err
 	}
 
-	fmt.Printf("Gasless batch txn submitted: %s\n", resp.TransactionHash)
-	fmt.Printf("Transaction ID: %s\n", resp.TransactionID)
-	fmt.Printf("State: %s\n", resp.State)
+	c.logger.Info("relay tx submitted",
+		"operation", operationName,
+		"tx_hash", resp.TransactionHash,
+		"transaction_id", resp.TransactionID,
+		"state", resp.State,
+	)
 
 	// 等待确认
 	if resp.TransactionHash != "" {
@@ -625,11 +644,7 @@ func (c *PolymarketGaslessWeb3Client) ExecuteBatch(calls []ProxyCall, operationN
 			return nil, err
 		}
 
-		if receipt.Status == 1 {
-			fmt.Printf("%s succeeded\n", operationName)
-		} else {
-			fmt.Printf("%s failed\n", operationName)
-		}
+		c.logTxMined(operationName, receipt)
 
 		return receipt, nil
 	}
@@ -4,10 +4,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
 )
 
 // GetMarketIndex 从question_id中提取市场索引（最后2个十六进制字符）
@@ -135,19 +137,29 @@ func Keccak256Hash(data []byte) string {
 }
 
 // ToWei 将金额转换为wei（指定小数位数）
+// 按 float64 的最短十进制表示换算，超出 decimals 的部分截断，避免 0.1 这类数值少 1 wei
 func ToWei(amount float64, decimals int) *big.Int {
-	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
-	amountBig := new(big.Float).SetFloat64(amount)
-	amountBig.Mul(amountBig, new(big.Float).SetInt(multiplier))
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
 
-	result := new(big.Int)
-	amountBig.Int(result)
-	return result
+// ToWeiDecimal 将定点小数金额精确转换为wei（指定小数位数）
+// decimals 小于 6 时超出部分截断
+func ToWeiDecimal(amount decimal.Decimal, decimals int) *big.Int {
+	units := amount.BigInt()
+	if decimals >= decimal.Places {
+		return units.Mul(units, pow10(decimals-decimal.Places))
+	}
+	return units.Quo(units, pow10(decimal.Places-decimals))
 }
 
 // FromWei 将wei转换为金额
 func FromWei(amount *big.Int, decimals int) float64 {
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	divisor := pow10(decimals)
 	amountFloat := new(big.Float).SetInt(amount)
 	divisorFloat := new(big.Float).SetInt(divisor)
 	amountFloat.Quo(amountFloat, divisorFloat)
@@ -155,3 +167,20 @@ func FromWei(amount *big.Int, decimals int) float64 {
 	result, _ := amountFloat.Float64()
 	return result
 }
+
+// FromWeiDecimal 将wei转换为定点小数金额
+// decimals 大于 6 时超出 6 位的部分截断（如 18 位精度的 POL）
+func FromWeiDecimal(amount *big.Int, decimals int) (decimal.Decimal, error) {
+	units := new(big.Int).Set(amount)
+	if decimals >= decimal.Places {
+		units.Quo(units, pow10(decimals-decimal.Places))
+	} else {
+		units.Mul(units, pow10(decimal.Places-decimals))
+	}
+	return decimal.FromBigUnits(units)
+}
+
+// pow10 返回 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -276,21 +276,17 @@ func (c *PolymarketWeb3Client) executeTransaction(tx *types.Transaction, operati
 		return nil, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	fmt.Printf("Txn hash: %s\n", tx.Hash().Hex())
+	c.logger.Info("tx submitted", "operation", operationName, "tx_hash", tx.Hash().Hex())
 
 	receipt, err := c.waitForReceipt(tx.Hash())
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait for receipt: %w", err)
 	}
 
-	if receipt.Status == 1 {
-		fmt.Printf("%s succeeded\n", operationName)
-	} else {
-		fmt.Printf("%s failed\n", operationName)
-	}
+	c.logTxMined(operationName, receipt)
 
 	gasUsed := new(big.Int).Mul(big.NewInt(int64(receipt.GasUsed)), receipt.EffectiveGasPrice)
-	fmt.Printf("Paid %f POL for gas\n", FromWei(gasUsed, 18))
+	c.logger.Info("tx gas paid", "operation", operationName, "tx_hash", tx.Hash().Hex(), "gas_cost_pol", FromWei(gasUsed, 18))
 
 	return receipt, nil
 }
@@ -413,56 +409,56 @@ func (c *PolymarketWeb3Client) SetConditionalTokensApproval(spender common.Addre
 func (c *PolymarketWeb3Client) SetAllApprovals() ([]*TransactionReceipt, error) {
 	var receipts []*TransactionReceipt
 
-	fmt.Println("Approving ConditionalTokens as spender on USDC")
+	c.logger.Info("setting approval", "spender", "ConditionalTokens", "token", "USDC")
 	r, err := c.SetCollateralApproval(c.ConditionalTokensAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("Approving CTFExchange as spender on USDC")
+	c.logger.Info("setting approval", "spender", "CTFExchange", "token", "USDC")
 	r, err = c.SetCollateralApproval(c.ExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("Approving NegRiskCtfExchange as spender on USDC")
+	c.logger.Info("setting approval", "spender", "NegRiskCtfExchange", "token", "USDC")
 	r, err = c.SetCollateralApproval(c.NegRiskExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("Approving NegRiskAdapter as spender on USDC")
+	c.logger.Info("setting approval", "spender", "NegRiskAdapter", "token", "USDC")
 	r, err = c.SetCollateralApproval(NegRiskAdapterAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("Approving CTFExchange as spender on ConditionalTokens")
+	c.logger.Info("setting approval", "spender", "CTFExchange", "token", "ConditionalTokens")
 	r, err = c.SetConditionalTokensApproval(c.ExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("Approving NegRiskCtfExchange as spender on ConditionalTokens")
+	c.logger.Info("setting approval", "spender", "NegRiskCtfExchange", "token", "ConditionalTokens")
 	r, err = c.SetConditionalTokensApproval(c.NegRiskExchangeAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("Approving NegRiskAdapter as spender on ConditionalTokens")
+	c.logger.Info("setting approval", "spender", "NegRiskAdapter", "token", "ConditionalTokens")
 	r, err = c.SetConditionalTokensApproval(NegRiskAdapterAddress)
 	if err != nil {
 		return receipts, err
 	}
 	receipts = append(receipts, r)
 
-	fmt.Println("All approvals set!")
+	c.logger.Info("all approvals set")
 	return receipts, nil
 }
 
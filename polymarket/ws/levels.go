@@ -0,0 +1,234 @@
+package ws
+
+import "github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+
+// levels 按价格排序的价位集合
+//
+// 使用 treap（以价格的最小单位为键，优先级由键哈希得到，结果与插入顺序无关），
+// 设置、删除和查询均为 O(log n)，遍历按价格有序
+type levels struct {
+	root *levelNode
+	n    int
+}
+
+// levelNode treap 节点
+type levelNode struct {
+	price       int64 // decimal 最小单位
+	size        decimal.Decimal
+	text        levelText // 服务器给出的原始字符串，用于生成快照
+	prio        uint64
+	left, right *levelNode
+}
+
+// levelText 价位在服务器消息中的原始数值字符串（保留末尾的 0 等格式），为空时按 decimal 格式化
+type levelText struct {
+	price, size string
+}
+
+// set 设置价位的数量，size <= 0 表示移除该价位
+func (l *levels) set(price, size decimal.Decimal, text levelText) {
+	if size.Sign() <= 0 {
+		var removed bool
+		l.root, removed = removeLevel(l.root, price.Units())
+		if removed {
+			l.n--
+		}
+		return
+	}
+	var added bool
+	l.root, added = insertLevel(l.root, price.Units(), size, text)
+	if added {
+		l.n++
+	}
+}
+
+// get 返回价位的数量
+func (l *levels) get(price decimal.Decimal) (decimal.Decimal, bool) {
+	key := price.Units()
+	for n := l.root; n != nil; {
+		switch {
+		case key < n.price:
+			n = n.left
+		case key > n.price:
+			n = n.right
+		default:
+			return n.size, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// min 返回最低价位
+func (l *levels) min() (PriceLevel, bool) {
+	n := l.root
+	if n == nil {
+		return PriceLevel{}, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.level(), true
+}
+
+// max 返回最高价位
+func (l *levels) max() (PriceLevel, bool) {
+	n := l.root
+	if n == nil {
+		return PriceLevel{}, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.level(), true
+}
+
+// ascend 按价格从低到高遍历，fn 返回 false 时停止
+func (l *levels) ascend(fn func(PriceLevel) bool) {
+	ascendLevels(l.root, func(n *levelNode) bool { return fn(n.level()) })
+}
+
+// descend 按价格从高到低遍历，fn 返回 false 时停止
+func (l *levels) descend(fn func(PriceLevel) bool) {
+	descendLevels(l.root, func(n *levelNode) bool { return fn(n.level()) })
+}
+
+// ascendText 按价格从低到高遍历价位的字符串形式
+func (l *levels) ascendText(fn func(price, size string)) {
+	ascendLevels(l.root, func(n *levelNode) bool {
+		fn(n.priceText(), n.sizeText())
+		return true
+	})
+}
+
+// descendText 按价格从高到低遍历价位的字符串形式
+func (l *levels) descendText(fn func(price, size string)) {
+	descendLevels(l.root, func(n *levelNode) bool {
+		fn(n.priceText(), n.sizeText())
+		return true
+	})
+}
+
+// len 返回价位数量
+func (l *levels) len() int {
+	return l.n
+}
+
+// clear 移除所有价位
+func (l *levels) clear() {
+	l.root = nil
+	l.n = 0
+}
+
+// level 转换为 PriceLevel
+func (n *levelNode) level() PriceLevel {
+	return PriceLevel{Price: decimal.FromUnits(n.price), Size: n.size}
+}
+
+// priceText 返回服务器给出的价格字符串
+func (n *levelNode) priceText() string {
+	if n.text.price != "" {
+		return n.text.price
+	}
+	return decimal.FromUnits(n.price).String()
+}
+
+// sizeText 返回服务器给出的数量字符串
+func (n *levelNode) sizeText() string {
+	if n.text.size != "" {
+		return n.text.size
+	}
+	return n.size.String()
+}
+
+// insertLevel 插入或更新价位，返回新的根节点和是否新增
+func insertLevel(n *levelNode, price int64, size decimal.Decimal, text levelText) (*levelNode, bool) {
+	if n == nil {
+		return &levelNode{price: price, size: size, text: text, prio: levelPriority(price)}, true
+	}
+	var added bool
+	switch {
+	case price < n.price:
+		n.left, added = insertLevel(n.left, price, size, text)
+		if n.left.prio > n.prio {
+			n = rotateRight(n)
+		}
+	case price > n.price:
+		n.right, added = insertLevel(n.right, price, size, text)
+		if n.right.prio > n.prio {
+			n = rotateLeft(n)
+		}
+	default:
+		n.size = size
+		n.text = text
+	}
+	return n, added
+}
+
+// removeLevel 移除价位，返回新的根节点和是否移除
+func removeLevel(n *levelNode, price int64) (*levelNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch {
+	case price < n.price:
+		n.left, removed = removeLevel(n.left, price)
+	case price > n.price:
+		n.right, removed = removeLevel(n.right, price)
+	default:
+		return mergeLevels(n.left, n.right), true
+	}
+	return n, removed
+}
+
+// mergeLevels 合并两棵 treap（a 中所有价格都低于 b）
+func mergeLevels(a, b *levelNode) *levelNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.prio > b.prio {
+		a.right = mergeLevels(a.right, b)
+		return a
+	}
+	b.left = mergeLevels(a, b.left)
+	return b
+}
+
+func rotateRight(n *levelNode) *levelNode {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	return l
+}
+
+func rotateLeft(n *levelNode) *levelNode {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	return r
+}
+
+func ascendLevels(n *levelNode, fn func(*levelNode) bool) bool {
+	if n == nil {
+		return true
+	}
+	return ascendLevels(n.left, fn) && fn(n) && ascendLevels(n.right, fn)
+}
+
+func descendLevels(n *levelNode, fn func(*levelNode) bool) bool {
+	if n == nil {
+		return true
+	}
+	return descendLevels(n.right, fn) && fn(n) && descendLevels(n.left, fn)
+}
+
+// levelPriority 由价格得到 treap 优先级（splitmix64）
+func levelPriority(price int64) uint64 {
+	x := uint64(price) + 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
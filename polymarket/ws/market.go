@@ -0,0 +1,64 @@
+package ws
+
+import "context"
+
+// MarketClient 市场频道客户端，按 asset ID（token ID）订阅公开的订单簿和成交事件
+type MarketClient struct {
+	s *stream
+}
+
+// NewMarketClient 创建市场频道客户端（未连接）
+func NewMarketClient(opts ...Option) *MarketClient {
+	cfg := newConfig(DefaultMarketURL, opts)
+	return &MarketClient{s: newStream(cfg, channel{
+		name:   "market",
+		decode: decodeMarketMessage,
+		hello: func(ids []string) interface{} {
+			return map[string]interface{}{"assets_ids": ids, "type": "market"}
+		},
+		update: func(op string, ids []string) interface{} {
+			return map[string]interface{}{"assets_ids": ids, "operation": op}
+		},
+	})}
+}
+
+// Connect 建立连接并订阅当前所有 asset ID
+// 连接断开（Done 关闭）后可再次调用以重连，订阅会自动恢复
+func (c *MarketClient) Connect(ctx context.Context) error {
+	return c.s.connect(ctx)
+}
+
+// Subscribe 订阅 asset ID，已连接时立即生效，否则在 Connect 时发送
+func (c *MarketClient) Subscribe(assetIDs ...string) error {
+	return c.s.subscribe(assetIDs)
+}
+
+// Unsubscribe 取消订阅 asset ID
+func (c *MarketClient) Unsubscribe(assetIDs ...string) error {
+	return c.s.unsubscribe(assetIDs)
+}
+
+// Subscriptions 返回当前订阅的 asset ID（排序后）
+func (c *MarketClient) Subscriptions() []string {
+	return c.s.subscriptions()
+}
+
+// Events 返回事件通道（使用 WithHandler 时没有事件），Close 后关闭
+func (c *MarketClient) Events() <-chan Event {
+	return c.s.events
+}
+
+// Done 返回当前连接断开时关闭的通道（未连接时已关闭）
+func (c *MarketClient) Done() <-chan struct{} {
+	return c.s.connDone()
+}
+
+// Err 返回最近一次连接断开的原因，连接中返回 nil
+func (c *MarketClient) Err() error {
+	return c.s.connErr()
+}
+
+// Close 关闭连接并停止客户端，可以在 WithHandler 回调中调用
+func (c *MarketClient) Close() error {
+	return c.s.close()
+}
@@ -0,0 +1,349 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// 市场频道事件类型
+const (
+	EventBook           = "book"
+	EventPriceChange    = "price_change"
+	EventLastTradePrice = "last_trade_price"
+	EventTickSizeChange = "tick_size_change"
+)
+
+// PriceLevel 订单簿的一个价位
+type PriceLevel struct {
+	Price decimal.Decimal `json:"price"`
+	Size  decimal.Decimal `json:"size"`
+}
+
+// decimalText 解析后的数值及其在消息中的原始字符串
+type decimalText struct {
+	decimal.Decimal
+	text string
+}
+
+// UnmarshalJSON 解析数值并保留原始字符串（null 和缺失时为空）
+func (d *decimalText) UnmarshalJSON(data []byte) error {
+	if err := d.Decimal.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		d.text = ""
+	case len(data) > 0 && data[0] == '"':
+		return json.Unmarshal(data, &d.text)
+	default:
+		d.text = string(data)
+	}
+	return nil
+}
+
+// wireLevel 消息中的价位
+type wireLevel struct {
+	Price decimalText `json:"price"`
+	Size  decimalText `json:"size"`
+}
+
+// BookEvent 订单簿快照（订阅后及成交后推送）
+type BookEvent struct {
+	AssetID   string
+	Market    string // condition ID
+	Bids      []PriceLevel
+	Asks      []PriceLevel
+	Timestamp time.Time
+	Hash      string
+
+	bidText, askText []levelText // 与 Bids / Asks 对应的原始字符串
+}
+
+// EventType 实现 Event
+func (e *BookEvent) EventType() string { return EventBook }
+
+// UnmarshalJSON 兼容 bids/asks 和旧格式 buys/sells
+func (e *BookEvent) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		AssetID   string      `json:"asset_id"`
+		Market    string      `json:"market"`
+		Bids      []wireLevel `json:"bids"`
+		Asks      []wireLevel `json:"asks"`
+		Buys      []wireLevel `json:"buys"`
+		Sells     []wireLevel `json:"sells"`
+		Timestamp unixTime    `json:"timestamp"`
+		Hash      string      `json:"hash"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Bids == nil {
+		aux.Bids = aux.Buys
+	}
+	if aux.Asks == nil {
+		aux.Asks = aux.Sells
+	}
+	*e = BookEvent{
+		AssetID:   aux.AssetID,
+		Market:    aux.Market,
+		Timestamp: aux.Timestamp.Time,
+		Hash:      aux.Hash,
+	}
+	e.Bids, e.bidText = splitLevels(aux.Bids)
+	e.Asks, e.askText = splitLevels(aux.Asks)
+	return nil
+}
+
+// splitLevels 拆分为价位和对应的原始字符串，nil 保持为 nil
+func splitLevels(wire []wireLevel) ([]PriceLevel, []levelText) {
+	if wire == nil {
+		return nil, nil
+	}
+	out := make([]PriceLevel, len(wire))
+	text := make([]levelText, len(wire))
+	for i, l := range wire {
+		out[i] = PriceLevel{Price: l.Price.Decimal, Size: l.Size.Decimal}
+		text[i] = levelText{price: l.Price.text, size: l.Size.text}
+	}
+	return out, text
+}
+
+// PriceChange 一个价位的变化，Size 为该价位的新总量（0 表示移除）
+type PriceChange struct {
+	AssetID string          `json:"asset_id"`
+	Price   decimal.Decimal `json:"price"`
+	Size    decimal.Decimal `json:"size"`
+	Side    string          `json:"side"` // BUY / SELL
+	Hash    string          `json:"hash"` // 变化后订单簿的哈希
+	BestBid decimal.Decimal `json:"best_bid"`
+	BestAsk decimal.Decimal `json:"best_ask"`
+
+	text levelText // Price / Size 的原始字符串
+}
+
+// UnmarshalJSON 解析价位变化并保留价格和数量的原始字符串
+func (c *PriceChange) UnmarshalJSON(data []byte) error {
+	type plain PriceChange
+	var aux struct {
+		plain
+		Price decimalText `json:"price"`
+		Size  decimalText `json:"size"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*c = PriceChange(aux.plain)
+	c.Price, c.Size = aux.Price.Decimal, aux.Size.Decimal
+	c.text = levelText{price: aux.Price.text, size: aux.Size.text}
+	return nil
+}
+
+// PriceChangeEvent 价位变化（下单、撤单时推送）
+type PriceChangeEvent struct {
+	Market    string // condition ID
+	Changes   []PriceChange
+	Timestamp time.Time
+}
+
+// EventType 实现 Event
+func (e *PriceChangeEvent) EventType() string { return EventPriceChange }
+
+// UnmarshalJSON 兼容新格式 price_changes 和旧格式 asset_id + changes + hash
+func (e *PriceChangeEvent) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Market       string        `json:"market"`
+		PriceChanges []PriceChange `json:"price_changes"`
+		AssetID      string        `json:"asset_id"`
+		Changes      []PriceChange `json:"changes"`
+		Hash         string        `json:"hash"`
+		Timestamp    unixTime      `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = PriceChangeEvent{
+		Market:    aux.Market,
+		Changes:   aux.PriceChanges,
+		Timestamp: aux.Timestamp.Time,
+	}
+	if e.Changes == nil {
+		e.Changes = aux.Changes
+		for i := range e.Changes {
+			if e.Changes[i].AssetID == "" {
+				e.Changes[i].AssetID = aux.AssetID
+			}
+			if e.Changes[i].Hash == "" {
+				e.Changes[i].Hash = aux.Hash
+			}
+		}
+	}
+	return nil
+}
+
+// LastTradePriceEvent 最新成交价（撮合成交时推送）
+type LastTradePriceEvent struct {
+	AssetID    string
+	Market     string // condition ID
+	Price      decimal.Decimal
+	Size       decimal.Decimal
+	Side       string // BUY / SELL
+	FeeRateBps int
+	Timestamp  time.Time
+
+	priceText string // Price 的原始字符串
+}
+
+// EventType 实现 Event
+func (e *LastTradePriceEvent) EventType() string { return EventLastTradePrice }
+
+// UnmarshalJSON 解析字符串形式的时间戳和手续费率
+func (e *LastTradePriceEvent) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		AssetID    string          `json:"asset_id"`
+		Market     string          `json:"market"`
+		Price      decimalText     `json:"price"`
+		Size       decimal.Decimal `json:"size"`
+		Side       string          `json:"side"`
+		FeeRateBps json.Number     `json:"fee_rate_bps"`
+		Timestamp  unixTime        `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = LastTradePriceEvent{
+		AssetID:   aux.AssetID,
+		Market:    aux.Market,
+		Price:     aux.Price.Decimal,
+		Size:      aux.Size,
+		Side:      aux.Side,
+		Timestamp: aux.Timestamp.Time,
+		priceText: aux.Price.text,
+	}
+	if aux.FeeRateBps != "" {
+		fee, err := strconv.Atoi(aux.FeeRateBps.String())
+		if err != nil {
+			return fmt.Errorf("invalid fee_rate_bps %q: %w", aux.FeeRateBps, err)
+		}
+		e.FeeRateBps = fee
+	}
+	return nil
+}
+
+// TickSizeChangeEvent tick size 变化（价格接近 0 或 1 时推送）
+type TickSizeChangeEvent struct {
+	AssetID     string
+	Market      string // condition ID
+	OldTickSize decimal.Decimal
+	NewTickSize decimal.Decimal
+	Timestamp   time.Time
+
+	newTickText string // NewTickSize 的原始字符串
+}
+
+// EventType 实现 Event
+func (e *TickSizeChangeEvent) EventType() string { return EventTickSizeChange }
+
+// UnmarshalJSON 解析字符串形式的时间戳
+func (e *TickSizeChangeEvent) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		AssetID     string          `json:"asset_id"`
+		Market      string          `json:"market"`
+		OldTickSize decimal.Decimal `json:"old_tick_size"`
+		NewTickSize decimalText     `json:"new_tick_size"`
+		Timestamp   unixTime        `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = TickSizeChangeEvent{
+		AssetID:     aux.AssetID,
+		Market:      aux.Market,
+		OldTickSize: aux.OldTickSize,
+		NewTickSize: aux.NewTickSize.Decimal,
+		Timestamp:   aux.Timestamp.Time,
+		newTickText: aux.NewTickSize.text,
+	}
+	return nil
+}
+
+// MarketHandlers 按事件类型分发的回调，未设置的回调忽略对应事件
+//
+//	client := ws.NewMarketClient(ws.WithHandler(ws.MarketHandlers{
+//		Book: func(e *ws.BookEvent) { ... },
+//	}.Handle))
+type MarketHandlers struct {
+	Book           func(*BookEvent)
+	PriceChange    func(*PriceChangeEvent)
+	LastTradePrice func(*LastTradePriceEvent)
+	TickSizeChange func(*TickSizeChangeEvent)
+	// Other 接收其他事件（如 UnknownEvent）
+	Other func(Event)
+}
+
+// Handle 将事件分发给对应的回调
+func (h MarketHandlers) Handle(ev Event) {
+	switch e := ev.(type) {
+	case *BookEvent:
+		if h.Book != nil {
+			h.Book(e)
+		}
+	case *PriceChangeEvent:
+		if h.PriceChange != nil {
+			h.PriceChange(e)
+		}
+	case *LastTradePriceEvent:
+		if h.LastTradePrice != nil {
+			h.LastTradePrice(e)
+		}
+	case *TickSizeChangeEvent:
+		if h.TickSizeChange != nil {
+			h.TickSizeChange(e)
+		}
+	default:
+		if h.Other != nil {
+			h.Other(ev)
+		}
+	}
+}
+
+// decodeMarketMessage 解码市场频道的一条消息
+func decodeMarketMessage(data []byte) ([]Event, error) {
+	items, err := splitMessages(data)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(items))
+	for _, raw := range items {
+		typ, err := eventType(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		var ev Event
+		switch typ {
+		case EventBook:
+			ev = &BookEvent{}
+		case EventPriceChange:
+			ev = &PriceChangeEvent{}
+		case EventLastTradePrice:
+			ev = &LastTradePriceEvent{}
+		case EventTickSizeChange:
+			ev = &TickSizeChangeEvent{}
+		default:
+			events = append(events, &UnknownEvent{Type: typ, Raw: raw})
+			continue
+		}
+		if err := json.Unmarshal(raw, ev); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", typ, err)
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
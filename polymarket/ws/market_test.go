@@ -0,0 +1,335 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testServer 本地 WebSocket 测试服务器，收到的文本消息写入 received，并可向客户端推送消息
+type testServer struct {
+	*httptest.Server
+	received chan string
+	conns    chan *websocket.Conn
+}
+
+// newTestServer 创建测试服务器，服务器像线上一样用 PONG 回复 PING
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ts := &testServer{
+		received: make(chan string, 64),
+		conns:    make(chan *websocket.Conn, 4),
+	}
+	upgrader := websocket.Upgrader{}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		ts.conns <- conn
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "PING" {
+				conn.WriteMessage(websocket.TextMessage, []byte("PONG"))
+			}
+			ts.received <- string(data)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// url 返回 ws:// 地址
+func (ts *testServer) url() string {
+	return "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+// conn 等待客户端连接
+func (ts *testServer) conn(t *testing.T) *websocket.Conn {
+	t.Helper()
+	select {
+	case conn := <-ts.conns:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for connection")
+		return nil
+	}
+}
+
+// next 等待下一条非 PING 消息并解码
+func (ts *testServer) next(t *testing.T) map[string]interface{} {
+	t.Helper()
+	for {
+		select {
+		case msg := <-ts.received:
+			if msg == "PING" {
+				continue
+			}
+			var out map[string]interface{}
+			if err := json.Unmarshal([]byte(msg), &out); err != nil {
+				t.Fatalf("invalid message %q: %v", msg, err)
+			}
+			return out
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+}
+
+// nextEvent 等待客户端收到下一个事件
+func nextEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed")
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for event")
+		return nil
+	}
+}
+
+// assetIDs 读取订阅消息中的 assets_ids
+func assetIDs(msg map[string]interface{}) []string {
+	var ids []string
+	for _, id := range msg["assets_ids"].([]interface{}) {
+		ids = append(ids, id.(string))
+	}
+	return ids
+}
+
+func TestMarketClientEvents(t *testing.T) {
+	ts := newTestServer(t)
+	client := NewMarketClient(WithURL(ts.url()))
+	defer client.Close()
+
+	if err := client.Subscribe("111", "222"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server := ts.conn(t)
+
+	hello := ts.next(t)
+	if hello["type"] != "market" || strings.Join(assetIDs(hello), ",") != "111,222" {
+		t.Fatalf("unexpected subscribe message: %v", hello)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`[{"event_type":"book","asset_id":"111","market":"0xabc",
+		"bids":[{"price":"0.48","size":"30"}],"asks":[{"price":"0.52","size":"25.5"}],
+		"timestamp":"1700000000123","hash":"h1"}]`))
+	book, ok := nextEvent(t, client.Events()).(*BookEvent)
+	if !ok {
+		t.Fatal("expected *BookEvent")
+	}
+	if book.AssetID != "111" || book.Hash != "h1" || len(book.Bids) != 1 || book.Asks[0].Size.String() != "25.5" {
+		t.Fatalf("unexpected book: %+v", book)
+	}
+	if book.Timestamp.UnixMilli() != 1700000000123 {
+		t.Fatalf("unexpected timestamp: %v", book.Timestamp)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"price_change","market":"0xabc",
+		"price_changes":[{"asset_id":"111","price":"0.49","size":"10","side":"BUY","hash":"h2","best_bid":"0.49","best_ask":"0.52"}],
+		"timestamp":"1700000000200"}`))
+	change, ok := nextEvent(t, client.Events()).(*PriceChangeEvent)
+	if !ok {
+		t.Fatal("expected *PriceChangeEvent")
+	}
+	if len(change.Changes) != 1 || change.Changes[0].Price.String() != "0.49" || change.Changes[0].Hash != "h2" {
+		t.Fatalf("unexpected price change: %+v", change)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"price_change","market":"0xabc","asset_id":"222",
+		"changes":[{"price":"0.3","size":"0","side":"SELL"}],"hash":"h3","timestamp":"1700000000300"}`))
+	legacy := nextEvent(t, client.Events()).(*PriceChangeEvent)
+	if legacy.Changes[0].AssetID != "222" || legacy.Changes[0].Hash != "h3" || !legacy.Changes[0].Size.IsZero() {
+		t.Fatalf("unexpected legacy price change: %+v", legacy)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"last_trade_price","asset_id":"111","market":"0xabc",
+		"price":"0.5","size":"12","side":"SELL","fee_rate_bps":"0","timestamp":"1700000000400"}`))
+	trade := nextEvent(t, client.Events()).(*LastTradePriceEvent)
+	if trade.Price.String() != "0.5" || trade.Side != "SELL" {
+		t.Fatalf("unexpected last trade price: %+v", trade)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"tick_size_change","asset_id":"111","market":"0xabc",
+		"old_tick_size":"0.01","new_tick_size":"0.001","timestamp":"1700000000500"}`))
+	tick := nextEvent(t, client.Events()).(*TickSizeChangeEvent)
+	if tick.OldTickSize.String() != "0.01" || tick.NewTickSize.String() != "0.001" {
+		t.Fatalf("unexpected tick size change: %+v", tick)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"new_thing"}`))
+	if ev := nextEvent(t, client.Events()); ev.EventType() != "new_thing" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+}
+
+func TestMarketClientDynamicSubscriptions(t *testing.T) {
+	ts := newTestServer(t)
+	client := NewMarketClient(WithURL(ts.url()))
+	defer client.Close()
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ts.conn(t)
+
+	// 没有订阅时连接，第一次订阅发送完整的订阅消息
+	if err := client.Subscribe("111"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := ts.next(t); msg["type"] != "market" {
+		t.Fatalf("expected initial subscribe, got %v", msg)
+	}
+
+	if err := client.Subscribe("111", "222"); err != nil {
+		t.Fatal(err)
+	}
+	msg := ts.next(t)
+	if msg["operation"] != "subscribe" || strings.Join(assetIDs(msg), ",") != "222" {
+		t.Fatalf("unexpected subscribe: %v", msg)
+	}
+
+	if err := client.Unsubscribe("111"); err != nil {
+		t.Fatal(err)
+	}
+	msg = ts.next(t)
+	if msg["operation"] != "unsubscribe" || strings.Join(assetIDs(msg), ",") != "111" {
+		t.Fatalf("unexpected unsubscribe: %v", msg)
+	}
+	if got := client.Subscriptions(); len(got) != 1 || got[0] != "222" {
+		t.Fatalf("unexpected subscriptions: %v", got)
+	}
+}
+
+func TestMarketClientReconnectAndPing(t *testing.T) {
+	ts := newTestServer(t)
+	var handled []string
+	events := make(chan struct{}, 8)
+	client := NewMarketClient(
+		WithURL(ts.url()),
+		WithPingInterval(20*time.Millisecond),
+		WithHandler(MarketHandlers{
+			Book: func(e *BookEvent) {
+				handled = append(handled, e.AssetID)
+				events <- struct{}{}
+			},
+		}.Handle),
+	)
+	defer client.Close()
+
+	client.Subscribe("111")
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Connect(context.Background()); err != ErrAlreadyConnected {
+		t.Fatalf("expected ErrAlreadyConnected, got %v", err)
+	}
+	server := ts.conn(t)
+	ts.next(t)
+
+	// PING 保活
+	deadline := time.After(2 * time.Second)
+	for ping := false; !ping; {
+		select {
+		case msg := <-ts.received:
+			ping = msg == "PING"
+		case <-deadline:
+			t.Fatal("timeout waiting for PING")
+		}
+	}
+
+	// 服务器断开后 Done 关闭，重连恢复订阅
+	server.Close()
+	select {
+	case <-client.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for disconnect")
+	}
+	if client.Err() == nil {
+		t.Fatal("expected disconnect error")
+	}
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server = ts.conn(t)
+	if msg := ts.next(t); strings.Join(assetIDs(msg), ",") != "111" {
+		t.Fatalf("subscriptions not restored: %v", msg)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"book","asset_id":"111","bids":[],"asks":[]}`))
+	select {
+	case <-events:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for handler")
+	}
+	if len(handled) != 1 || handled[0] != "111" {
+		t.Fatalf("unexpected handled events: %v", handled)
+	}
+
+	client.Close()
+	if _, ok := <-client.Events(); ok {
+		t.Fatal("expected events channel to be closed")
+	}
+	if err := client.Connect(context.Background()); err != ErrClosed {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestMarketClientCloseFromHandler(t *testing.T) {
+	ts := newTestServer(t)
+	var client *MarketClient
+	closed := make(chan error, 1)
+	client = NewMarketClient(
+		WithURL(ts.url()),
+		WithPingInterval(0),
+		WithHandler(func(Event) { closed <- client.Close() }),
+	)
+	client.Subscribe("111")
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server := ts.conn(t)
+	ts.next(t)
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"book","asset_id":"111","bids":[],"asks":[]}`))
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close called from the handler did not return")
+	}
+
+	// 回调返回后读取协程退出，Events() 随之关闭
+	select {
+	case _, ok := <-client.Events():
+		if ok {
+			t.Fatal("expected events channel to be closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("events channel not closed")
+	}
+	if !errors.Is(client.Err(), ErrClosed) {
+		t.Fatalf("Err = %v", client.Err())
+	}
+}
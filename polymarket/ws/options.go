@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultMarketURL 市场频道地址
+	DefaultMarketURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+	// DefaultUserURL 用户频道地址
+	DefaultUserURL = "wss://ws-subscriptions-clob.polymarket.com/ws/user"
+
+	// DefaultPingInterval 默认的 PING 间隔（服务器约 10 秒无消息会断开连接）
+	DefaultPingInterval = 10 * time.Second
+	// DefaultBufferSize 默认的事件通道缓冲大小
+	DefaultBufferSize = 256
+
+	// writeTimeout 写消息超时
+	writeTimeout = 10 * time.Second
+)
+
+// Option 客户端构造选项
+type Option func(*config)
+
+// config 客户端配置
+type config struct {
+	url          string
+	dialer       *websocket.Dialer
+	header       http.Header
+	pingInterval time.Duration
+	bufferSize   int
+	handler      func(Event)
+	logger       *slog.Logger
+}
+
+// newConfig 使用默认值和选项创建配置
+func newConfig(url string, opts []Option) config {
+	cfg := config{
+		url:          url,
+		dialer:       websocket.DefaultDialer,
+		pingInterval: DefaultPingInterval,
+		bufferSize:   DefaultBufferSize,
+		logger:       slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithURL 设置频道地址（如测试服务器）
+func WithURL(url string) Option {
+	return func(c *config) {
+		c.url = url
+	}
+}
+
+// WithDialer 使用自定义的 websocket.Dialer（如代理、TLS 配置）
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(c *config) {
+		if dialer != nil {
+			c.dialer = dialer
+		}
+	}
+}
+
+// WithHeader 设置握手时附加的请求头
+func WithHeader(header http.Header) Option {
+	return func(c *config) {
+		c.header = header
+	}
+}
+
+// WithPingInterval 设置 PING 间隔，<= 0 表示不发送 PING（也不检测读超时）
+func WithPingInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.pingInterval = interval
+	}
+}
+
+// WithBufferSize 设置事件通道的缓冲大小
+func WithBufferSize(size int) Option {
+	return func(c *config) {
+		if size >= 0 {
+			c.bufferSize = size
+		}
+	}
+}
+
+// WithHandler 以回调方式接收事件（在读取协程中同步调用），设置后 Events() 不再产出事件
+func WithHandler(handler func(Event)) Option {
+	return func(c *config) {
+		c.handler = handler
+	}
+}
+
+// WithLogger 设置日志记录器，nil 表示不输出日志
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+		c.logger = logger
+	}
+}
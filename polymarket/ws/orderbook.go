@@ -0,0 +1,453 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/timestamp"
+)
+
+// ErrBookMismatch 本地订单簿与服务器不一致（哈希、最优价或事件顺序不符）
+var ErrBookMismatch = errors.New("ws: order book mismatch")
+
+// BookSource 获取订单簿快照，*polymarket.ClobClient 实现了该接口
+type BookSource interface {
+	GetOrderBookWithContext(ctx context.Context, tokenID string) (*polymarket.OrderBookSummary, error)
+}
+
+// DefaultBookResyncInterval 同一资产两次从 REST 重新同步的默认最小间隔
+const DefaultBookResyncInterval = time.Second
+
+// BookOption OrderBook 构造选项
+type BookOption func(*bookConfig)
+
+// bookConfig OrderBook 配置
+type bookConfig struct {
+	hash           func(*polymarket.OrderBookSummary) string
+	checkHash      bool
+	resyncInterval time.Duration
+	onResync       func(assetID string, reason error)
+}
+
+// WithBookHashCheck 设置是否用价位变化中的哈希校验本地订单簿（默认关闭）
+//
+// GenerateOrderBookSummaryHash 尚未用服务器的真实哈希验证，开启后若算法与服务器不一致，
+// 每次价位变化都会触发重新同步；关闭时仍会校验最优价、交叉和事件顺序。
+// polymarket 包的 TestOrderBookHashFixtures 用抓取的 /book 响应通过后再改为默认开启
+func WithBookHashCheck(enabled bool) BookOption {
+	return func(c *bookConfig) {
+		c.checkHash = enabled
+	}
+}
+
+// WithBookHashFunc 设置计算订单簿哈希的函数
+func WithBookHashFunc(hash func(*polymarket.OrderBookSummary) string) BookOption {
+	return func(c *bookConfig) {
+		if hash != nil {
+			c.hash = hash
+		}
+	}
+}
+
+// WithBookResyncInterval 设置两次从 REST 重新同步的最小间隔（默认 DefaultBookResyncInterval），
+// <= 0 表示不限制。间隔内再次检测到不一致时只标记为待同步，合并到间隔结束后的下一次同步
+func WithBookResyncInterval(d time.Duration) BookOption {
+	return func(c *bookConfig) {
+		c.resyncInterval = d
+	}
+}
+
+// WithBookResyncHandler 设置从 REST 重新同步后的回调，reason 为触发原因
+func WithBookResyncHandler(fn func(assetID string, reason error)) BookOption {
+	return func(c *bookConfig) {
+		c.onResync = fn
+	}
+}
+
+// OrderBook 本地维护的单个资产的订单簿
+//
+// 先通过 Sync 从 REST 获取快照，再用 Apply 应用市场频道的 book / price_change /
+// tick_size_change / last_trade_price 事件。每次变化后与事件中的最优价（以及开启
+// WithBookHashCheck 时的哈希）比对，不一致时自动从 REST 重新同步；同一资产的重新同步
+// 受 WithBookResyncInterval 限制，间隔内的不一致合并为一次同步
+type OrderBook struct {
+	source  BookSource
+	assetID string
+	cfg     bookConfig
+	now     func() time.Time
+
+	mu             sync.RWMutex
+	seeded         bool
+	market         string
+	bids           levels
+	asks           levels
+	timestamp      time.Time // 最近一次快照或变化的时间
+	seededAt       time.Time // 最近一次快照的时间，早于它的变化已包含在快照中
+	hash           string
+	minOrderSize   string
+	negRisk        bool
+	tickSize       string
+	lastTradePrice string
+	lastResync     time.Time // 最近一次从 REST 重新同步的时间
+	pending        error     // 检测到的不一致，等待重新同步（期间忽略价位变化）
+}
+
+// NewOrderBook 创建资产的本地订单簿（尚未同步）
+func NewOrderBook(source BookSource, assetID string, opts ...BookOption) *OrderBook {
+	cfg := bookConfig{
+		hash:           polymarket.GenerateOrderBookSummaryHash,
+		resyncInterval: DefaultBookResyncInterval,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &OrderBook{source: source, assetID: assetID, cfg: cfg, now: time.Now}
+}
+
+// AssetID 返回资产 ID
+func (b *OrderBook) AssetID() string {
+	return b.assetID
+}
+
+// Sync 从 REST 获取快照并替换本地订单簿
+func (b *OrderBook) Sync(ctx context.Context) error {
+	summary, err := b.source.GetOrderBookWithContext(ctx, b.assetID)
+	if err != nil {
+		return fmt.Errorf("failed to sync order book %s: %w", b.assetID, err)
+	}
+	return b.Reset(summary)
+}
+
+// Reset 用订单簿摘要（如 GetOrderBook / GetOrderBooks 的结果）替换本地订单簿
+func (b *OrderBook) Reset(summary *polymarket.OrderBookSummary) error {
+	ts, err := timestamp.Parse(summary.Timestamp)
+	if err != nil {
+		return err
+	}
+	bids, bidText, err := parseSummaries(summary.Bids)
+	if err != nil {
+		return fmt.Errorf("invalid bids for %s: %w", b.assetID, err)
+	}
+	asks, askText, err := parseSummaries(summary.Asks)
+	if err != nil {
+		return fmt.Errorf("invalid asks for %s: %w", b.assetID, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset(bids, asks, bidText, askText, ts, summary.Hash)
+	b.market = summary.Market
+	b.minOrderSize = summary.MinOrderSize
+	b.negRisk = summary.NegRisk
+	b.tickSize = summary.TickSize
+	b.lastTradePrice = summary.LastTradePrice
+	return nil
+}
+
+// Apply 应用市场频道事件，其他资产的事件和无关事件会被忽略
+//
+// 尚未同步时先从 REST 同步；检测到不一致时从 REST 重新同步，只有同步失败时返回错误
+func (b *OrderBook) Apply(ctx context.Context, ev Event) error {
+	var err error
+	switch e := ev.(type) {
+	case *BookEvent:
+		if e.AssetID == b.assetID {
+			b.applyBook(e)
+		}
+		return nil
+	case *PriceChangeEvent:
+		err = b.applyPriceChange(e)
+	case *TickSizeChangeEvent:
+		if e.AssetID == b.assetID {
+			b.mu.Lock()
+			b.tickSize = textOr(e.newTickText, e.NewTickSize)
+			b.mu.Unlock()
+		}
+		return nil
+	case *LastTradePriceEvent:
+		if e.AssetID == b.assetID {
+			b.mu.Lock()
+			b.lastTradePrice = textOr(e.priceText, e.Price)
+			b.mu.Unlock()
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	if err == nil {
+		return nil
+	}
+	return b.resync(ctx, err)
+}
+
+// resync 因 reason 从 REST 重新同步；距上次同步不足最小间隔时只记录为待同步，
+// 由间隔结束后该资产的下一个价位变化触发
+func (b *OrderBook) resync(ctx context.Context, reason error) error {
+	b.mu.Lock()
+	now := b.now()
+	if !b.lastResync.IsZero() && now.Sub(b.lastResync) < b.cfg.resyncInterval {
+		if b.pending == nil {
+			b.pending = reason
+		}
+		b.mu.Unlock()
+		return nil
+	}
+	b.lastResync = now
+	b.mu.Unlock()
+
+	if err := b.Sync(ctx); err != nil {
+		b.mu.Lock()
+		b.pending = reason
+		b.mu.Unlock()
+		return fmt.Errorf("%w (after %v)", err, reason)
+	}
+	if b.cfg.onResync != nil {
+		b.cfg.onResync(b.assetID, reason)
+	}
+	return nil
+}
+
+// applyBook 用 book 事件替换本地订单簿，早于当前状态的快照被忽略
+func (b *OrderBook) applyBook(e *BookEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.seeded && !e.Timestamp.IsZero() && e.Timestamp.Before(b.timestamp) {
+		return
+	}
+	b.reset(e.Bids, e.Asks, e.bidText, e.askText, e.Timestamp, e.Hash)
+	if e.Market != "" {
+		b.market = e.Market
+	}
+}
+
+// applyPriceChange 应用价位变化，需要重新同步时返回原因
+func (b *OrderBook) applyPriceChange(e *PriceChangeEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var changes []PriceChange
+	for _, change := range e.Changes {
+		if change.AssetID == b.assetID {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if !b.seeded {
+		return errors.New("order book not synced")
+	}
+	if b.pending != nil {
+		return b.pending
+	}
+	if !e.Timestamp.IsZero() {
+		// 早于快照的变化已包含在快照中
+		if e.Timestamp.Before(b.seededAt) {
+			return nil
+		}
+		if e.Timestamp.Before(b.timestamp) {
+			return fmt.Errorf("%w: out-of-order change at %d, book at %d",
+				ErrBookMismatch, e.Timestamp.UnixMilli(), b.timestamp.UnixMilli())
+		}
+		b.timestamp = e.Timestamp
+	}
+	if e.Market != "" {
+		b.market = e.Market
+	}
+
+	for _, change := range changes {
+		switch change.Side {
+		case "BUY":
+			b.bids.set(change.Price, change.Size, change.text)
+		case "SELL":
+			b.asks.set(change.Price, change.Size, change.text)
+		default:
+			return fmt.Errorf("%w: unknown side %q", ErrBookMismatch, change.Side)
+		}
+	}
+
+	bid, hasBid := b.bids.max()
+	ask, hasAsk := b.asks.min()
+	if hasBid && hasAsk && bid.Price.Cmp(ask.Price) >= 0 {
+		return fmt.Errorf("%w: crossed book (bid %s >= ask %s)", ErrBookMismatch, bid.Price, ask.Price)
+	}
+
+	// 服务器给出的最优价和哈希对应该资产所有变化之后的订单簿
+	last := changes[len(changes)-1]
+	if err := b.verify(last); err != nil {
+		return err
+	}
+	b.hash = last.Hash
+	return nil
+}
+
+// verify 将本地订单簿与变化中附带的最优价和哈希比对
+func (b *OrderBook) verify(change PriceChange) error {
+	if !change.BestBid.IsZero() || !change.BestAsk.IsZero() {
+		bid, _ := b.bids.max()
+		ask, _ := b.asks.min()
+		if bid.Price.Cmp(change.BestBid) != 0 || ask.Price.Cmp(change.BestAsk) != 0 {
+			return fmt.Errorf("%w: best bid/ask %s/%s, server %s/%s",
+				ErrBookMismatch, bid.Price, ask.Price, change.BestBid, change.BestAsk)
+		}
+	}
+	if b.cfg.checkHash && change.Hash != "" {
+		if hash := b.cfg.hash(b.snapshot()); hash != change.Hash {
+			return fmt.Errorf("%w: hash %s, server %s", ErrBookMismatch, hash, change.Hash)
+		}
+	}
+	return nil
+}
+
+// reset 替换所有价位，调用方需持有写锁；bidText / askText 为对应价位的原始字符串，可为 nil
+func (b *OrderBook) reset(bids, asks []PriceLevel, bidText, askText []levelText, ts time.Time, hash string) {
+	b.bids.clear()
+	b.asks.clear()
+	for i, l := range bids {
+		b.bids.set(l.Price, l.Size, textAt(bidText, i))
+	}
+	for i, l := range asks {
+		b.asks.set(l.Price, l.Size, textAt(askText, i))
+	}
+	b.timestamp = ts
+	b.seededAt = ts
+	b.hash = hash
+	b.seeded = true
+	b.pending = nil
+}
+
+// Ready 是否已同步，检测到不一致且等待重新同步时返回 false
+func (b *OrderBook) Ready() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seeded && b.pending == nil
+}
+
+// BestBid 返回最高买价
+func (b *OrderBook) BestBid() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.bids.max()
+}
+
+// BestAsk 返回最低卖价
+func (b *OrderBook) BestAsk() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.asks.min()
+}
+
+// Depth 返回买卖双方最优的 n 个价位（从最优价开始），n <= 0 表示全部
+func (b *OrderBook) Depth(n int) (bids, asks []PriceLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return collectLevels(b.bids.descend, b.bids.len(), n), collectLevels(b.asks.ascend, b.asks.len(), n)
+}
+
+// Hash 返回最近一次快照或变化中服务器给出的哈希
+func (b *OrderBook) Hash() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.hash
+}
+
+// Timestamp 返回最近一次快照或变化的时间
+func (b *OrderBook) Timestamp() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.timestamp
+}
+
+// Snapshot 返回与 GetOrderBook 格式一致的订单簿摘要
+// 与服务器相同，买单按价格从低到高、卖单按价格从高到低排列（最优价在末尾）；
+// 价格、数量和最新成交价保持服务器给出的字符串（如末尾的 0）
+func (b *OrderBook) Snapshot() *polymarket.OrderBookSummary {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.snapshot()
+}
+
+// snapshot 生成订单簿摘要，调用方需持有锁
+func (b *OrderBook) snapshot() *polymarket.OrderBookSummary {
+	summary := &polymarket.OrderBookSummary{
+		Market:         b.market,
+		AssetID:        b.assetID,
+		Bids:           make([]polymarket.OrderSummary, 0, b.bids.len()),
+		Asks:           make([]polymarket.OrderSummary, 0, b.asks.len()),
+		MinOrderSize:   b.minOrderSize,
+		NegRisk:        b.negRisk,
+		TickSize:       b.tickSize,
+		Hash:           b.hash,
+		LastTradePrice: b.lastTradePrice,
+	}
+	if !b.timestamp.IsZero() {
+		summary.Timestamp = strconv.FormatInt(b.timestamp.UnixMilli(), 10)
+	}
+	b.bids.ascendText(func(price, size string) {
+		summary.Bids = append(summary.Bids, polymarket.OrderSummary{Price: price, Size: size})
+	})
+	b.asks.descendText(func(price, size string) {
+		summary.Asks = append(summary.Asks, polymarket.OrderSummary{Price: price, Size: size})
+	})
+	return summary
+}
+
+// collectLevels 按遍历顺序收集最多 n 个价位
+func collectLevels(walk func(func(PriceLevel) bool), total, n int) []PriceLevel {
+	if n <= 0 || n > total {
+		n = total
+	}
+	out := make([]PriceLevel, 0, n)
+	walk(func(l PriceLevel) bool {
+		if len(out) == n {
+			return false
+		}
+		out = append(out, l)
+		return true
+	})
+	return out
+}
+
+// textAt 返回第 i 个价位的原始字符串，缺失时为空
+func textAt(text []levelText, i int) levelText {
+	if i < len(text) {
+		return text[i]
+	}
+	return levelText{}
+}
+
+// textOr 返回原始字符串，为空时按 decimal 格式化
+func textOr(text string, d decimal.Decimal) string {
+	if text != "" {
+		return text
+	}
+	return d.String()
+}
+
+// parseSummaries 解析 REST 订单簿的价位，同时返回原始字符串
+func parseSummaries(summaries []polymarket.OrderSummary) ([]PriceLevel, []levelText, error) {
+	out := make([]PriceLevel, 0, len(summaries))
+	text := make([]levelText, 0, len(summaries))
+	for _, s := range summaries {
+		price, err := s.PriceDecimal()
+		if err != nil {
+			return nil, nil, err
+		}
+		size, err := s.SizeDecimal()
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, PriceLevel{Price: price, Size: size})
+		text = append(text, levelText{price: s.Price, size: s.Size})
+	}
+	return out, text, nil
+}
+
+var _ BookSource = (*polymarket.ClobClient)(nil)
@@ -0,0 +1,380 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+func TestLevelsMatchesSortedMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var l levels
+	ref := make(map[int64]int64)
+
+	for i := 0; i < 5000; i++ {
+		price := int64(rng.Intn(200)+1) * 5_000 // 0.005 ~ 1.0
+		size := int64(rng.Intn(4)) * 1_000_000  // 约 1/4 的操作为删除
+		l.set(decimal.FromUnits(price), decimal.FromUnits(size), levelText{})
+		if size == 0 {
+			delete(ref, price)
+		} else {
+			ref[price] = size
+		}
+	}
+
+	keys := make([]int64, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	if l.len() != len(keys) {
+		t.Fatalf("len = %d, want %d", l.len(), len(keys))
+	}
+	i := 0
+	l.ascend(func(p PriceLevel) bool {
+		if p.Price.Units() != keys[i] || p.Size.Units() != ref[keys[i]] {
+			t.Fatalf("level %d = %v, want %d/%d", i, p, keys[i], ref[keys[i]])
+		}
+		i++
+		return true
+	})
+	if lo, _ := l.min(); lo.Price.Units() != keys[0] {
+		t.Fatalf("min = %v, want %d", lo.Price, keys[0])
+	}
+	if hi, _ := l.max(); hi.Price.Units() != keys[len(keys)-1] {
+		t.Fatalf("max = %v, want %d", hi.Price, keys[len(keys)-1])
+	}
+	if size, ok := l.get(decimal.FromUnits(keys[0])); !ok || size.Units() != ref[keys[0]] {
+		t.Fatalf("get = %v, %v", size, ok)
+	}
+}
+
+// fakeBooks 返回固定快照的 BookSource
+type fakeBooks struct {
+	summary *polymarket.OrderBookSummary
+	calls   int
+}
+
+func (f *fakeBooks) GetOrderBookWithContext(ctx context.Context, tokenID string) (*polymarket.OrderBookSummary, error) {
+	f.calls++
+	if f.summary == nil {
+		return nil, errors.New("unavailable")
+	}
+	s := *f.summary
+	return &s, nil
+}
+
+func testSummary() *polymarket.OrderBookSummary {
+	return &polymarket.OrderBookSummary{
+		Market:       "0xabc",
+		AssetID:      "111",
+		Timestamp:    "1700000000000",
+		Bids:         []polymarket.OrderSummary{{Price: "0.47", Size: "100"}, {Price: "0.48", Size: "30"}},
+		Asks:         []polymarket.OrderSummary{{Price: "0.53", Size: "40"}, {Price: "0.52", Size: "25"}},
+		MinOrderSize: "5",
+		TickSize:     "0.01",
+		Hash:         "seed",
+	}
+}
+
+func change(price, size, side string) PriceChange {
+	return PriceChange{AssetID: "111", Price: decimal.MustParse(price), Size: decimal.MustParse(size), Side: side}
+}
+
+func TestOrderBookApplyChanges(t *testing.T) {
+	source := &fakeBooks{summary: testSummary()}
+	book := NewOrderBook(source, "111", WithBookHashCheck(false))
+	ctx := context.Background()
+
+	// 未同步时先从 REST 同步
+	ev := &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000100), Changes: []PriceChange{change("0.49", "10", "BUY")}}
+	if err := book.Apply(ctx, ev); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 1 || !book.Ready() {
+		t.Fatalf("expected seed from REST, calls = %d", source.calls)
+	}
+	if err := book.Apply(ctx, ev); err != nil {
+		t.Fatal(err)
+	}
+
+	// 移除价位、忽略其他资产
+	ev = &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000200), Changes: []PriceChange{
+		change("0.52", "0", "SELL"),
+		{AssetID: "222", Price: decimal.MustParse("0.1"), Size: decimal.MustParse("1"), Side: "BUY"},
+	}}
+	if err := book.Apply(ctx, ev); err != nil {
+		t.Fatal(err)
+	}
+
+	bid, _ := book.BestBid()
+	ask, _ := book.BestAsk()
+	if bid.Price.String() != "0.49" || ask.Price.String() != "0.53" {
+		t.Fatalf("best bid/ask = %s/%s", bid.Price, ask.Price)
+	}
+	bids, asks := book.Depth(2)
+	if len(bids) != 2 || bids[1].Price.String() != "0.48" || len(asks) != 1 {
+		t.Fatalf("unexpected depth: %v %v", bids, asks)
+	}
+
+	snap := book.Snapshot()
+	if snap.Timestamp != "1700000000200" || snap.TickSize != "0.01" || snap.MinOrderSize != "5" {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+	if snap.Bids[0].Price != "0.47" || snap.Bids[len(snap.Bids)-1].Price != "0.49" {
+		t.Fatalf("bids not ascending: %v", snap.Bids)
+	}
+	if source.calls != 1 {
+		t.Fatalf("unexpected resync, calls = %d", source.calls)
+	}
+
+	// 早于快照的变化已包含在快照中
+	stale := &PriceChangeEvent{Timestamp: time.UnixMilli(1699999999999), Changes: []PriceChange{change("0.2", "1", "BUY")}}
+	book.Apply(ctx, stale)
+	if _, ok := book.bids.get(decimal.MustParse("0.2")); ok {
+		t.Fatal("change before snapshot should be skipped")
+	}
+
+	book.Apply(ctx, &TickSizeChangeEvent{AssetID: "111", NewTickSize: decimal.MustParse("0.001")})
+	if book.Snapshot().TickSize != "0.001" {
+		t.Fatal("tick size not updated")
+	}
+}
+
+func TestOrderBookResyncOnMismatch(t *testing.T) {
+	source := &fakeBooks{summary: testSummary()}
+	var reasons []error
+	book := NewOrderBook(source, "111", WithBookHashCheck(true), WithBookResyncInterval(0),
+		WithBookResyncHandler(func(assetID string, reason error) {
+			reasons = append(reasons, reason)
+		}))
+	ctx := context.Background()
+	if err := book.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 哈希与本地订单簿一致时不重新同步
+	book.bids.set(decimal.MustParse("0.49"), decimal.MustParse("10"), levelText{})
+	expected := book.Snapshot()
+	expected.Timestamp = "1700000000100"
+	hash := polymarket.GenerateOrderBookSummaryHash(expected)
+	book.bids.set(decimal.MustParse("0.49"), decimal.Zero, levelText{})
+
+	c := change("0.49", "10", "BUY")
+	c.Hash = hash
+	c.BestBid = decimal.MustParse("0.49")
+	c.BestAsk = decimal.MustParse("0.52")
+	if err := book.Apply(ctx, &PriceChangeEvent{Market: "0xabc", Timestamp: time.UnixMilli(1700000000100), Changes: []PriceChange{c}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(reasons) != 0 || book.Hash() != hash {
+		t.Fatalf("unexpected resync: %v", reasons)
+	}
+
+	tests := []struct {
+		name string
+		ev   *PriceChangeEvent
+	}{
+		{"hash", &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000200), Changes: []PriceChange{
+			{AssetID: "111", Price: decimal.MustParse("0.46"), Size: decimal.MustParse("1"), Side: "BUY", Hash: "bogus"},
+		}}},
+		{"best bid/ask", &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000300), Changes: []PriceChange{
+			{AssetID: "111", Price: decimal.MustParse("0.46"), Size: decimal.MustParse("1"), Side: "BUY",
+				BestBid: decimal.MustParse("0.46"), BestAsk: decimal.MustParse("0.52")},
+		}}},
+		{"crossed", &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000400), Changes: []PriceChange{change("0.6", "1", "BUY")}}},
+		{"out of order", &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000050), Changes: []PriceChange{change("0.46", "1", "BUY")}}},
+	}
+	for i, tt := range tests {
+		// 先推进时间，使乱序检测有效
+		book.mu.Lock()
+		book.timestamp = time.UnixMilli(1700000000100)
+		book.mu.Unlock()
+
+		if err := book.Apply(ctx, tt.ev); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(reasons) != i+1 || !errors.Is(reasons[i], ErrBookMismatch) {
+			t.Fatalf("%s: expected resync, reasons = %v", tt.name, reasons)
+		}
+		if bid, _ := book.BestBid(); bid.Price.String() != "0.48" {
+			t.Fatalf("%s: book not restored from REST, best bid %s", tt.name, bid.Price)
+		}
+	}
+
+	source.summary = nil
+	if err := book.Apply(ctx, tests[0].ev); err == nil {
+		t.Fatal("expected error when resync fails")
+	}
+}
+
+func TestOrderBookHashCheckDefaultOff(t *testing.T) {
+	source := &fakeBooks{summary: testSummary()}
+	book := NewOrderBook(source, "111")
+	ctx := context.Background()
+	if err := book.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c := change("0.49", "10", "BUY")
+	c.Hash = "not-the-local-hash"
+	if err := book.Apply(ctx, &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000100), Changes: []PriceChange{c}}); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 1 || book.Hash() != "not-the-local-hash" {
+		t.Fatalf("hash mismatch triggered resync by default, calls = %d", source.calls)
+	}
+}
+
+func TestOrderBookResyncThrottle(t *testing.T) {
+	source := &fakeBooks{summary: testSummary()}
+	var reasons []error
+	book := NewOrderBook(source, "111", WithBookResyncInterval(time.Second),
+		WithBookResyncHandler(func(assetID string, reason error) {
+			reasons = append(reasons, reason)
+		}))
+	now := time.Unix(1700000000, 0)
+	book.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// 第一次不一致立即同步
+	mismatch := func(ts int64) *PriceChangeEvent {
+		c := change("0.45", "1", "BUY")
+		c.BestBid = decimal.MustParse("0.99")
+		return &PriceChangeEvent{Timestamp: time.UnixMilli(ts), Changes: []PriceChange{c}}
+	}
+	if err := book.Apply(ctx, mismatch(1700000000100)); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 1 || len(reasons) != 1 || !book.Ready() {
+		t.Fatalf("first mismatch: calls = %d, reasons = %v", source.calls, reasons)
+	}
+
+	// 间隔内的不一致只标记为待同步，之后的变化被忽略直到同步
+	now = now.Add(100 * time.Millisecond)
+	if err := book.Apply(ctx, mismatch(1700000000200)); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 1 || book.Ready() {
+		t.Fatalf("throttled mismatch: calls = %d, ready = %v", source.calls, book.Ready())
+	}
+	now = now.Add(100 * time.Millisecond)
+	if err := book.Apply(ctx, &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000300), Changes: []PriceChange{change("0.2", "1", "BUY")}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := book.bids.get(decimal.MustParse("0.2")); ok || source.calls != 1 {
+		t.Fatalf("change applied to a pending book, calls = %d", source.calls)
+	}
+
+	// 间隔结束后的下一个变化触发一次同步，合并之前的不一致
+	now = now.Add(time.Second)
+	if err := book.Apply(ctx, &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000400), Changes: []PriceChange{change("0.2", "1", "BUY")}}); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 2 || len(reasons) != 2 || !book.Ready() || !errors.Is(reasons[1], ErrBookMismatch) {
+		t.Fatalf("coalesced resync: calls = %d, reasons = %v, ready = %v", source.calls, reasons, book.Ready())
+	}
+
+	// book 快照直接恢复，不需要 REST
+	now = now.Add(100 * time.Millisecond)
+	book.Apply(ctx, mismatch(1700000000500))
+	if book.Ready() {
+		t.Fatal("expected pending resync")
+	}
+	book.Apply(ctx, &BookEvent{AssetID: "111", Timestamp: time.UnixMilli(1700000000600),
+		Bids: []PriceLevel{{Price: decimal.MustParse("0.4"), Size: decimal.MustParse("1")}}})
+	if !book.Ready() || source.calls != 2 {
+		t.Fatalf("book event did not clear pending resync, calls = %d", source.calls)
+	}
+
+	// 同步失败时保持待同步，间隔后重试
+	source.summary = nil
+	now = now.Add(2 * time.Second)
+	if err := book.Apply(ctx, mismatch(1700000000700)); err == nil {
+		t.Fatal("expected error when resync fails")
+	}
+	if book.Ready() || source.calls != 3 {
+		t.Fatalf("failed resync: ready = %v, calls = %d", book.Ready(), source.calls)
+	}
+	now = now.Add(100 * time.Millisecond)
+	if err := book.Apply(ctx, mismatch(1700000000800)); err != nil || source.calls != 3 {
+		t.Fatalf("retry within interval: %v, calls = %d", err, source.calls)
+	}
+}
+
+func TestOrderBookKeepsServerStrings(t *testing.T) {
+	summary := testSummary()
+	summary.Bids = []polymarket.OrderSummary{{Price: "0.47", Size: "100.00"}, {Price: "0.50", Size: "10.0"}}
+	summary.Asks = []polymarket.OrderSummary{{Price: "0.600", Size: "5"}}
+	summary.LastTradePrice = "0.500"
+	summary.Hash = polymarket.GenerateOrderBookSummaryHash(summary)
+
+	source := &fakeBooks{summary: summary}
+	book := NewOrderBook(source, "111", WithBookHashCheck(true), WithBookResyncInterval(0))
+	ctx := context.Background()
+	if err := book.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if hash := polymarket.GenerateOrderBookSummaryHash(book.Snapshot()); hash != summary.Hash {
+		t.Fatalf("snapshot differs from the REST book: hash %s, want %s", hash, summary.Hash)
+	}
+
+	// 价位变化和最新成交价同样保留消息中的字符串
+	expected := *summary
+	expected.Timestamp = "1700000000100"
+	expected.Bids = []polymarket.OrderSummary{{Price: "0.47", Size: "100.00"}, {Price: "0.500", Size: "7.50"}}
+	expected.LastTradePrice = "0.5100"
+	hash := polymarket.GenerateOrderBookSummaryHash(&expected)
+
+	events, err := decodeMarketMessage([]byte(`[
+		{"event_type":"last_trade_price","asset_id":"111","market":"0xabc","price":"0.5100","size":"1","side":"BUY","timestamp":"1700000000050"},
+		{"event_type":"price_change","market":"0xabc","timestamp":"1700000000100","price_changes":[
+			{"asset_id":"111","price":"0.500","size":"7.50","side":"BUY","hash":"` + hash + `","best_bid":"0.5","best_ask":"0.6"}]}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range events {
+		if err := book.Apply(ctx, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if source.calls != 1 {
+		t.Fatalf("hash of server strings did not match, %d syncs", source.calls)
+	}
+	snap := book.Snapshot()
+	if snap.Bids[1] != (polymarket.OrderSummary{Price: "0.500", Size: "7.50"}) || snap.Asks[0].Price != "0.600" || snap.LastTradePrice != "0.5100" {
+		t.Fatalf("server strings not kept: %+v", snap)
+	}
+}
+
+func TestOrderBookIgnoresStaleBookEvent(t *testing.T) {
+	source := &fakeBooks{summary: testSummary()}
+	book := NewOrderBook(source, "111")
+	ctx := context.Background()
+	if err := book.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Apply(ctx, &PriceChangeEvent{Timestamp: time.UnixMilli(1700000000100), Changes: []PriceChange{change("0.49", "10", "BUY")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 早于当前状态的快照不会让订单簿回退
+	book.Apply(ctx, &BookEvent{AssetID: "111", Timestamp: time.UnixMilli(1700000000050),
+		Bids: []PriceLevel{{Price: decimal.MustParse("0.3"), Size: decimal.MustParse("1")}}})
+	if bid, _ := book.BestBid(); bid.Price.String() != "0.49" {
+		t.Fatalf("stale book event applied, best bid %s", bid.Price)
+	}
+
+	book.Apply(ctx, &BookEvent{AssetID: "111", Timestamp: time.UnixMilli(1700000000100),
+		Bids: []PriceLevel{{Price: decimal.MustParse("0.3"), Size: decimal.MustParse("1")}}})
+	if bid, _ := book.BestBid(); bid.Price.String() != "0.3" {
+		t.Fatalf("book event not applied, best bid %s", bid.Price)
+	}
+}
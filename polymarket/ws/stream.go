@@ -0,0 +1,436 @@
+// Package ws 提供 CLOB WebSocket 频道的客户端
+//
+// MarketClient 订阅市场频道（按 asset ID），接收订单簿快照、价格变化、最新成交价
+// 和 tick size 变化事件。事件可以从 Events() 通道读取，也可以通过 WithHandler 以回调方式接收：
+//
+//	client := ws.NewMarketClient()
+//	if err := client.Subscribe(tokenID); err != nil { ... }
+//	if err := client.Connect(ctx); err != nil { ... }
+//	defer client.Close()
+//	for ev := range client.Events() {
+//		switch e := ev.(type) {
+//		case *ws.BookEvent:
+//			...
+//		case *ws.PriceChangeEvent:
+//			...
+//		}
+//	}
+//
+// UserClient 使用 L2 API 凭证订阅用户频道（按 condition ID），接收自己订单的 OrderEvent
+// 和成交状态变化的 TradeEvent。
+//
+// 连接断开后 Done() 关闭、Err() 返回原因，再次调用 Connect 会恢复所有订阅
+package ws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket/internal/timestamp"
+)
+
+var (
+	// ErrClosed 客户端已关闭
+	ErrClosed = errors.New("ws: client closed")
+	// ErrNotConnected 尚未连接
+	ErrNotConnected = errors.New("ws: not connected")
+	// ErrAlreadyConnected 已经连接，需先等待断开（Done）再重新连接
+	ErrAlreadyConnected = errors.New("ws: already connected")
+)
+
+// Event WebSocket 事件，具体类型见 BookEvent、PriceChangeEvent 等
+type Event interface {
+	// EventType 返回服务器的 event_type（如 "book"）
+	EventType() string
+}
+
+// UnknownEvent 无法识别类型的事件
+type UnknownEvent struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+// EventType 实现 Event
+func (e *UnknownEvent) EventType() string { return e.Type }
+
+// channel 频道的协议差异：消息解码和订阅消息格式
+type channel struct {
+	name string
+	// decode 解码一条服务器消息（可能包含多个事件）
+	decode func(data []byte) ([]Event, error)
+	// hello 连接后发送的首条订阅消息
+	hello func(ids []string) interface{}
+	// update 动态订阅/取消订阅消息，op 为 "subscribe" 或 "unsubscribe"
+	update func(op string, ids []string) interface{}
+	// helloWithoutIDs 没有订阅时是否也发送首条消息（用户频道可以不指定市场）
+	helloWithoutIDs bool
+}
+
+// stream 管理一条 WebSocket 连接：订阅、PING 保活、读取和分发事件
+type stream struct {
+	cfg config
+	ch  channel
+
+	events chan Event
+
+	mu      sync.Mutex
+	subs    map[string]struct{}
+	conn    *websocket.Conn
+	greeted bool               // 当前连接是否已发送首条订阅消息
+	cancel  context.CancelFunc // 结束当前连接
+	done    chan struct{}      // 当前连接结束时关闭
+	err     error              // 最近一次连接结束的原因
+	closed  bool
+	wg      sync.WaitGroup
+
+	dispatching atomic.Bool // 读取协程正在调用 WithHandler 回调
+
+	writeMu sync.Mutex
+}
+
+// newStream 创建未连接的 stream
+func newStream(cfg config, ch channel) *stream {
+	done := make(chan struct{})
+	close(done)
+	return &stream{
+		cfg:    cfg,
+		ch:     ch,
+		events: make(chan Event, cfg.bufferSize),
+		subs:   make(map[string]struct{}),
+		done:   done,
+		err:    ErrNotConnected,
+	}
+}
+
+// connect 建立连接，发送当前所有订阅，并启动读取和 PING 协程
+func (s *stream) connect(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	if s.conn != nil {
+		s.mu.Unlock()
+		return ErrAlreadyConnected
+	}
+	s.mu.Unlock()
+
+	conn, _, err := s.cfg.dialer.DialContext(ctx, s.cfg.url, s.cfg.header)
+	if err != nil {
+		return fmt.Errorf("ws: dial %s: %w", s.cfg.url, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.conn != nil {
+		conn.Close()
+		if s.closed {
+			return ErrClosed
+		}
+		return ErrAlreadyConnected
+	}
+
+	ids := s.subscriptionsLocked()
+	if len(ids) > 0 || s.ch.helloWithoutIDs {
+		if err := s.write(conn, s.ch.hello(ids)); err != nil {
+			conn.Close()
+			return fmt.Errorf("ws: subscribe: %w", err)
+		}
+	}
+
+	connCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.conn = conn
+	s.greeted = len(ids) > 0 || s.ch.helloWithoutIDs
+	s.cancel = cancel
+	s.done = done
+	s.err = nil
+
+	s.wg.Add(2)
+	go s.readLoop(connCtx, conn, done)
+	go s.pingLoop(connCtx, conn)
+
+	s.cfg.logger.InfoContext(ctx, "ws connected", "channel", s.ch.name, "subscriptions", len(ids))
+	return nil
+}
+
+// subscribe 添加订阅，已连接时立即发送
+func (s *stream) subscribe(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	var added []string
+	for _, id := range ids {
+		if _, ok := s.subs[id]; !ok && id != "" {
+			s.subs[id] = struct{}{}
+			added = append(added, id)
+		}
+	}
+	if len(added) == 0 || s.conn == nil {
+		return nil
+	}
+
+	if !s.greeted {
+		if err := s.write(s.conn, s.ch.hello(s.subscriptionsLocked())); err != nil {
+			return fmt.Errorf("ws: subscribe: %w", err)
+		}
+		s.greeted = true
+		return nil
+	}
+	if err := s.write(s.conn, s.ch.update("subscribe", added)); err != nil {
+		return fmt.Errorf("ws: subscribe: %w", err)
+	}
+	return nil
+}
+
+// unsubscribe 取消订阅，已连接时立即发送
+func (s *stream) unsubscribe(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	var removed []string
+	for _, id := range ids {
+		if _, ok := s.subs[id]; ok {
+			delete(s.subs, id)
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 || s.conn == nil || !s.greeted {
+		return nil
+	}
+	if err := s.write(s.conn, s.ch.update("unsubscribe", removed)); err != nil {
+		return fmt.Errorf("ws: unsubscribe: %w", err)
+	}
+	return nil
+}
+
+// subscriptions 返回当前订阅（排序后）
+func (s *stream) subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subscriptionsLocked()
+}
+
+// subscriptionsLocked 返回当前订阅（排序后），调用方需持有 mu
+func (s *stream) subscriptionsLocked() []string {
+	ids := make([]string, 0, len(s.subs))
+	for id := range s.subs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// connDone 返回当前连接结束时关闭的通道（未连接时已关闭）
+func (s *stream) connDone() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+// connErr 返回最近一次连接结束的原因，连接中返回 nil
+func (s *stream) connErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// close 关闭连接并停止客户端，之后 Events() 通道会被关闭
+//
+// 通常等待读取和 PING 协程退出后返回。WithHandler 回调执行期间调用时（包括在回调中调用）
+// 等待读取协程会死锁，此时不等待：回调返回后读取协程退出，随后 Events() 通道关闭
+func (s *stream) close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	conn, cancel := s.conn, s.cancel
+	s.mu.Unlock()
+
+	if conn != nil {
+		s.writeMu.Lock()
+		msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+		s.writeMu.Unlock()
+		cancel()
+		conn.Close()
+	}
+	if s.dispatching.Load() {
+		go func() {
+			s.wg.Wait()
+			close(s.events)
+		}()
+		return nil
+	}
+	s.wg.Wait()
+	close(s.events)
+	return nil
+}
+
+// write 发送 JSON 消息
+func (s *stream) write(conn *websocket.Conn, msg interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return conn.WriteJSON(msg)
+}
+
+// pingLoop 定期发送 PING 保持连接
+func (s *stream) pingLoop(ctx context.Context, conn *websocket.Conn) {
+	defer s.wg.Done()
+	if s.cfg.pingInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.writeMu.Lock()
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err := conn.WriteMessage(websocket.TextMessage, []byte("PING"))
+		s.writeMu.Unlock()
+		if err != nil {
+			// 写失败说明连接已断开，由 readLoop 处理
+			conn.Close()
+			return
+		}
+	}
+}
+
+// readLoop 读取消息并分发事件，连接断开时记录原因
+func (s *stream) readLoop(ctx context.Context, conn *websocket.Conn, done chan struct{}) {
+	defer s.wg.Done()
+
+	err := s.read(ctx, conn)
+
+	s.mu.Lock()
+	if s.closed {
+		err = ErrClosed
+	}
+	s.conn = nil
+	s.greeted = false
+	s.cancel()
+	s.err = err
+	close(done)
+	s.mu.Unlock()
+
+	conn.Close()
+	s.cfg.logger.Info("ws disconnected", "channel", s.ch.name, "error", err)
+}
+
+// read 循环读取消息，直到出错或 ctx 结束
+func (s *stream) read(ctx context.Context, conn *websocket.Conn) error {
+	readTimeout := 3 * s.cfg.pingInterval
+	for {
+		if readTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(readTimeout))
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if isPong(data) {
+			continue
+		}
+
+		events, err := s.ch.decode(data)
+		if err != nil {
+			s.cfg.logger.Warn("ws message decode failed", "channel", s.ch.name, "error", err)
+			continue
+		}
+		for _, ev := range events {
+			if s.cfg.handler != nil {
+				s.dispatching.Store(true)
+				s.cfg.handler(ev)
+				s.dispatching.Store(false)
+				continue
+			}
+			select {
+			case s.events <- ev:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// isPong 是否为服务器对 PING 的回复
+func isPong(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("PONG"))
+}
+
+// splitMessages 将一条消息拆分为事件：服务器可能发送单个对象或对象数组
+func splitMessages(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+	if data[0] != '{' {
+		return nil, fmt.Errorf("unexpected message %q", truncate(data, 64))
+	}
+	return []json.RawMessage{data}, nil
+}
+
+// eventType 读取消息的 event_type
+func eventType(raw json.RawMessage) (string, error) {
+	var head struct {
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		return "", err
+	}
+	return head.EventType, nil
+}
+
+// truncate 截断过长的消息（用于错误信息）
+func truncate(data []byte, n int) []byte {
+	if len(data) > n {
+		return data[:n]
+	}
+	return data
+}
+
+// unixTime 时间戳，兼容字符串和数字、秒和毫秒以及 RFC3339 字符串
+type unixTime struct {
+	time.Time
+}
+
+// UnmarshalJSON 解析时间戳（空值为零时间）
+func (t *unixTime) UnmarshalJSON(data []byte) error {
+	v, err := timestamp.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	t.Time = v
+	return nil
+}
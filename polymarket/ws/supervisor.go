@@ -0,0 +1,704 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+)
+
+// EventStatus 监督器状态事件类型
+const EventStatus = "status"
+
+// 每次 GetOrderBooks 请求的最大资产数
+const booksBatchSize = 100
+
+// 定期丢弃过期成交去重记录的间隔
+const tradePruneInterval = time.Minute
+
+// DefaultBackfillMaxPages 每个市场补齐成交时默认最多请求的页数
+const DefaultBackfillMaxPages = 10
+
+// SupervisorSource 监督器使用的 REST 接口，*polymarket.ClobClient 实现了该接口
+type SupervisorSource interface {
+	BookSource
+	GetOrderBooksWithContext(ctx context.Context, params []polymarket.BookParams) ([]*polymarket.OrderBookSummary, error)
+	TradesPager(params *polymarket.TradeParams, opts polymarket.PageOptions) *polymarket.Pager[polymarket.Trade]
+}
+
+var _ SupervisorSource = (*polymarket.ClobClient)(nil)
+
+// StreamState 数据流状态
+type StreamState string
+
+const (
+	// StreamStale 连接断开或订单簿重新同步失败，之后的数据可能缺失，本地状态不可信
+	StreamStale StreamState = "stale"
+	// StreamResynced 已（重新）连接并从 REST 恢复：订单簿已重新同步，遗漏的成交已补齐
+	StreamResynced StreamState = "resynced"
+)
+
+// StatusEvent 监督器发出的数据流状态信号
+type StatusEvent struct {
+	Channel string // "market" 或 "user"
+	State   StreamState
+	AssetID string    // 单个订单簿因不一致而重新同步时为其资产 ID，否则为空
+	Err     error     // 断开或重新同步的原因
+	Time    time.Time // 发出信号的时间
+}
+
+// EventType 实现 Event
+func (e *StatusEvent) EventType() string { return EventStatus }
+
+// Backoff 重连等待策略
+type Backoff struct {
+	Initial    time.Duration // 首次重连前的等待时间
+	Max        time.Duration // 单次等待时间上限
+	Multiplier float64       // 每次失败后等待时间的增长倍数
+	Jitter     float64       // 随机抖动比例（0~1），避免多个客户端同时重连
+}
+
+// DefaultBackoff 默认重连策略：500ms 起，每次翻倍，最长 30 秒
+func DefaultBackoff() Backoff {
+	return Backoff{
+		Initial:    500 * time.Millisecond,
+		Max:        30 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+}
+
+// delay 计算第 attempt 次重连（从1开始）前的等待时间
+func (b Backoff) delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(b.Initial) * math.Pow(multiplier, float64(attempt-1))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (rand.Float64()*2 - 1)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
+
+// SupervisorOption 监督器构造选项
+type SupervisorOption func(*supervisorConfig)
+
+// supervisorConfig 监督器配置
+type supervisorConfig struct {
+	marketOpts []Option
+	userCreds  *polymarket.ApiCreds
+	userOpts   []Option
+	bookOpts   []BookOption
+	backoff       Backoff
+	bufferSize    int
+	backfillPages int
+	logger        *slog.Logger
+}
+
+// WithMarketOptions 设置市场频道客户端的选项（WithHandler 会被监督器覆盖）
+func WithMarketOptions(opts ...Option) SupervisorOption {
+	return func(c *supervisorConfig) {
+		c.marketOpts = append(c.marketOpts, opts...)
+	}
+}
+
+// WithUserStream 启用用户频道，creds 通常为 ClobClient.GetCreds()（WithHandler 会被监督器覆盖）
+func WithUserStream(creds *polymarket.ApiCreds, opts ...Option) SupervisorOption {
+	return func(c *supervisorConfig) {
+		c.userCreds = creds
+		c.userOpts = append(c.userOpts, opts...)
+	}
+}
+
+// WithBookOptions 设置监督器创建的 OrderBook 的选项
+func WithBookOptions(opts ...BookOption) SupervisorOption {
+	return func(c *supervisorConfig) {
+		c.bookOpts = append(c.bookOpts, opts...)
+	}
+}
+
+// WithBackoff 设置重连等待策略
+func WithBackoff(b Backoff) SupervisorOption {
+	return func(c *supervisorConfig) {
+		c.backoff = b
+	}
+}
+
+// WithSupervisorBufferSize 设置监督器事件通道的缓冲大小
+func WithSupervisorBufferSize(size int) SupervisorOption {
+	return func(c *supervisorConfig) {
+		if size >= 0 {
+			c.bufferSize = size
+		}
+	}
+}
+
+// WithBackfillMaxPages 设置每个市场补齐成交时最多请求的页数（默认 DefaultBackfillMaxPages），
+// <= 0 表示不限制。达到上限时已获取的成交照常输出，用户频道保持 stale
+func WithBackfillMaxPages(n int) SupervisorOption {
+	return func(c *supervisorConfig) {
+		c.backfillPages = n
+	}
+}
+
+// WithSupervisorLogger 设置日志记录器，nil 表示不输出日志
+func WithSupervisorLogger(logger *slog.Logger) SupervisorOption {
+	return func(c *supervisorConfig) {
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+		c.logger = logger
+	}
+}
+
+// tradeRecord 已转发的成交状态，用于补齐时去重
+type tradeRecord struct {
+	statuses  []TradeStatus
+	matchTime time.Time
+}
+
+// Supervisor 市场和用户数据流的监督器
+//
+// 连接断开时发出 stale 信号并按 Backoff 重连，订阅自动恢复；重连后通过 GetOrderBooks
+// 重新同步所有订单簿，通过 GetTrades(After: 最近成交时间) 补齐遗漏的成交，然后发出 resynced 信号。
+// 市场事件在单独的协程中应用到订单簿，单个订单簿的 REST 重新同步不会阻塞读取；
+// 从连接到订单簿同步完成之间收到的事件先暂存，同步后按顺序应用和转发，
+// 避免 REST 快照覆盖已应用的变化。
+// 市场事件、用户事件、补齐的成交（Backfilled 为 true）和 StatusEvent 都从 Events() 输出
+type Supervisor struct {
+	source SupervisorSource
+	cfg    supervisorConfig
+	market *MarketClient
+	user   *UserClient
+	events chan Event
+	stop   chan struct{}
+
+	pruneEvery time.Duration // 丢弃过期成交记录的间隔
+
+	mu        sync.Mutex
+	ctx       context.Context
+	running   bool
+	books     map[string]*OrderBook
+	trades    map[string]*tradeRecord
+	lastTrade time.Time // 最近一次成交的撮合时间，补齐从这里开始
+	holding   bool      // 订单簿同步完成前暂存市场事件
+	queue     []Event   // 待应用的市场事件
+
+	marketWake chan struct{} // 有新的市场事件待应用
+	applyMu    sync.Mutex    // 保证市场事件按顺序应用
+}
+
+// NewSupervisor 创建监督器，未启用用户频道时只监督市场频道
+func NewSupervisor(source SupervisorSource, opts ...SupervisorOption) (*Supervisor, error) {
+	cfg := supervisorConfig{
+		backoff:       DefaultBackoff(),
+		bufferSize:    DefaultBufferSize,
+		backfillPages: DefaultBackfillMaxPages,
+		logger:        slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Supervisor{
+		source: source,
+		cfg:    cfg,
+		events: make(chan Event, cfg.bufferSize),
+		stop:   make(chan struct{}),
+		books:  make(map[string]*OrderBook),
+		trades: make(map[string]*tradeRecord),
+
+		pruneEvery: tradePruneInterval,
+		marketWake: make(chan struct{}, 1),
+	}
+	s.market = NewMarketClient(append(cfg.marketOpts, WithHandler(s.handleMarket))...)
+	if cfg.userCreds != nil {
+		user, err := NewUserClientWithCreds(cfg.userCreds, append(cfg.userOpts, WithHandler(s.handleUser))...)
+		if err != nil {
+			return nil, err
+		}
+		s.user = user
+	}
+	return s, nil
+}
+
+// SubscribeMarket 订阅资产并为其维护本地订单簿
+func (s *Supervisor) SubscribeMarket(assetIDs ...string) error {
+	s.mu.Lock()
+	for _, id := range assetIDs {
+		if _, ok := s.books[id]; !ok && id != "" {
+			s.books[id] = s.newBook(id)
+		}
+	}
+	s.mu.Unlock()
+	return s.market.Subscribe(assetIDs...)
+}
+
+// UnsubscribeMarket 取消订阅资产并丢弃其订单簿
+func (s *Supervisor) UnsubscribeMarket(assetIDs ...string) error {
+	s.mu.Lock()
+	for _, id := range assetIDs {
+		delete(s.books, id)
+	}
+	s.mu.Unlock()
+	return s.market.Unsubscribe(assetIDs...)
+}
+
+// SubscribeUser 订阅用户频道的市场（condition ID），未启用用户频道时返回 ErrNotConnected
+func (s *Supervisor) SubscribeUser(conditionIDs ...string) error {
+	if s.user == nil {
+		return ErrNotConnected
+	}
+	return s.user.Subscribe(conditionIDs...)
+}
+
+// UnsubscribeUser 取消订阅用户频道的市场
+func (s *Supervisor) UnsubscribeUser(conditionIDs ...string) error {
+	if s.user == nil {
+		return ErrNotConnected
+	}
+	return s.user.Unsubscribe(conditionIDs...)
+}
+
+// Book 返回资产的本地订单簿（未订阅时返回 nil）
+func (s *Supervisor) Book(assetID string) *OrderBook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.books[assetID]
+}
+
+// Events 返回事件通道，Run 返回后关闭
+func (s *Supervisor) Events() <-chan Event {
+	return s.events
+}
+
+// Run 连接并监督所有数据流，直到 ctx 结束；返回时关闭连接和 Events() 通道
+// Run 只能调用一次
+func (s *Supervisor) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("ws: supervisor already running")
+	}
+	s.running = true
+	s.ctx = ctx
+	if s.lastTrade.IsZero() {
+		s.lastTrade = time.Now()
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.supervise(ctx, "market", s.holdMarket, s.market.Connect, s.market.Done, s.market.Err, s.resyncBooks)
+	}()
+	go func() {
+		defer wg.Done()
+		s.marketLoop(ctx)
+	}()
+	if s.user != nil {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.supervise(ctx, "user", nil, s.user.Connect, s.user.Done, s.user.Err, s.backfillTrades)
+		}()
+		go func() {
+			defer wg.Done()
+			s.pruneLoop(ctx)
+		}()
+	}
+
+	<-ctx.Done()
+	close(s.stop)
+	s.market.Close()
+	if s.user != nil {
+		s.user.Close()
+	}
+	wg.Wait()
+	close(s.events)
+	return ctx.Err()
+}
+
+// supervise 保持一个数据流连接：连接后恢复数据并发出 resynced，断开后发出 stale 并重连
+// prepare 不为 nil 时在每次连接前调用
+func (s *Supervisor) supervise(ctx context.Context, channel string, prepare func(),
+	connect func(context.Context) error, done func() <-chan struct{}, connErr func() error,
+	restore func(context.Context) error) {
+	attempt := 0
+	for {
+		if prepare != nil {
+			prepare()
+		}
+		if err := connect(ctx); err != nil {
+			if ctx.Err() != nil || errors.Is(err, ErrClosed) {
+				return
+			}
+			attempt++
+			s.cfg.logger.WarnContext(ctx, "ws reconnect failed", "channel", channel, "attempt", attempt, "error", err)
+			if !s.sleep(ctx, s.cfg.backoff.delay(attempt)) {
+				return
+			}
+			continue
+		}
+		attempt = 0
+
+		if err := restore(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// 恢复失败时保持 stale，数据仍会继续推送，订单簿在下次不一致时重新同步
+			s.cfg.logger.WarnContext(ctx, "ws recovery failed", "channel", channel, "error", err)
+			s.emit(&StatusEvent{Channel: channel, State: StreamStale, Err: err, Time: time.Now()})
+		} else {
+			s.emit(&StatusEvent{Channel: channel, State: StreamResynced, Time: time.Now()})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-done():
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.emit(&StatusEvent{Channel: channel, State: StreamStale, Err: connErr(), Time: time.Now()})
+
+		attempt++
+		if !s.sleep(ctx, s.cfg.backoff.delay(attempt)) {
+			return
+		}
+	}
+}
+
+// resyncBooks 通过 GetOrderBooks 重新同步所有订单簿，完成（或失败）后应用暂存的市场事件
+func (s *Supervisor) resyncBooks(ctx context.Context) error {
+	defer s.releaseMarket()
+
+	s.mu.Lock()
+	params := make([]polymarket.BookParams, 0, len(s.books))
+	for id := range s.books {
+		params = append(params, polymarket.BookParams{TokenID: id})
+	}
+	s.mu.Unlock()
+	sort.Slice(params, func(i, j int) bool { return params[i].TokenID < params[j].TokenID })
+
+	for start := 0; start < len(params); start += booksBatchSize {
+		end := min(start+booksBatchSize, len(params))
+		summaries, err := s.source.GetOrderBooksWithContext(ctx, params[start:end])
+		if err != nil {
+			return err
+		}
+		for _, summary := range summaries {
+			if summary == nil {
+				continue
+			}
+			if book := s.Book(summary.AssetID); book != nil {
+				if err := book.Reset(summary); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// backfillTrades 通过 GetTrades 补齐最近成交之后遗漏的成交
+func (s *Supervisor) backfillTrades(ctx context.Context) error {
+	s.mu.Lock()
+	// 向前多取一秒，同一秒内的成交由去重处理
+	after := int(s.lastTrade.Unix()) - 1
+	s.mu.Unlock()
+
+	markets := s.user.Subscriptions()
+	if len(markets) == 0 {
+		markets = []string{""}
+	}
+
+	var (
+		trades    []polymarket.Trade
+		truncated []string
+	)
+	for _, market := range markets {
+		pager := s.source.TradesPager(&polymarket.TradeParams{Market: market, After: after},
+			polymarket.PageOptions{MaxPages: s.cfg.backfillPages})
+		page, err := pager.Collect(ctx)
+		if err != nil {
+			return err
+		}
+		trades = append(trades, page...)
+		if !pager.Done() {
+			truncated = append(truncated, market)
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].MatchTime.Before(trades[j].MatchTime) })
+
+	for _, t := range trades {
+		ev := tradeEventFromREST(t)
+		if s.observeTrade(ev) {
+			s.emit(ev)
+		}
+	}
+	s.pruneTrades()
+	if len(truncated) > 0 {
+		return fmt.Errorf("ws: trade backfill stopped after %d pages for markets %q", s.cfg.backfillPages, truncated)
+	}
+	return nil
+}
+
+// holdMarket 开始暂存市场事件，直到 resyncBooks 结束
+func (s *Supervisor) holdMarket() {
+	s.mu.Lock()
+	s.holding = true
+	s.mu.Unlock()
+}
+
+// releaseMarket 按顺序应用并转发暂存的市场事件，然后停止暂存
+// 早于 REST 快照的价位变化和 book 事件由 OrderBook 忽略
+func (s *Supervisor) releaseMarket() {
+	s.drainMarket(true)
+}
+
+// handleMarket 将市场事件加入队列，由 marketLoop 应用（在读取协程中调用，不做 REST 请求）
+func (s *Supervisor) handleMarket(ev Event) {
+	s.mu.Lock()
+	s.queue = append(s.queue, ev)
+	holding := s.holding
+	s.mu.Unlock()
+	if holding {
+		return
+	}
+	select {
+	case s.marketWake <- struct{}{}:
+	default:
+	}
+}
+
+// marketLoop 应用队列中的市场事件，直到 ctx 结束
+func (s *Supervisor) marketLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.marketWake:
+			s.drainMarket(false)
+		}
+	}
+}
+
+// drainMarket 按顺序应用队列中的市场事件，直到队列为空
+// release 为 false 时暂存期间不应用；为 true 时应用暂存的事件，队列清空后停止暂存
+func (s *Supervisor) drainMarket(release bool) {
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+	for {
+		s.mu.Lock()
+		if s.holding && !release {
+			s.mu.Unlock()
+			return
+		}
+		queue := s.queue
+		s.queue = nil
+		if len(queue) == 0 {
+			if release {
+				s.holding = false
+			}
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+		for _, ev := range queue {
+			s.applyMarket(ev)
+		}
+	}
+}
+
+// applyMarket 将市场事件应用到订单簿并转发
+func (s *Supervisor) applyMarket(ev Event) {
+	for _, book := range s.booksFor(ev) {
+		if err := book.Apply(s.context(), ev); err != nil {
+			s.cfg.logger.Warn("ws order book resync failed", "asset_id", book.AssetID(), "error", err)
+			s.emit(&StatusEvent{Channel: "market", State: StreamStale, AssetID: book.AssetID(), Err: err, Time: time.Now()})
+		}
+	}
+	s.emit(ev)
+}
+
+// handleUser 转发用户事件，已转发过的成交状态不再重复
+func (s *Supervisor) handleUser(ev Event) {
+	if trade, ok := ev.(*TradeEvent); ok && !s.observeTrade(trade) {
+		return
+	}
+	s.emit(ev)
+}
+
+// booksFor 返回事件涉及的订单簿
+func (s *Supervisor) booksFor(ev Event) []*OrderBook {
+	var ids []string
+	switch e := ev.(type) {
+	case *BookEvent:
+		ids = []string{e.AssetID}
+	case *PriceChangeEvent:
+		for _, c := range e.Changes {
+			ids = append(ids, c.AssetID)
+		}
+	case *TickSizeChangeEvent:
+		ids = []string{e.AssetID}
+	case *LastTradePriceEvent:
+		ids = []string{e.AssetID}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var books []*OrderBook
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if book, ok := s.books[id]; ok && !seen[id] {
+			seen[id] = true
+			books = append(books, book)
+		}
+	}
+	return books
+}
+
+// observeTrade 记录成交状态并推进最近成交时间，返回该状态是否首次出现
+func (s *Supervisor) observeTrade(ev *TradeEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matchTime := ev.MatchTime
+	if matchTime.IsZero() {
+		matchTime = ev.Timestamp
+	}
+	if matchTime.After(s.lastTrade) {
+		s.lastTrade = matchTime
+	}
+
+	rec, ok := s.trades[ev.ID]
+	if !ok {
+		rec = &tradeRecord{matchTime: matchTime}
+		s.trades[ev.ID] = rec
+	}
+	for _, status := range rec.statuses {
+		if status == ev.Status {
+			return false
+		}
+	}
+	if n := len(rec.statuses); n > 0 && ev.PreviousStatus == "" {
+		ev.PreviousStatus = rec.statuses[n-1]
+	}
+	rec.statuses = append(rec.statuses, ev.Status)
+	return true
+}
+
+// pruneLoop 定期丢弃过期的成交记录，直到 ctx 结束
+func (s *Supervisor) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.pruneEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.pruneTrades()
+		}
+	}
+}
+
+// pruneTrades 丢弃早于补齐起点的成交记录（之后的补齐不会再返回它们）
+func (s *Supervisor) pruneTrades() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := s.lastTrade.Add(-time.Minute)
+	for id, rec := range s.trades {
+		if rec.matchTime.Before(cutoff) {
+			delete(s.trades, id)
+		}
+	}
+}
+
+// newBook 创建订单簿，重新同步时发出 resynced 信号
+func (s *Supervisor) newBook(assetID string) *OrderBook {
+	opts := append([]BookOption{}, s.cfg.bookOpts...)
+	opts = append(opts, WithBookResyncHandler(func(assetID string, reason error) {
+		s.emit(&StatusEvent{Channel: "market", State: StreamResynced, AssetID: assetID, Err: reason, Time: time.Now()})
+	}))
+	return NewOrderBook(s.source, assetID, opts...)
+}
+
+// context 返回 Run 的 context
+func (s *Supervisor) context() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// emit 输出事件，Run 结束后丢弃
+func (s *Supervisor) emit(ev Event) {
+	select {
+	case s.events <- ev:
+	case <-s.stop:
+	}
+}
+
+// sleep 等待 d，ctx 结束时返回 false
+func (s *Supervisor) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// tradeEventFromREST 将 GetTrades 返回的成交转换为 TradeEvent
+func tradeEventFromREST(t polymarket.Trade) *TradeEvent {
+	ev := &TradeEvent{
+		ID:           t.ID,
+		Status:       TradeStatus(strings.ToUpper(string(t.Status))),
+		AssetID:      t.AssetID,
+		Market:       t.Market,
+		Outcome:      t.Outcome,
+		Side:         t.Side,
+		Price:        t.Price,
+		Size:         t.Size,
+		TakerOrderID: t.TakerOrderID,
+		Owner:        t.Owner,
+		MatchTime:    t.MatchTime,
+		LastUpdate:   t.LastUpdate,
+		Timestamp:    t.LastUpdate,
+		Backfilled:   true,
+	}
+	for _, m := range t.MakerOrders {
+		ev.MakerOrders = append(ev.MakerOrders, MakerOrder{
+			OrderID:       m.OrderID,
+			AssetID:       m.AssetID,
+			Outcome:       m.Outcome,
+			Owner:         m.Owner,
+			Price:         m.Price,
+			MatchedAmount: m.MatchedAmount,
+		})
+	}
+	return ev
+}
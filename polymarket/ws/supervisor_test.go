@@ -0,0 +1,452 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+	"github.com/wimgithub/Polymarket-golang/polymarket/pagination"
+)
+
+// fakeSource 记录调用的 SupervisorSource
+type fakeSource struct {
+	fakeBooks
+
+	booksGate   chan struct{} // 不为 nil 时 GetOrderBooks 等待其关闭
+	bookGate    chan struct{} // 不为 nil 时 GetOrderBook 先写入 bookEntered，再等待其关闭
+	bookEntered chan struct{}
+
+	mu         sync.Mutex
+	booksCalls int
+	trades     []polymarket.Trade
+	tradePages int // 成交分页数，<= 1 时只有一页
+	tradeAfter []int
+	pageCalls  int
+}
+
+func (f *fakeSource) GetOrderBookWithContext(ctx context.Context, tokenID string) (*polymarket.OrderBookSummary, error) {
+	if f.bookGate != nil {
+		f.bookEntered <- struct{}{}
+		<-f.bookGate
+	}
+	return f.fakeBooks.GetOrderBookWithContext(ctx, tokenID)
+}
+
+func (f *fakeSource) GetOrderBooksWithContext(ctx context.Context, params []polymarket.BookParams) ([]*polymarket.OrderBookSummary, error) {
+	if f.booksGate != nil {
+		<-f.booksGate
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.booksCalls++
+	out := make([]*polymarket.OrderBookSummary, 0, len(params))
+	for _, p := range params {
+		s := *f.summary
+		s.AssetID = p.TokenID
+		out = append(out, &s)
+	}
+	return out, nil
+}
+
+func (f *fakeSource) TradesPager(params *polymarket.TradeParams, opts polymarket.PageOptions) *polymarket.Pager[polymarket.Trade] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tradeAfter = append(f.tradeAfter, params.After)
+	trades, pages := f.trades, f.tradePages
+	return pagination.New(func(ctx context.Context, cursor string) ([]polymarket.Trade, string, error) {
+		f.mu.Lock()
+		f.pageCalls++
+		f.mu.Unlock()
+		// pages > 0 时每页返回同样的成交，共 pages 页
+		n, _ := strconv.Atoi(cursor)
+		if n+1 >= pages {
+			return trades, pagination.EndCursor, nil
+		}
+		return trades, strconv.Itoa(n + 1), nil
+	}, opts)
+}
+
+func (f *fakeSource) calls() (books int, tradeAfter []int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.booksCalls, append([]int(nil), f.tradeAfter...)
+}
+
+// expectEvent 等待满足条件的事件，跳过其他事件
+func expectEvent(t *testing.T, events <-chan Event, desc string, match func(Event) bool) Event {
+	t.Helper()
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				t.Fatalf("events closed while waiting for %s", desc)
+			}
+			if match(ev) {
+				return ev
+			}
+		case <-timeout:
+			t.Fatalf("timeout waiting for %s", desc)
+			return nil
+		}
+	}
+}
+
+func status(channel string, state StreamState) func(Event) bool {
+	return func(ev Event) bool {
+		e, ok := ev.(*StatusEvent)
+		return ok && e.Channel == channel && e.State == state && e.AssetID == ""
+	}
+}
+
+func tradeWith(id string, status TradeStatus) func(Event) bool {
+	return func(ev Event) bool {
+		e, ok := ev.(*TradeEvent)
+		return ok && e.ID == id && e.Status == status
+	}
+}
+
+func TestSupervisorReconnectAndRecover(t *testing.T) {
+	marketServer := newTestServer(t)
+	userServer := newTestServer(t)
+	source := &fakeSource{fakeBooks: fakeBooks{summary: testSummary()}}
+
+	sup, err := NewSupervisor(source,
+		WithMarketOptions(WithURL(marketServer.url())),
+		WithUserStream(&polymarket.ApiCreds{APIKey: "key", APISecret: "secret", APIPassphrase: "pass"}, WithURL(userServer.url())),
+		WithBookOptions(WithBookHashCheck(false)),
+		WithBackoff(Backoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sup.SubscribeMarket("111")
+	sup.SubscribeUser("0xabc")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- sup.Run(ctx) }()
+	events := sup.Events()
+
+	// 首次连接：订单簿从 GetOrderBooks 同步
+	market := marketServer.conn(t)
+	marketServer.next(t)
+	user := userServer.conn(t)
+	userServer.next(t)
+	resynced := map[string]bool{}
+	expectEvent(t, events, "market and user resynced", func(ev Event) bool {
+		if e, ok := ev.(*StatusEvent); ok && e.State == StreamResynced {
+			resynced[e.Channel] = true
+		}
+		return resynced["market"] && resynced["user"]
+	})
+	if bid, _ := sup.Book("111").BestBid(); bid.Price.String() != "0.48" {
+		t.Fatalf("book not synced, best bid %s", bid.Price)
+	}
+
+	// 实时成交推进补齐起点
+	matchTime := time.Now().Unix() + 60
+	user.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"event_type":"trade","id":"t1","status":"MATCHED",
+		"asset_id":"111","market":"0xabc","price":"0.5","size":"10","matchtime":"%d"}`, matchTime)))
+	expectEvent(t, events, "live trade", tradeWith("t1", TradeMatched))
+
+	// 市场频道断开：stale，重连后恢复订阅并重新同步订单簿
+	market.Close()
+	expectEvent(t, events, "market stale", status("market", StreamStale))
+	marketServer.conn(t)
+	if ids := assetIDs(marketServer.next(t)); len(ids) != 1 || ids[0] != "111" {
+		t.Fatalf("market subscriptions not restored: %v", ids)
+	}
+	expectEvent(t, events, "market resynced after reconnect", status("market", StreamResynced))
+	if books, _ := source.calls(); books != 2 {
+		t.Fatalf("GetOrderBooks calls = %d, want 2", books)
+	}
+
+	// 用户频道断开：补齐遗漏的成交，已转发的状态不重复
+	source.mu.Lock()
+	source.trades = []polymarket.Trade{
+		{ID: "t2", Status: polymarket.TradeStatusConfirmed, MatchTime: time.Unix(matchTime+5, 0), Price: decimal.MustParse("0.4"), Size: decimal.FromInt(3)},
+		{ID: "t1", Status: polymarket.TradeStatusMatched, MatchTime: time.Unix(matchTime, 0)},
+		{ID: "t1", Status: polymarket.TradeStatusMined, MatchTime: time.Unix(matchTime, 0)},
+	}
+	source.mu.Unlock()
+	user.Close()
+	expectEvent(t, events, "user stale", status("user", StreamStale))
+	userServer.conn(t)
+	if msg := userServer.next(t); msg["markets"].([]interface{})[0] != "0xabc" {
+		t.Fatalf("user subscriptions not restored: %v", msg)
+	}
+
+	var backfilled []*TradeEvent
+	expectEvent(t, events, "user resynced after reconnect", func(ev Event) bool {
+		if e, ok := ev.(*TradeEvent); ok {
+			backfilled = append(backfilled, e)
+		}
+		return status("user", StreamResynced)(ev)
+	})
+	if len(backfilled) != 2 {
+		t.Fatalf("backfilled %d trades, want 2", len(backfilled))
+	}
+	if e := backfilled[0]; e.ID != "t1" || e.Status != TradeMined || e.PreviousStatus != TradeMatched || !e.Backfilled {
+		t.Fatalf("unexpected backfilled trade: %+v", e)
+	}
+	if e := backfilled[1]; e.ID != "t2" || e.Price.String() != "0.4" {
+		t.Fatalf("unexpected backfilled trade: %+v", e)
+	}
+	if _, after := source.calls(); len(after) != 2 || after[1] != int(matchTime)-1 {
+		t.Fatalf("GetTrades after = %v", after)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Run returned %v", err)
+	}
+	for range events {
+	}
+}
+
+func TestSupervisorBookResyncSignal(t *testing.T) {
+	marketServer := newTestServer(t)
+	source := &fakeSource{fakeBooks: fakeBooks{summary: testSummary()}}
+	sup, err := NewSupervisor(source,
+		WithMarketOptions(WithURL(marketServer.url())),
+		WithBookOptions(WithBookHashCheck(false)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sup.SubscribeMarket("111")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sup.Run(ctx)
+	events := sup.Events()
+
+	market := marketServer.conn(t)
+	marketServer.next(t)
+	expectEvent(t, events, "market resynced", status("market", StreamResynced))
+
+	// 最优价与服务器不一致：单个订单簿从 REST 重新同步
+	market.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"price_change","market":"0xabc","timestamp":"1800000000000",
+		"price_changes":[{"asset_id":"111","price":"0.49","size":"5","side":"BUY","best_bid":"0.5","best_ask":"0.52"}]}`))
+	ev := expectEvent(t, events, "book resynced", func(ev Event) bool {
+		e, ok := ev.(*StatusEvent)
+		return ok && e.AssetID == "111"
+	}).(*StatusEvent)
+	if ev.State != StreamResynced || ev.Err == nil {
+		t.Fatalf("unexpected status: %+v", ev)
+	}
+	expectEvent(t, events, "forwarded price change", func(ev Event) bool {
+		_, ok := ev.(*PriceChangeEvent)
+		return ok
+	})
+	if bid, _ := sup.Book("111").BestBid(); bid.Price.String() != "0.48" {
+		t.Fatalf("book not restored, best bid %s", bid.Price)
+	}
+}
+
+func TestSupervisorHoldsMarketEventsDuringResync(t *testing.T) {
+	marketServer := newTestServer(t)
+	source := &fakeSource{fakeBooks: fakeBooks{summary: testSummary()}, booksGate: make(chan struct{})}
+	sup, err := NewSupervisor(source, WithMarketOptions(WithURL(marketServer.url())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sup.SubscribeMarket("111")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sup.Run(ctx)
+	events := sup.Events()
+
+	// GetOrderBooks 尚未返回时收到晚于快照的变化：先暂存，不应用也不转发
+	market := marketServer.conn(t)
+	marketServer.next(t)
+	market.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"price_change","market":"0xabc","timestamp":"1700000000100",
+		"price_changes":[{"asset_id":"111","price":"0.49","size":"5","side":"BUY","best_bid":"0.49","best_ask":"0.52"}]}`))
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		sup.mu.Lock()
+		held := len(sup.queue)
+		sup.mu.Unlock()
+		if held == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("price change not held during resync")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if sup.Book("111").Ready() {
+		t.Fatal("book applied events before the REST snapshot")
+	}
+
+	// 快照之后按顺序应用暂存的变化，快照不会覆盖它
+	close(source.booksGate)
+	expectEvent(t, events, "held price change", func(ev Event) bool {
+		_, ok := ev.(*PriceChangeEvent)
+		return ok
+	})
+	expectEvent(t, events, "market resynced", status("market", StreamResynced))
+	if bid, _ := sup.Book("111").BestBid(); bid.Price.String() != "0.49" {
+		t.Fatalf("held change lost, best bid %s", bid.Price)
+	}
+	if source.fakeBooks.calls != 0 {
+		t.Fatalf("unexpected per-book syncs: %d", source.fakeBooks.calls)
+	}
+
+	// 同步完成后事件直接应用
+	market.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"price_change","market":"0xabc","timestamp":"1700000000200",
+		"price_changes":[{"asset_id":"111","price":"0.49","size":"0","side":"BUY","best_bid":"0.48","best_ask":"0.52"}]}`))
+	expectEvent(t, events, "live price change", func(ev Event) bool {
+		_, ok := ev.(*PriceChangeEvent)
+		return ok
+	})
+	if bid, _ := sup.Book("111").BestBid(); bid.Price.String() != "0.48" {
+		t.Fatalf("live change not applied, best bid %s", bid.Price)
+	}
+}
+
+func TestSupervisorPrunesTradesOnTimer(t *testing.T) {
+	marketServer := newTestServer(t)
+	userServer := newTestServer(t)
+	source := &fakeSource{fakeBooks: fakeBooks{summary: testSummary()}}
+	sup, err := NewSupervisor(source,
+		WithMarketOptions(WithURL(marketServer.url())),
+		WithUserStream(&polymarket.ApiCreds{APIKey: "key", APISecret: "secret", APIPassphrase: "pass"}, WithURL(userServer.url())),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sup.pruneEvery = 10 * time.Millisecond
+	sup.SubscribeUser("0xabc")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sup.Run(ctx)
+	events := sup.Events()
+	marketServer.conn(t)
+	user := userServer.conn(t)
+	userServer.next(t)
+	expectEvent(t, events, "user resynced", status("user", StreamResynced))
+
+	// 没有重连和补齐时，早于最近成交一分钟以上的记录也会被丢弃
+	base := time.Now().Unix() + 60
+	for i, id := range []string{"t1", "t2"} {
+		user.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"event_type":"trade","id":"%s","status":"MATCHED",
+			"asset_id":"111","market":"0xabc","price":"0.5","size":"10","matchtime":"%d"}`, id, base+int64(i)*120)))
+		expectEvent(t, events, id, tradeWith(id, TradeMatched))
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		sup.mu.Lock()
+		_, old := sup.trades["t1"]
+		_, recent := sup.trades["t2"]
+		sup.mu.Unlock()
+		if !old && recent {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("trade records not pruned: t1 %v, t2 %v", old, recent)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSupervisorBookResyncDoesNotBlockReads(t *testing.T) {
+	marketServer := newTestServer(t)
+	source := &fakeSource{fakeBooks: fakeBooks{summary: testSummary()},
+		bookGate: make(chan struct{}), bookEntered: make(chan struct{}, 1)}
+	sup, err := NewSupervisor(source,
+		WithMarketOptions(WithURL(marketServer.url())),
+		WithBookOptions(WithBookHashCheck(false)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sup.SubscribeMarket("111")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sup.Run(ctx)
+	events := sup.Events()
+
+	market := marketServer.conn(t)
+	marketServer.next(t)
+	expectEvent(t, events, "market resynced", status("market", StreamResynced))
+
+	// 最优价不一致触发单个订单簿的 REST 同步，同步阻塞期间仍继续读取消息
+	market.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"price_change","market":"0xabc","timestamp":"1800000000000",
+		"price_changes":[{"asset_id":"111","price":"0.49","size":"5","side":"BUY","best_bid":"0.5","best_ask":"0.52"}]}`))
+	select {
+	case <-source.bookEntered:
+	case <-time.After(3 * time.Second):
+		t.Fatal("book resync not started")
+	}
+	market.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"last_trade_price","asset_id":"111","market":"0xabc",
+		"price":"0.5","size":"1","side":"BUY","timestamp":"1800000000100"}`))
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		sup.mu.Lock()
+		queued := len(sup.queue)
+		sup.mu.Unlock()
+		if queued == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("message not read while the book resync was blocked")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(source.bookGate)
+	expectEvent(t, events, "forwarded price change", func(ev Event) bool {
+		_, ok := ev.(*PriceChangeEvent)
+		return ok
+	})
+	expectEvent(t, events, "forwarded last trade price", func(ev Event) bool {
+		_, ok := ev.(*LastTradePriceEvent)
+		return ok
+	})
+}
+
+func TestSupervisorLimitsBackfillPages(t *testing.T) {
+	marketServer := newTestServer(t)
+	userServer := newTestServer(t)
+	source := &fakeSource{fakeBooks: fakeBooks{summary: testSummary()}, tradePages: 5}
+	source.trades = []polymarket.Trade{{ID: "t1", Status: polymarket.TradeStatusMatched, MatchTime: time.Now()}}
+	sup, err := NewSupervisor(source,
+		WithMarketOptions(WithURL(marketServer.url())),
+		WithUserStream(&polymarket.ApiCreds{APIKey: "key", APISecret: "secret", APIPassphrase: "pass"}, WithURL(userServer.url())),
+		WithBackfillMaxPages(2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sup.SubscribeUser("0xabc")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sup.Run(ctx)
+	events := sup.Events()
+	marketServer.conn(t)
+	userServer.conn(t)
+
+	// 达到页数上限：已获取的成交照常输出，用户频道保持 stale
+	expectEvent(t, events, "backfilled trade", tradeWith("t1", TradeMatched))
+	ev := expectEvent(t, events, "user stale", status("user", StreamStale)).(*StatusEvent)
+	if ev.Err == nil {
+		t.Fatal("expected truncation error")
+	}
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	if source.pageCalls != 2 {
+		t.Fatalf("fetched %d pages, want 2", source.pageCalls)
+	}
+}
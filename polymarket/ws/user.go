@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+)
+
+// UserClient 用户频道客户端，使用 L2 API 凭证接收自己账户的订单和成交事件
+type UserClient struct {
+	s *stream
+}
+
+// NewUserClient 使用 ClobClient 的 API 凭证创建用户频道客户端（未连接）
+// ClobClient 需要L2认证
+func NewUserClient(client *polymarket.ClobClient, opts ...Option) (*UserClient, error) {
+	if err := client.AssertLevel2Auth(); err != nil {
+		return nil, err
+	}
+	return NewUserClientWithCreds(client.GetCreds(), opts...)
+}
+
+// NewUserClientWithCreds 使用 API 凭证创建用户频道客户端（未连接）
+func NewUserClientWithCreds(creds *polymarket.ApiCreds, opts ...Option) (*UserClient, error) {
+	if creds == nil || creds.APIKey == "" {
+		return nil, fmt.Errorf(polymarket.L2AuthUnavailable)
+	}
+	auth := map[string]string{
+		"apiKey":     creds.APIKey,
+		"secret":     creds.APISecret,
+		"passphrase": creds.APIPassphrase,
+	}
+	trades := &tradeStatuses{statuses: make(map[string]TradeStatus)}
+
+	cfg := newConfig(DefaultUserURL, opts)
+	return &UserClient{s: newStream(cfg, channel{
+		name:   "user",
+		decode: trades.decode,
+		hello: func(ids []string) interface{} {
+			return map[string]interface{}{"auth": auth, "markets": ids, "type": "user"}
+		},
+		update: func(op string, ids []string) interface{} {
+			return map[string]interface{}{"markets": ids, "operation": op}
+		},
+		helloWithoutIDs: true,
+	})}, nil
+}
+
+// Connect 建立连接并订阅当前所有市场（没有订阅时接收所有市场的事件）
+// 连接断开（Done 关闭）后可再次调用以重连，订阅会自动恢复
+func (c *UserClient) Connect(ctx context.Context) error {
+	return c.s.connect(ctx)
+}
+
+// Subscribe 订阅市场（condition ID），已连接时立即生效，否则在 Connect 时发送
+func (c *UserClient) Subscribe(conditionIDs ...string) error {
+	return c.s.subscribe(conditionIDs)
+}
+
+// Unsubscribe 取消订阅市场（condition ID）
+func (c *UserClient) Unsubscribe(conditionIDs ...string) error {
+	return c.s.unsubscribe(conditionIDs)
+}
+
+// Subscriptions 返回当前订阅的 condition ID（排序后）
+func (c *UserClient) Subscriptions() []string {
+	return c.s.subscriptions()
+}
+
+// Events 返回事件通道（使用 WithHandler 时没有事件），Close 后关闭
+func (c *UserClient) Events() <-chan Event {
+	return c.s.events
+}
+
+// Done 返回当前连接断开时关闭的通道（未连接时已关闭）
+func (c *UserClient) Done() <-chan struct{} {
+	return c.s.connDone()
+}
+
+// Err 返回最近一次连接断开的原因，连接中返回 nil
+func (c *UserClient) Err() error {
+	return c.s.connErr()
+}
+
+// Close 关闭连接并停止客户端，可以在 WithHandler 回调中调用
+func (c *UserClient) Close() error {
+	return c.s.close()
+}
@@ -0,0 +1,252 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+	"github.com/wimgithub/Polymarket-golang/polymarket/decimal"
+)
+
+// 用户频道事件类型
+const (
+	EventOrder = "order"
+	EventTrade = "trade"
+)
+
+// OrderEventType 订单事件类型
+type OrderEventType string
+
+const (
+	OrderPlacement    OrderEventType = "PLACEMENT"    // 下单
+	OrderUpdate       OrderEventType = "UPDATE"       // 部分成交
+	OrderCancellation OrderEventType = "CANCELLATION" // 撤单
+)
+
+// TradeStatus 成交状态：MATCHED → MINED → CONFIRMED，上链失败时为 RETRYING，最终失败为 FAILED
+type TradeStatus = polymarket.TradeStatus
+
+const (
+	TradeMatched   = polymarket.TradeStatusMatched
+	TradeMined     = polymarket.TradeStatusMined
+	TradeConfirmed = polymarket.TradeStatusConfirmed
+	TradeRetrying  = polymarket.TradeStatusRetrying
+	TradeFailed    = polymarket.TradeStatusFailed
+)
+
+// OrderEvent 自己订单的变化
+type OrderEvent struct {
+	ID              string
+	Type            OrderEventType
+	AssetID         string
+	Market          string // condition ID
+	Outcome         string
+	Side            string // BUY / SELL
+	Price           decimal.Decimal
+	OriginalSize    decimal.Decimal
+	SizeMatched     decimal.Decimal
+	Owner           string // API key
+	OrderOwner      string
+	AssociateTrades []string // 相关成交的 ID
+	Timestamp       time.Time
+}
+
+// EventType 实现 Event
+func (e *OrderEvent) EventType() string { return EventOrder }
+
+// UnmarshalJSON 解析字符串形式的时间戳
+func (e *OrderEvent) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ID              string          `json:"id"`
+		Type            OrderEventType  `json:"type"`
+		AssetID         string          `json:"asset_id"`
+		Market          string          `json:"market"`
+		Outcome         string          `json:"outcome"`
+		Side            string          `json:"side"`
+		Price           decimal.Decimal `json:"price"`
+		OriginalSize    decimal.Decimal `json:"original_size"`
+		SizeMatched     decimal.Decimal `json:"size_matched"`
+		Owner           string          `json:"owner"`
+		OrderOwner      string          `json:"order_owner"`
+		AssociateTrades []string        `json:"associate_trades"`
+		Timestamp       unixTime        `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = OrderEvent{
+		ID:              aux.ID,
+		Type:            OrderEventType(strings.ToUpper(string(aux.Type))),
+		AssetID:         aux.AssetID,
+		Market:          aux.Market,
+		Outcome:         aux.Outcome,
+		Side:            aux.Side,
+		Price:           aux.Price,
+		OriginalSize:    aux.OriginalSize,
+		SizeMatched:     aux.SizeMatched,
+		Owner:           aux.Owner,
+		OrderOwner:      aux.OrderOwner,
+		AssociateTrades: aux.AssociateTrades,
+		Timestamp:       aux.Timestamp.Time,
+	}
+	return nil
+}
+
+// MakerOrder 成交中的 maker 订单
+type MakerOrder struct {
+	OrderID       string          `json:"order_id"`
+	AssetID       string          `json:"asset_id"`
+	Outcome       string          `json:"outcome"`
+	Owner         string          `json:"owner"`
+	Price         decimal.Decimal `json:"price"`
+	MatchedAmount decimal.Decimal `json:"matched_amount"`
+}
+
+// TradeEvent 自己参与的成交及其状态变化
+type TradeEvent struct {
+	ID             string
+	Status         TradeStatus
+	PreviousStatus TradeStatus // 此前收到的该成交的状态（首次收到时为空）
+	AssetID        string
+	Market         string // condition ID
+	Outcome        string
+	Side           string // BUY / SELL
+	Price          decimal.Decimal
+	Size           decimal.Decimal
+	TakerOrderID   string
+	MakerOrders    []MakerOrder
+	Owner          string // API key
+	TradeOwner     string
+	MatchTime      time.Time
+	LastUpdate     time.Time
+	Timestamp      time.Time
+	Backfilled     bool // 由 Supervisor 从 REST（GetTrades）补齐，而非 WebSocket 推送
+}
+
+// EventType 实现 Event
+func (e *TradeEvent) EventType() string { return EventTrade }
+
+// UnmarshalJSON 解析字符串形式的时间戳，状态统一为大写
+func (e *TradeEvent) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ID           string          `json:"id"`
+		Status       TradeStatus     `json:"status"`
+		AssetID      string          `json:"asset_id"`
+		Market       string          `json:"market"`
+		Outcome      string          `json:"outcome"`
+		Side         string          `json:"side"`
+		Price        decimal.Decimal `json:"price"`
+		Size         decimal.Decimal `json:"size"`
+		TakerOrderID string          `json:"taker_order_id"`
+		MakerOrders  []MakerOrder    `json:"maker_orders"`
+		Owner        string          `json:"owner"`
+		TradeOwner   string          `json:"trade_owner"`
+		MatchTime    unixTime        `json:"matchtime"`
+		LastUpdate   unixTime        `json:"last_update"`
+		Timestamp    unixTime        `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = TradeEvent{
+		ID:           aux.ID,
+		Status:       TradeStatus(strings.ToUpper(string(aux.Status))),
+		AssetID:      aux.AssetID,
+		Market:       aux.Market,
+		Outcome:      aux.Outcome,
+		Side:         aux.Side,
+		Price:        aux.Price,
+		Size:         aux.Size,
+		TakerOrderID: aux.TakerOrderID,
+		MakerOrders:  aux.MakerOrders,
+		Owner:        aux.Owner,
+		TradeOwner:   aux.TradeOwner,
+		MatchTime:    aux.MatchTime.Time,
+		LastUpdate:   aux.LastUpdate.Time,
+		Timestamp:    aux.Timestamp.Time,
+	}
+	return nil
+}
+
+// UserHandlers 按事件类型分发的回调，未设置的回调忽略对应事件
+type UserHandlers struct {
+	Order func(*OrderEvent)
+	Trade func(*TradeEvent)
+	// Other 接收其他事件（如 UnknownEvent）
+	Other func(Event)
+}
+
+// Handle 将事件分发给对应的回调
+func (h UserHandlers) Handle(ev Event) {
+	switch e := ev.(type) {
+	case *OrderEvent:
+		if h.Order != nil {
+			h.Order(e)
+		}
+	case *TradeEvent:
+		if h.Trade != nil {
+			h.Trade(e)
+		}
+	default:
+		if h.Other != nil {
+			h.Other(ev)
+		}
+	}
+}
+
+// tradeStatuses 记录未完成成交的最近状态，用于填充 PreviousStatus
+type tradeStatuses struct {
+	mu       sync.Mutex
+	statuses map[string]TradeStatus
+}
+
+// observe 记录成交的新状态并返回此前的状态，最终状态的成交不再记录
+func (t *tradeStatuses) observe(id string, status TradeStatus) TradeStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev := t.statuses[id]
+	if status.IsFinal() {
+		delete(t.statuses, id)
+	} else {
+		t.statuses[id] = status
+	}
+	return prev
+}
+
+// decode 解码用户频道的一条消息
+func (t *tradeStatuses) decode(data []byte) ([]Event, error) {
+	items, err := splitMessages(data)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(items))
+	for _, raw := range items {
+		typ, err := eventType(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case EventOrder:
+			ev := &OrderEvent{}
+			if err := json.Unmarshal(raw, ev); err != nil {
+				return nil, fmt.Errorf("decode %s event: %w", typ, err)
+			}
+			events = append(events, ev)
+		case EventTrade:
+			ev := &TradeEvent{}
+			if err := json.Unmarshal(raw, ev); err != nil {
+				return nil, fmt.Errorf("decode %s event: %w", typ, err)
+			}
+			ev.PreviousStatus = t.observe(ev.ID, ev.Status)
+			events = append(events, ev)
+		default:
+			events = append(events, &UnknownEvent{Type: typ, Raw: raw})
+		}
+	}
+	return events, nil
+}
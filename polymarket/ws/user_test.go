@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/wimgithub/Polymarket-golang/polymarket"
+)
+
+func TestUserClientAuthAndEvents(t *testing.T) {
+	ts := newTestServer(t)
+	client, err := NewUserClientWithCreds(&polymarket.ApiCreds{
+		APIKey:        "key",
+		APISecret:     "secret",
+		APIPassphrase: "pass",
+	}, WithURL(ts.url()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// 没有订阅时也发送认证消息
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server := ts.conn(t)
+	hello := ts.next(t)
+	auth, _ := hello["auth"].(map[string]interface{})
+	if hello["type"] != "user" || auth["apiKey"] != "key" || auth["secret"] != "secret" || auth["passphrase"] != "pass" {
+		t.Fatalf("unexpected auth message: %v", hello)
+	}
+
+	if err := client.Subscribe("0xabc"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := ts.next(t); msg["operation"] != "subscribe" || msg["markets"].([]interface{})[0] != "0xabc" {
+		t.Fatalf("unexpected subscribe: %v", msg)
+	}
+
+	server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"order","type":"PLACEMENT","id":"0xo1",
+		"asset_id":"111","market":"0xabc","outcome":"YES","side":"BUY","price":"0.57","original_size":"10",
+		"size_matched":"0","owner":"key","associate_trades":null,"timestamp":"1672290687"}`))
+	order, ok := nextEvent(t, client.Events()).(*OrderEvent)
+	if !ok {
+		t.Fatal("expected *OrderEvent")
+	}
+	if order.Type != OrderPlacement || order.Price.String() != "0.57" || order.Timestamp.Unix() != 1672290687 {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+
+	trade := func(status string) *TradeEvent {
+		server.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"trade","type":"TRADE","id":"t1",
+			"status":"`+status+`","asset_id":"111","market":"0xabc","side":"BUY","price":"0.57","size":"10",
+			"taker_order_id":"0xo1","maker_orders":[{"order_id":"0xo2","matched_amount":"10","price":"0.57"}],
+			"matchtime":"1672290701","timestamp":"1672290701"}`))
+		ev, ok := nextEvent(t, client.Events()).(*TradeEvent)
+		if !ok {
+			t.Fatal("expected *TradeEvent")
+		}
+		return ev
+	}
+
+	transitions := []struct {
+		status   string
+		previous TradeStatus
+	}{
+		{"MATCHED", ""},
+		{"MINED", TradeMatched},
+		{"RETRYING", TradeMined},
+		{"CONFIRMED", TradeRetrying},
+		{"MATCHED", ""}, // 最终状态后不再记录
+	}
+	for _, tt := range transitions {
+		ev := trade(tt.status)
+		if string(ev.Status) != tt.status || ev.PreviousStatus != tt.previous {
+			t.Fatalf("status %s: got %s (previous %q), want previous %q", tt.status, ev.Status, ev.PreviousStatus, tt.previous)
+		}
+		if len(ev.MakerOrders) != 1 || ev.MakerOrders[0].MatchedAmount.String() != "10" {
+			t.Fatalf("unexpected maker orders: %+v", ev.MakerOrders)
+		}
+	}
+}
+
+func TestNewUserClientRequiresCreds(t *testing.T) {
+	if _, err := NewUserClientWithCreds(nil); err == nil {
+		t.Fatal("expected error without creds")
+	}
+}